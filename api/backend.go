@@ -0,0 +1,55 @@
+package api
+
+import (
+	"time"
+
+	"github.com/tez-capital/tezpay/common"
+)
+
+type Status struct {
+	Version               string    `json:"version"`
+	StartedAt             time.Time `json:"started_at"`
+	DryRun                bool      `json:"dry_run"`
+	Paused                bool      `json:"paused"`
+	Processing            bool      `json:"processing"`
+	LastProcessedCycle    int64     `json:"last_processed_cycle"`
+	OnchainCompletedCycle int64     `json:"onchain_completed_cycle"`
+	PendingNotifications  int       `json:"pending_notifications"`
+}
+
+type CycleReports struct {
+	Cycle   int64                      `json:"cycle"`
+	Summary *common.CyclePayoutSummary `json:"summary,omitempty"`
+	Payouts []common.PayoutReport      `json:"payouts"`
+}
+
+type PayoutResult struct {
+	Cycle       int64                        `json:"cycle"`
+	DryRun      bool                         `json:"dry_run"`
+	Finished    bool                         `json:"finished"`
+	Error       string                       `json:"error,omitempty"`
+	Preparation *common.PreparePayoutsResult `json:"preparation"`
+	Execution   *common.ExecutePayoutsResult `json:"execution,omitempty"`
+}
+
+type AdminNotification struct {
+	Id        int64     `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// Backend is implemented by the running daemon, api server only translates http requests to it
+type Backend interface {
+	GetStatus() Status
+	GetCycleReports(cycle int64) (*CycleReports, error)
+	GenerateBlueprint(cycle int64) (*common.CyclePayoutBlueprint, error)
+	// ExecutePayout starts payout of cycle in background, progress is available through GetLastPayout
+	ExecutePayout(cycle int64, dryRun bool) error
+	// GetLastPayout returns the last payout started through api or nil if there was none
+	GetLastPayout() *PayoutResult
+	Pause()
+	Resume()
+	GetAdminNotifications() []AdminNotification
+	// AcknowledgeAdminNotifications removes notifications up to id (all if 0) and returns their count
+	AcknowledgeAdminNotifications(upToId int64) int
+}
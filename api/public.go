@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type PublicRewardsLookup func(delegator tezos.Address) (*common.PublicDelegatorRewards, error)
+
+type PublicAppOptions struct {
+	Baker tezos.Address
+	// RateLimit is number of requests allowed per minute from single ip
+	RateLimit int
+}
+
+type publicPage struct {
+	Baker   tezos.Address
+	Address string
+	Error   string
+	Rewards *common.PublicDelegatorRewards
+}
+
+var publicPageTemplate = template.Must(template.New("rewards").Funcs(template.FuncMap{
+	"tez": func(amount tezos.Z) string {
+		return fmt.Sprintf("%.6f", float64(amount.Int64())/constants.MUTEZ_FACTOR)
+	},
+	"percent": func(ratio float64) string {
+		return fmt.Sprintf("%.2f%%", ratio*100)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rewards of {{.Baker}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+input[type=text] { width: 28em; max-width: 100%; padding: 0.4em; font-family: monospace; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; margin-bottom: 2em; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.4em 0.6em; text-align: left; }
+td.amount, th.amount { text-align: right; font-variant-numeric: tabular-nums; }
+.error { color: #b00020; }
+.hash { font-family: monospace; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>Rewards lookup</h1>
+<p>Baker <span class="hash">{{.Baker}}</span></p>
+<form method="get">
+<input type="text" name="address" placeholder="tz1..." value="{{.Address}}">
+<button type="submit">Look up</button>
+</form>
+{{- if .Error}}
+<p class="error">{{.Error}}</p>
+{{- end}}
+{{- with .Rewards}}
+<h2>Cycles {{.FirstCycle}} - {{.LastCycle}}</h2>
+<p>Total paid: {{tez .TotalPaid}} TEZ, pending: {{tez .TotalPending}} TEZ, effective fee: {{percent .EffectiveFee}}</p>
+<h3>Paid</h3>
+<table>
+<thead><tr><th>Cycle</th><th>Kind</th><th class="amount">Delegated balance (TEZ)</th><th class="amount">Amount (TEZ)</th><th class="amount">Fee (TEZ)</th><th>Operation</th></tr></thead>
+<tbody>
+{{- range .Paid}}
+<tr><td>{{.Cycle}}</td><td>{{.Kind}}</td><td class="amount">{{tez .DelegatedBalance}}</td><td class="amount">{{tez .Amount}}</td><td class="amount">{{tez .Fee}}</td><td class="hash">{{if .PaidWithCycle}}paid with cycle {{.PaidWithCycle}}{{else if .ExplorerUrl}}<a href="{{.ExplorerUrl}}" rel="noopener">{{.OpHash}}</a>{{else}}{{.OpHash}}{{end}}</td></tr>
+{{- else}}
+<tr><td colspan="6">no paid rewards found</td></tr>
+{{- end}}
+</tbody>
+</table>
+<h3>Pending</h3>
+<table>
+<thead><tr><th>Cycle</th><th>Kind</th><th>Status</th><th class="amount">Amount (TEZ)</th></tr></thead>
+<tbody>
+{{- range .Pending}}
+<tr><td>{{.Cycle}}</td><td>{{.Kind}}</td><td>{{.Status}}</td><td class="amount">{{tez .Amount}}</td></tr>
+{{- else}}
+<tr><td colspan="4">nothing pending</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+func parseDelegatorAddress(address string) (tezos.Address, error) {
+	delegator, err := tezos.ParseAddress(address)
+	if err != nil || !delegator.IsValid() {
+		return tezos.InvalidAddress, errors.Join(constants.ErrInvalidApiRequest, fmt.Errorf("invalid address - %s", address))
+	}
+	return delegator, nil
+}
+
+// NewPublicApp creates read only delegator facing rewards lookup with html page and json endpoint
+func NewPublicApp(lookup PublicRewardsLookup, options PublicAppOptions) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	if options.RateLimit > 0 {
+		app.Use(limiter.New(limiter.Config{
+			Max:        options.RateLimit,
+			Expiration: time.Minute,
+			LimitReached: func(c *fiber.Ctx) error {
+				return respondWithError(c, fiber.StatusTooManyRequests, errors.New("too many requests"))
+			},
+		}))
+	}
+
+	app.Get("/", func(c *fiber.Ctx) error {
+		page := publicPage{Baker: options.Baker, Address: c.Query("address")}
+		status := fiber.StatusOK
+		if page.Address != "" {
+			delegator, err := parseDelegatorAddress(page.Address)
+			if err == nil {
+				page.Rewards, err = lookup(delegator)
+			}
+			if err != nil {
+				slog.Debug("rewards lookup failed", "address", page.Address, "error", err.Error())
+				status, page.Error = fiber.StatusBadRequest, "invalid address"
+				if !errors.Is(err, constants.ErrInvalidApiRequest) {
+					status, page.Error = fiber.StatusInternalServerError, "rewards are not available right now"
+				}
+			}
+		}
+		var buffer bytes.Buffer
+		if err := publicPageTemplate.Execute(&buffer, page); err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Status(status).Send(buffer.Bytes())
+	})
+	app.Get("/rewards/:address", func(c *fiber.Ctx) error {
+		delegator, err := parseDelegatorAddress(c.Params("address"))
+		if err != nil {
+			return respondWithError(c, fiber.StatusBadRequest, err)
+		}
+		rewards, err := lookup(delegator)
+		if err != nil {
+			slog.Debug("rewards lookup failed", "address", delegator, "error", err.Error())
+			return respondWithError(c, fiber.StatusInternalServerError, errors.New("rewards are not available right now"))
+		}
+		return c.JSON(rewards)
+	})
+
+	return app
+}
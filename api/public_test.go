@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestPublicRewardsApp(t *testing.T) {
+	assert := assert.New(t)
+
+	baker := mock.GetRandomAddress()
+	delegator := mock.GetRandomAddress()
+	opHash := tezos.NewOpHash([]byte("01234567890123456789012345678901"))
+	failedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	reports := []common.PayoutReport{
+		{Cycle: 100, Timestamp: failedAt, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, TxKind: enums.PAYOUT_TX_KIND_TEZ, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(900_000)},
+		{Cycle: 100, Timestamp: failedAt.Add(time.Hour), Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, TxKind: enums.PAYOUT_TX_KIND_TEZ, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(900_000), Fee: tezos.NewZ(100_000), OpHash: opHash, IsSuccess: true, Note: "internal"},
+		{Cycle: 100, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, TxKind: enums.PAYOUT_TX_KIND_FA2, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(50_000_000), IsSuccess: true},
+		{Cycle: 101, Kind: enums.PAYOUT_KIND_ACCUMULATED, TxKind: enums.PAYOUT_TX_KIND_TEZ, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(5_000), Note: "0123456789abcdef#102"},
+		{Cycle: 101, Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, TxKind: enums.PAYOUT_TX_KIND_TEZ, Delegator: delegator, Recipient: delegator, Amount: tezos.NewZ(7_000)},
+		{Cycle: 101, Kind: enums.PAYOUT_KIND_FEE_INCOME, TxKind: enums.PAYOUT_TX_KIND_TEZ, Delegator: delegator, Recipient: baker, Amount: tezos.NewZ(100_000), IsSuccess: true},
+	}
+	app := NewPublicApp(func(address tezos.Address) (*common.PublicDelegatorRewards, error) {
+		return core.BuildPublicDelegatorRewards(reports, address, 100, 101, "https://tzkt.io/"), nil
+	}, PublicAppOptions{Baker: baker, RateLimit: 3})
+	get := func(target string) (int, []byte) {
+		resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+		assert.Nil(err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, body
+	}
+
+	status, body := get("/rewards/" + delegator.String())
+	assert.Equal(200, status)
+	assert.NotContains(string(body), baker.String())
+	assert.NotContains(string(body), "internal")
+	var rewards common.PublicDelegatorRewards
+	assert.Nil(json.Unmarshal(body, &rewards))
+	assert.Len(rewards.Paid, 2)
+	assert.Equal(common.PUBLIC_REWARD_STATUS_ACCUMULATED, rewards.Paid[0].Status)
+	assert.Equal(int64(102), rewards.Paid[0].PaidWithCycle)
+	assert.Equal("https://tzkt.io/"+opHash.String(), rewards.Paid[1].ExplorerUrl)
+	assert.Equal(int64(900_000), rewards.TotalPaid.Int64())
+	t.Log("failed payout retried successfully is not pending")
+	assert.Len(rewards.Pending, 1)
+	assert.Equal(common.PUBLIC_REWARD_STATUS_FAILED, rewards.Pending[0].Status)
+	assert.Equal(int64(7_000), rewards.TotalPending.Int64())
+	assert.InDelta(0.1, rewards.EffectiveFee, 1e-9)
+
+	status, _ = get("/rewards/invalid")
+	assert.Equal(400, status)
+	status, body = get("/?address=" + delegator.String())
+	assert.Equal(200, status)
+	assert.Contains(string(body), "0.900000")
+
+	status, _ = get("/")
+	assert.Equal(429, status)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+const (
+	API_PREFIX = "/api/v1"
+)
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func respondWithError(c *fiber.Ctx, status int, err error) error {
+	return c.Status(status).JSON(errorResponse{Error: err.Error()})
+}
+
+func getErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrPayoutAlreadyInProgress):
+		return fiber.StatusConflict
+	case errors.Is(err, constants.ErrNoCycleDataAvailable):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+func getCycleParam(c *fiber.Ctx) (int64, error) {
+	cycle, err := strconv.ParseInt(c.Params("cycle"), 10, 64)
+	if err != nil || cycle <= 0 {
+		return 0, errors.Join(constants.ErrInvalidApiRequest, errors.New("cycle has to be positive number"))
+	}
+	return cycle, nil
+}
+
+func authenticate(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		provided, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return respondWithError(c, fiber.StatusUnauthorized, constants.ErrApiUnauthorized)
+		}
+		return c.Next()
+	}
+}
+
+// NewApp creates management api, all routes require bearer token
+func NewApp(backend Backend, token string) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Use(recover.New())
+
+	router := app.Group(API_PREFIX, authenticate(token))
+	router.Get("/status", func(c *fiber.Ctx) error {
+		return c.JSON(backend.GetStatus())
+	})
+	router.Get("/reports/:cycle", func(c *fiber.Ctx) error {
+		cycle, err := getCycleParam(c)
+		if err != nil {
+			return respondWithError(c, fiber.StatusBadRequest, err)
+		}
+		reports, err := backend.GetCycleReports(cycle)
+		if err != nil {
+			return respondWithError(c, getErrorStatus(err), err)
+		}
+		return c.JSON(reports)
+	})
+	router.Get("/blueprints/:cycle", func(c *fiber.Ctx) error {
+		cycle, err := getCycleParam(c)
+		if err != nil {
+			return respondWithError(c, fiber.StatusBadRequest, err)
+		}
+		blueprint, err := backend.GenerateBlueprint(cycle)
+		if err != nil {
+			return respondWithError(c, getErrorStatus(err), err)
+		}
+		return c.JSON(blueprint)
+	})
+	router.Post("/payouts/:cycle", func(c *fiber.Ctx) error {
+		cycle, err := getCycleParam(c)
+		if err != nil {
+			return respondWithError(c, fiber.StatusBadRequest, err)
+		}
+		dryRun := c.QueryBool("dry_run", false)
+		slog.Info("payout requested through api", "cycle", cycle, "dry_run", dryRun)
+		if err := backend.ExecutePayout(cycle, dryRun); err != nil {
+			return respondWithError(c, getErrorStatus(err), err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(backend.GetLastPayout())
+	})
+	router.Get("/payouts/last", func(c *fiber.Ctx) error {
+		result := backend.GetLastPayout()
+		if result == nil {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+		return c.JSON(result)
+	})
+	router.Post("/pause", func(c *fiber.Ctx) error {
+		backend.Pause()
+		return c.JSON(backend.GetStatus())
+	})
+	router.Post("/resume", func(c *fiber.Ctx) error {
+		backend.Resume()
+		return c.JSON(backend.GetStatus())
+	})
+	router.Get("/notifications", func(c *fiber.Ctx) error {
+		return c.JSON(backend.GetAdminNotifications())
+	})
+	router.Delete("/notifications", func(c *fiber.Ctx) error {
+		upToId := int64(c.QueryInt("up_to", 0))
+		return c.JSON(fiber.Map{"acknowledged": backend.AcknowledgeAdminNotifications(upToId)})
+	})
+
+	return app
+}
+
+// Start launches management api at specified address
+func Start(backend Backend, token string, address string) {
+	app := NewApp(backend, token)
+	go func() {
+		slog.Info("starting management api", "address", address)
+		if err := app.Listen(address); err != nil {
+			slog.Error("management api failed", "error", err.Error())
+		}
+	}()
+}
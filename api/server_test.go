@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+type testBackend struct {
+	paused        bool
+	payoutCycle   int64
+	payoutDryRun  bool
+	notifications []AdminNotification
+}
+
+func (b *testBackend) GetStatus() Status {
+	return Status{Paused: b.paused, LastProcessedCycle: 100}
+}
+
+func (b *testBackend) GetCycleReports(cycle int64) (*CycleReports, error) {
+	if cycle == 666 {
+		panic("failed to read reports")
+	}
+	return &CycleReports{Cycle: cycle, Payouts: []common.PayoutReport{}}, nil
+}
+
+func (b *testBackend) GenerateBlueprint(cycle int64) (*common.CyclePayoutBlueprint, error) {
+	return nil, constants.ErrNoCycleDataAvailable
+}
+
+func (b *testBackend) ExecutePayout(cycle int64, dryRun bool) error {
+	if b.payoutCycle != 0 {
+		return constants.ErrPayoutAlreadyInProgress
+	}
+	b.payoutCycle, b.payoutDryRun = cycle, dryRun
+	return nil
+}
+
+func (b *testBackend) GetLastPayout() *PayoutResult {
+	if b.payoutCycle == 0 {
+		return nil
+	}
+	return &PayoutResult{Cycle: b.payoutCycle, DryRun: b.payoutDryRun}
+}
+
+func (b *testBackend) Pause()  { b.paused = true }
+func (b *testBackend) Resume() { b.paused = false }
+
+func (b *testBackend) GetAdminNotifications() []AdminNotification {
+	return b.notifications
+}
+
+func (b *testBackend) AcknowledgeAdminNotifications(upToId int64) int {
+	count := len(b.notifications)
+	b.notifications = nil
+	return count
+}
+
+func TestManagementApi(t *testing.T) {
+	assert := assert.New(t)
+
+	backend := &testBackend{notifications: []AdminNotification{{Id: 1, Message: "payouts failed"}}}
+	app := NewApp(backend, "secret")
+	request := func(method string, target string, token string) (int, map[string]any) {
+		req := httptest.NewRequest(method, API_PREFIX+target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		assert.Nil(err)
+		body, _ := io.ReadAll(resp.Body)
+		var result map[string]any
+		json.Unmarshal(body, &result)
+		return resp.StatusCode, result
+	}
+
+	status, _ := request("GET", "/status", "")
+	assert.Equal(401, status)
+	status, _ = request("GET", "/status", "wrong")
+	assert.Equal(401, status)
+	status, body := request("GET", "/status", "secret")
+	assert.Equal(200, status)
+	assert.Equal(float64(100), body["last_processed_cycle"])
+
+	status, body = request("POST", "/pause", "secret")
+	assert.Equal(200, status)
+	assert.Equal(true, body["paused"])
+	status, body = request("POST", "/resume", "secret")
+	assert.Equal(200, status)
+	assert.Equal(false, body["paused"])
+
+	status, _ = request("GET", "/reports/abc", "secret")
+	assert.Equal(400, status)
+	status, _ = request("GET", "/reports/666", "secret")
+	assert.Equal(500, status)
+	status, _ = request("GET", "/blueprints/500", "secret")
+	assert.Equal(404, status)
+
+	status, _ = request("GET", "/payouts/last", "secret")
+	assert.Equal(204, status)
+	status, body = request("POST", "/payouts/500?dry_run=true", "secret")
+	assert.Equal(202, status)
+	assert.Equal(float64(500), body["cycle"])
+	assert.Equal(int64(500), backend.payoutCycle)
+	assert.True(backend.payoutDryRun)
+	status, _ = request("POST", "/payouts/501", "secret")
+	assert.Equal(409, status)
+	status, body = request("GET", "/payouts/last", "secret")
+	assert.Equal(200, status)
+	assert.Equal(true, body["dry_run"])
+
+	status, body = request("DELETE", "/notifications", "secret")
+	assert.Equal(200, status)
+	assert.Equal(float64(1), body["acknowledged"])
+}
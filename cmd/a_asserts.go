@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"log/slog"
-	"os"
 )
 
 func assertRunWithErrorMessage(toExecute func() error, exitCode int, msg string, args ...any) {
@@ -10,7 +9,7 @@ func assertRunWithErrorMessage(toExecute func() error, exitCode int, msg string,
 	if err != nil {
 		args = append(args, "error", err.Error())
 		slog.Error(msg, args...)
-		os.Exit(exitCode)
+		exit(exitCode)
 	}
 }
 
@@ -19,7 +18,7 @@ func assertRunWithParamAndErrorMessage[T any](toExecute func(T) error, param T,
 	if err != nil {
 		args = append(args, "error", err.Error())
 		slog.Error(msg, args...)
-		os.Exit(exitCode)
+		exit(exitCode)
 	}
 }
 
@@ -28,7 +27,7 @@ func assertRunWithResultAndErrorMessage[T any](toExecute func() (T, error), exit
 	if err != nil {
 		args = append(args, "error", err.Error())
 		slog.Error(msg, args...)
-		os.Exit(exitCode)
+		exit(exitCode)
 	}
 	return result
 }
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/core"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+)
+
+// autoStakeIncome stakes configured share of the cycle income and records the stake in the cycle summary.
+// Cycles with stake already recorded are skipped. Failures are only reported, payouts are already done at this point.
+func autoStakeIncome(context *configurationAndEngines, summary *common.CyclePayoutSummary, reporter common.ReporterEngine, confirmations int64) {
+	config, collector, _, transactor := context.Unwrap()
+	autoStake := config.PayoutConfiguration.AutoStake
+	if autoStake == nil {
+		return
+	}
+	if existing, err := reporter.GetExistingCycleSummary(summary.Cycle); err == nil && existing.AutoStake != nil {
+		slog.Info("income already staked", "cycle", summary.Cycle, "op_hash", existing.AutoStake.OpHash.String(), "phase", "auto_stake")
+		return
+	}
+
+	signer, err := signer_engines.Load(autoStake.Signer)
+	if err != nil {
+		slog.Error("failed to load auto stake signer", "error", err.Error(), "phase", "auto_stake")
+		notifyAdmin(config, fmt.Sprintf("Failed to stake income of cycle %d - %s", summary.Cycle, err.Error()))
+		return
+	}
+	result, err := core.AutoStakeIncome(autoStake, summary, collector, signer, transactor, confirmations)
+	if err != nil {
+		slog.Error("failed to stake income", "cycle", summary.Cycle, "error", err.Error(), "phase", "auto_stake")
+		notifyAdmin(config, fmt.Sprintf("Failed to stake income of cycle %d - %s", summary.Cycle, err.Error()))
+		return
+	}
+	if result == nil {
+		return
+	}
+	slog.Info("income staked", "cycle", summary.Cycle, "source", result.Source.String(), "amount", common.MutezToTezS(result.Amount.Int64()), "op_hash", result.OpHash.String(), "phase", "auto_stake")
+
+	summary.AutoStake = result
+	if err := reporter.ReportCycleSummary(*summary); err != nil {
+		slog.Warn("failed to record stake in cycle summary", "cycle", summary.Cycle, "error", err.Error())
+	}
+}
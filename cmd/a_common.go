@@ -3,19 +3,24 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants"
 	collector_engines "github.com/tez-capital/tezpay/engines/collector"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
 	signer_engines "github.com/tez-capital/tezpay/engines/signer"
 	transactor_engines "github.com/tez-capital/tezpay/engines/transactor"
 	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/tezos"
@@ -26,12 +31,49 @@ type configurationAndEngines struct {
 	Collector     common.CollectorEngine
 	Signer        common.SignerEngine
 	Transactor    common.TransactorEngine
+
+	MultisigSigners []common.SignerEngine
+	// TopUp is loaded only by commands paying out without operator, see continual
+	TopUp common.TopUpEngine
+	// Reporter is shared by all cycles processed in continual mode
+	Reporter common.ReporterEngine
 }
 
 func (cae *configurationAndEngines) Unwrap() (*configuration.RuntimeConfiguration, common.CollectorEngine, common.SignerEngine, common.TransactorEngine) {
 	return cae.Configuration, cae.Collector, cae.Signer, cae.Transactor
 }
 
+func (cae *configurationAndEngines) NewExecutePayoutsEngineContext(reporter common.ReporterEngine) *common.ExecutePayoutsEngineContext {
+	return common.NewExecutePayoutsEngineContext(cae.Signer, cae.Transactor, reporter, notifyAdminFactory(cae.Configuration)).WithCollector(cae.Collector).WithMultisigSigners(cae.MultisigSigners)
+}
+
+func (cae *configurationAndEngines) NewGeneratePayoutsEngineContext() *common.GeneratePayoutsEngineContext {
+	return common.NewGeneratePayoutsEngines(cae.Collector, cae.Signer, notifyAdminFactory(cae.Configuration)).WithTopUp(cae.TopUp)
+}
+
+// loadedReporters are closed on exit, see closeReporters
+var loadedReporters []common.ReporterEngine
+
+func loadReporter(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) common.ReporterEngine {
+	reporter := assertRunWithResultAndErrorMessage(func() (common.ReporterEngine, error) {
+		return reporter_engines.Load(config, options)
+	}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load reporter engine", "engine", config.Reports.Engine)
+	loadedReporters = append(loadedReporters, reporter)
+	return reporter
+}
+
+// closeReporters retries pending sink writes and releases resources of all loaded reporters
+func closeReporters() {
+	for _, reporter := range loadedReporters {
+		if closer, ok := reporter.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				slog.Warn("failed to close reporter", "error", err.Error())
+			}
+		}
+	}
+	loadedReporters = nil
+}
+
 func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 	config, err := configuration.Load()
 	if err != nil {
@@ -57,6 +99,14 @@ func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 		return nil, err
 	}
 
+	var multisigSigners []common.SignerEngine
+	if config.PayoutConfiguration.Multisig != nil {
+		multisigSigners, err = signer_engines.LoadMultisigSigners(config.PayoutConfiguration.Multisig.Signers)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+		}
+	}
+
 	if utils.IsTty() {
 		slog.Debug("loaded configuration", "configuration", config)
 	}
@@ -75,6 +125,8 @@ func loadConfigurationEnginesExtensions() (*configurationAndEngines, error) {
 		Collector:     collector,
 		Signer:        signerEngine,
 		Transactor:    transactorEngine,
+
+		MultisigSigners: multisigSigners,
 	}, nil
 }
 
@@ -113,6 +165,94 @@ func writePayoutBlueprintToFile(toFile string, blueprint *common.CyclePayoutBlue
 	return nil
 }
 
+func loadOfflineSigningBundleFromFile(fromFile string) (*common.OfflineSigningBundle, error) {
+	slog.Info("reading offline signing bundle from file", "path", fromFile)
+	data, err := os.ReadFile(fromFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleLoadFailed, err)
+	}
+	bundle := &common.OfflineSigningBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleLoadFailed, err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func writeOfflineSigningBundleToFile(toFile string, bundle *common.OfflineSigningBundle) error {
+	slog.Info("writing offline signing bundle to file", "path", toFile)
+	data, err := json.MarshalIndent(bundle, "", "\t")
+	if err != nil {
+		return errors.Join(constants.ErrOfflineBundleSaveFailed, err)
+	}
+	if err := os.WriteFile(toFile, data, 0644); err != nil {
+		return errors.Join(constants.ErrOfflineBundleSaveFailed, err)
+	}
+	return nil
+}
+
+// loadReportsOfCycles reads reports of the cycles, cycles without reports are skipped
+func loadReportsOfCycles(reporter common.ReporterEngine, cycles []int64) []common.PayoutReport {
+	reports := make([]common.PayoutReport, 0)
+	for _, cycle := range cycles {
+		cycleReports, err := reporter.GetExistingReports(cycle)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				slog.Warn("failed to read reports", "cycle", cycle, "error", err.Error())
+			}
+			continue
+		}
+		reports = append(reports, cycleReports...)
+	}
+	return reports
+}
+
+// assertNotPaidOut exits if any of the payouts was already reported as paid out, matches reports the same way as payout preparation
+func assertNotPaidOut(payouts []common.PayoutRecipe, cycles []int64, config *configuration.RuntimeConfiguration, collector common.CollectorEngine, reporter common.ReporterEngine) {
+	for _, cycle := range cycles {
+		reports, err := reporter.GetExistingReports(cycle)
+		if err != nil && !os.IsNotExist(err) {
+			slog.Error("failed to read past reports", "cycle", cycle, "error", err.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+		cyclePayouts := lo.Filter(payouts, func(p common.PayoutRecipe, _ int) bool { return p.Cycle == cycle })
+		if unpaid, _ := utils.FilterRecipesByBakerReports(cyclePayouts, reports, config.BakerPKH, collector); len(unpaid) != len(cyclePayouts) {
+			slog.Error("some of the payouts were already paid out, refusing to continue", "cycle", cycle)
+			exit(EXIT_OPERTION_FAILED)
+		}
+	}
+}
+
+func loadMultisigProposalBundleFromFile(fromFile string) (*common.MultisigProposalBundle, error) {
+	slog.Info("reading multisig proposal bundle from file", "path", fromFile)
+	data, err := os.ReadFile(fromFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrMultisigBundleLoadFailed, err)
+	}
+	bundle := &common.MultisigProposalBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, errors.Join(constants.ErrMultisigBundleLoadFailed, err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func writeMultisigProposalBundleToFile(toFile string, bundle *common.MultisigProposalBundle) error {
+	slog.Info("writing multisig proposal bundle to file", "path", toFile)
+	data, err := json.MarshalIndent(bundle, "", "\t")
+	if err != nil {
+		return errors.Join(constants.ErrMultisigBundleSaveFailed, err)
+	}
+	if err := os.WriteFile(toFile, data, 0644); err != nil {
+		return errors.Join(constants.ErrMultisigBundleSaveFailed, err)
+	}
+	return nil
+}
+
 type versionInfo struct {
 	Version string `json:"tag_name"`
 }
@@ -132,6 +272,9 @@ func PrintPreparationResults(preparationResult *common.PreparePayoutsResult, cyc
 	title := utils.FormatCycleNumbers(cyclesForTitle...)
 
 	utils.PrintPayouts(preparationResult.InvalidPayouts, fmt.Sprintf("Invalid - %s", title), false)
+	for _, blueprint := range preparationResult.Blueprints {
+		utils.PrintInvalidPayoutsBreakdowns(blueprint.Summary.InvalidPayouts, fmt.Sprintf("Invalid Payouts Breakdown - %d", blueprint.Cycle))
+	}
 	utils.PrintPayouts(preparationResult.AccumulatedPayouts, fmt.Sprintf("Accumulated - %s", title), false)
 	utils.PrintReports(preparationResult.ReportsOfPastSuccesfulPayouts, fmt.Sprintf("Already Successfull - %s", title), true)
 	utils.PrintPayouts(preparationResult.ValidPayouts, fmt.Sprintf("Valid - %s", title), true)
@@ -144,6 +287,7 @@ func PrintPayoutWalletRemainingBalance(collector common.CollectorEngine, signer
 		slog.Error("failed to get balance", "error", err.Error())
 		return
 	}
+	metrics.PayoutWalletBalance.Set(float64(balance.Int64()), addr.String())
 
 	slog.Info("the payout wallet remaining balance", "wallet", addr.String(), "balance", common.FormatTezAmount(balance.Int64()), "phase", "payout_wallet_remaining_balance")
 }
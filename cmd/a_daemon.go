@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tez-capital/tezpay/api"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
+)
+
+const (
+	ADMIN_NOTIFICATION_CAPACITY = 100
+)
+
+// daemonState is shared by continual processing and management api
+type daemonState struct {
+	startedAt  time.Time
+	paused     atomic.Bool
+	processing atomic.Bool
+	payoutsMtx sync.Mutex
+
+	lastProcessedCycle    atomic.Int64
+	onchainCompletedCycle atomic.Int64
+
+	notifications      []api.AdminNotification
+	lastNotificationId int64
+	notificationsMtx   sync.Mutex
+}
+
+var daemon = &daemonState{startedAt: time.Now()}
+
+func (d *daemonState) setLastProcessedCycle(cycle int64) {
+	d.lastProcessedCycle.Store(cycle)
+	metrics.SetLastProcessedCycle(cycle)
+}
+
+func (d *daemonState) setOnchainCompletedCycle(cycle int64) {
+	d.onchainCompletedCycle.Store(cycle)
+	metrics.OnchainCompletedCycle.Set(float64(cycle))
+}
+
+func (d *daemonState) waitWhilePaused() {
+	for d.paused.Load() {
+		time.Sleep(time.Second * 5)
+	}
+}
+
+// runExclusive runs payout processing, only one can be in progress at a time
+func (d *daemonState) runExclusive(f func()) {
+	d.payoutsMtx.Lock()
+	defer d.payoutsMtx.Unlock()
+	d.processing.Store(true)
+	defer d.processing.Store(false)
+	f()
+}
+
+// tryStartExclusive starts payout processing in background, fails if another one is in progress
+func (d *daemonState) tryStartExclusive(f func()) error {
+	if !d.payoutsMtx.TryLock() {
+		return constants.ErrPayoutAlreadyInProgress
+	}
+	d.processing.Store(true)
+	go func() {
+		defer d.payoutsMtx.Unlock()
+		defer d.processing.Store(false)
+		f()
+	}()
+	return nil
+}
+
+func (d *daemonState) addAdminNotification(msg string) {
+	d.notificationsMtx.Lock()
+	defer d.notificationsMtx.Unlock()
+	d.lastNotificationId++
+	d.notifications = append(d.notifications, api.AdminNotification{
+		Id:        d.lastNotificationId,
+		Timestamp: time.Now(),
+		Message:   msg,
+	})
+	if len(d.notifications) > ADMIN_NOTIFICATION_CAPACITY {
+		d.notifications = d.notifications[len(d.notifications)-ADMIN_NOTIFICATION_CAPACITY:]
+	}
+}
+
+func (d *daemonState) getAdminNotifications() []api.AdminNotification {
+	d.notificationsMtx.Lock()
+	defer d.notificationsMtx.Unlock()
+	return append([]api.AdminNotification{}, d.notifications...)
+}
+
+// acknowledgeAdminNotifications removes notifications up to id, all of them if id is 0
+func (d *daemonState) acknowledgeAdminNotifications(upToId int64) int {
+	d.notificationsMtx.Lock()
+	defer d.notificationsMtx.Unlock()
+	remaining := make([]api.AdminNotification, 0, len(d.notifications))
+	for _, notification := range d.notifications {
+		if upToId > 0 && notification.Id > upToId {
+			remaining = append(remaining, notification)
+		}
+	}
+	acknowledged := len(d.notifications) - len(remaining)
+	d.notifications = remaining
+	return acknowledged
+}
@@ -3,9 +3,12 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
 )
 
 func parseDateFlags(cmd *cobra.Command) (time.Time, time.Time, error) {
@@ -42,3 +45,40 @@ func parseDateFlags(cmd *cobra.Command) (time.Time, time.Time, error) {
 	}
 	return time.Time{}, time.Time{}, errors.New("invalid date range")
 }
+
+// getCyclesFromRangeFlags resolves cycles from date range, single cycle or cycle range flags
+func getCyclesFromRangeFlags(cmd *cobra.Command, collector common.CollectorEngine) []int64 {
+	startDateFlag, _ := cmd.Flags().GetString(START_DATE_FLAG)
+	monthFlag, _ := cmd.Flags().GetString(MONTH_FLAG)
+	if startDateFlag != "" || monthFlag != "" {
+		startDate, endDate, err := parseDateFlags(cmd)
+		if err != nil {
+			slog.Error("failed to parse date flags", "error", err.Error())
+			exit(EXIT_IVNALID_ARGS)
+		}
+		cycles, err := collector.GetCyclesInDateRange(startDate, endDate)
+		if err != nil {
+			slog.Error("failed to get cycles in date selected range", "error", err.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+		return cycles
+	}
+
+	cycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
+	if cycle != 0 {
+		return []int64{cycle}
+	}
+	firstCycle, _ := cmd.Flags().GetInt64(FIRST_CYCLE_FLAG)
+	lastCycle, _ := cmd.Flags().GetInt64(LAST_CYCLE_FLAG)
+	if lastCycle == 0 {
+		lastCycle = assertRunWithResult(collector.GetLastCompletedCycle, EXIT_OPERTION_FAILED)
+	}
+	if firstCycle == 0 {
+		firstCycle = lastCycle
+	}
+	if firstCycle > lastCycle {
+		slog.Error("first cycle cannot be after last cycle", "first_cycle", firstCycle, "last_cycle", lastCycle)
+		exit(EXIT_IVNALID_ARGS)
+	}
+	return lo.RangeFrom(firstCycle, int(lastCycle-firstCycle+1))
+}
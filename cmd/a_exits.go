@@ -1,5 +1,7 @@
 package cmd
 
+import "os"
+
 const (
 	// ops
 	EXIT_OPERTION_FAILED   = 1
@@ -19,3 +21,9 @@ const (
 
 	EXIT_STATE_LOAD_FAILURE = 30
 )
+
+// exit closes loaded reporters, so pending writes to report sinks are flushed, and exits with the code
+func exit(code int) {
+	closeReporters()
+	os.Exit(code)
+}
@@ -25,4 +25,21 @@ const (
 	START_DATE_FLAG                  = "start-date"
 	END_DATE_FLAG                    = "end-date"
 	MONTH_FLAG                       = "month"
+	EXPORT_UNSIGNED_FLAG             = "export-unsigned"
+	CONFIRMATIONS_FLAG               = "confirmations"
+	IMPORT_KEY_FILE_FLAG             = "import-key-file"
+	SKIP_TRANSFER_FLAG               = "skip-transfer"
+	SKIP_REVEAL_FLAG                 = "skip-reveal"
+	CHECK_CHAIN_FLAG                 = "check-chain"
+	DELEGATOR_FLAG                   = "delegator"
+	OUTPUT_DIRECTORY_FLAG            = "output"
+	OUTPUT_FILE_FLAG                 = "output-file"
+	FORMAT_FLAG                      = "format"
+	PRICE_FILE_FLAG                  = "price-file"
+	VIEW_FLAG                        = "view"
+	LISTEN_FLAG                      = "listen"
+	PAUSED_FLAG                      = "paused"
+	RATE_LIMIT_FLAG                  = "rate-limit"
+	REFRESH_INTERVAL_FLAG            = "refresh-interval"
+	REPAIR_FLAG                      = "repair"
 )
@@ -11,14 +11,14 @@ import (
 	"github.com/tez-capital/tezpay/notifications"
 )
 
-func collectAdditionalData(_ *common.CyclePayoutSummary) map[string]string {
+func collectAdditionalData(summary *common.CyclePayoutSummary) map[string]string {
 	data := make(map[string]json.RawMessage)
 
 	err := extension.ExecuteHook(enums.EXTENSION_HOOK_COLLECT_ADDITIONAL_NOTIFICATION_DATA, "0.1", &data)
 	if err != nil {
 		slog.Warn("failed to execute hook", "error", err.Error())
 	}
-	result := make(map[string]string)
+	result := summary.GetInvalidPayoutsTemplateData()
 	for key, value := range data {
 		result[key] = string(value)
 	}
@@ -57,6 +57,7 @@ func notifyPayoutsProcessedThroughAllNotificators(configuration *configuration.R
 }
 
 func notifyAdmin(configuration *configuration.RuntimeConfiguration, msg string) {
+	daemon.addAdminNotification(msg)
 	for _, notificatorConfiguration := range configuration.NotificationConfigurations {
 		if !notificatorConfiguration.IsAdmin {
 			continue
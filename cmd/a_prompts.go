@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/hashicorp/go-version"
@@ -76,7 +75,7 @@ func promptIfNewVersionAvailable() {
 		err := requireConfirmation(fmt.Sprintf("You are not running latest version of tezpay (new version : '%s', current version: '%s').\n Do you want to continue anyway?", latestVersion, constants.VERSION))
 		if errors.Is(err, constants.ErrUserNotConfirmed) {
 			slog.Info("new version available", "url", fmt.Sprintf("https://github.com/%s/releases", constants.TEZPAY_REPOSITORY))
-			os.Exit(1)
+			exit(1)
 		}
 	}
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core"
+	price_engines "github.com/tez-capital/tezpay/engines/price"
+)
+
+var accountingExportCmd = &cobra.Command{
+	Use:   "accounting-export",
+	Short: "exports payouts valued in fiat",
+	Long:  "exports successful payouts over cycle range or date range valued in fiat currencies at the time of payment as generic csv or crypto tax import formats",
+	Run: func(cmd *cobra.Command, args []string) {
+		outputDirectory, _ := cmd.Flags().GetString(OUTPUT_DIRECTORY_FLAG)
+		formatFlags, _ := cmd.Flags().GetStringSlice(FORMAT_FLAG)
+		priceFile, _ := cmd.Flags().GetString(PRICE_FILE_FLAG)
+
+		formats := lo.Map(formatFlags, func(format string, _ int) enums.EAccountingExportFormat { return enums.EAccountingExportFormat(format) })
+		if unsupported, _ := lo.Difference(formats, enums.SUPPORTED_ACCOUNTING_EXPORT_FORMATS); len(unsupported) > 0 {
+			slog.Error("unsupported accounting export format", "formats", unsupported)
+			exit(EXIT_IVNALID_ARGS)
+		}
+
+		config, collector, _, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		accountingConfiguration := config.Accounting
+		if priceFile != "" {
+			accountingConfiguration.PriceSource = enums.PRICE_SOURCE_CSV
+			accountingConfiguration.PriceFile = priceFile
+		}
+		priceEngine := assertRunWithResultAndErrorMessage(func() (common.PriceEngine, error) {
+			return price_engines.Load(&accountingConfiguration)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load price engine", "source", accountingConfiguration.PriceSource)
+
+		reporter := loadReporter(config, &common.ReporterEngineOptions{})
+		cycles := getCyclesFromRangeFlags(cmd, collector)
+		reports := loadReportsOfCycles(reporter, cycles)
+
+		records := assertRunWithResultAndErrorMessage(func() ([]common.AccountingRecord, error) {
+			return core.BuildAccountingRecords(reports, priceEngine, accountingConfiguration.Currencies)
+		}, EXIT_OPERTION_FAILED, "failed to value payouts")
+		if len(records) == 0 {
+			slog.Warn("no payouts found", "cycles", cycles)
+			return
+		}
+
+		assertRunWithErrorMessage(func() error {
+			return os.MkdirAll(outputDirectory, 0700)
+		}, EXIT_PAYOUT_WRITE_FAILURE, "failed to create output directory", "directory", outputDirectory)
+		for _, format := range formats {
+			data := assertRunWithResultAndErrorMessage(func() ([]byte, error) {
+				return core.MarshalAccountingRecords(records, accountingConfiguration.Currencies, format)
+			}, EXIT_OPERTION_FAILED, "failed to export accounting records", "format", format)
+			file := path.Join(outputDirectory, fmt.Sprintf("accounting-%s-%d-%d.csv", format, cycles[0], cycles[len(cycles)-1]))
+			assertRunWithErrorMessage(func() error {
+				return os.WriteFile(file, data, 0644)
+			}, EXIT_PAYOUT_WRITE_FAILURE, "failed to write accounting export", "file", file)
+			slog.Info("accounting records exported", "format", format, "file", file, "records", len(records))
+		}
+	},
+}
+
+func init() {
+	accountingExportCmd.Flags().Int64(CYCLE_FLAG, 0, "cycle to export payouts of")
+	accountingExportCmd.Flags().Int64(FIRST_CYCLE_FLAG, 0, "first cycle of the exported range (defaults to last cycle)")
+	accountingExportCmd.Flags().Int64(LAST_CYCLE_FLAG, 0, "last cycle of the exported range (defaults to last completed cycle)")
+	accountingExportCmd.Flags().String(START_DATE_FLAG, "", "start date of the exported range (format: 2024-02-01)")
+	accountingExportCmd.Flags().String(END_DATE_FLAG, "", "end date of the exported range (format: 2024-02-01)")
+	accountingExportCmd.Flags().String(MONTH_FLAG, "", "month to export payouts of (format: 2024-02)")
+	accountingExportCmd.Flags().String(OUTPUT_DIRECTORY_FLAG, "accounting", "directory to write exports to")
+	accountingExportCmd.Flags().StringSlice(FORMAT_FLAG, []string{"generic"}, "export formats (generic, koinly, cointracking)")
+	accountingExportCmd.Flags().String(PRICE_FILE_FLAG, "", "csv price file overriding configured price source")
+	RootCmd.AddCommand(accountingExportCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var broadcastCmd = &cobra.Command{
+	Use:   "broadcast <signed payouts file>",
+	Short: "broadcasts offline signed payouts",
+	Long:  "broadcasts payouts signed with 'tezpay sign' and reports them as usual",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		engines := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		config, collector, signer, _ := engines.Unwrap()
+		defer extension.CloseExtensions()
+
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
+
+		bundle := assertRunWithResultAndErrorMessage(func() (*common.OfflineSigningBundle, error) {
+			return loadOfflineSigningBundleFromFile(args[0])
+		}, EXIT_PAYOUTS_READ_FAILURE, "failed to load signed payouts")
+		if !bundle.IsSigned() {
+			slog.Error("payouts are not signed", "error", constants.ErrOfflineBundleNotSigned.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+
+		reporter := loadReporter(config, &common.ReporterEngineOptions{})
+
+		slog.Info("acquiring lock", "cycles", bundle.Cycles, "phase", "acquiring_lock")
+		unlock, err := lockCyclesWithTimeout(time.Minute*10, bundle.Cycles...)
+		if err != nil {
+			slog.Error("failed to acquire lock", "error", err.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+		defer unlock()
+
+		slog.Info("checking past reports")
+		assertNotPaidOut(bundle.GetPayouts(), bundle.Cycles, config, collector, reporter)
+
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info("payouts to broadcast", "cycles", bundle.Cycles, "payouts", bundle.GetPayouts(), "phase", "payouts_to_broadcast")
+		default:
+			utils.PrintPayouts(bundle.GetPayouts(), fmt.Sprintf("Signed - %s", utils.FormatCycleNumbers(bundle.Cycles...)), true)
+		}
+
+		if !confirmed {
+			assertRequireConfirmation("Do you want to broadcast above signed payouts?")
+		}
+
+		slog.Info("broadcasting payouts")
+		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
+			return core.ExecuteOfflineSignedPayouts(bundle, config, engines.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
+				Confirmations: confirmations,
+			})
+		}, EXIT_OPERTION_FAILED)
+
+		failedCount := lo.CountBy(executionResult.BatchResults, func(br common.BatchResult) bool { return !br.IsSuccess })
+		if len(executionResult.BatchResults) > 0 && failedCount > 0 {
+			slog.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults))
+			exit(EXIT_OPERTION_FAILED)
+		}
+		if silent, _ := cmd.Flags().GetBool(SILENT_FLAG); !silent {
+			for _, blueprint := range bundle.PreparationResult.Blueprints {
+				notifyPayoutsProcessedThroughAllNotificators(config, &blueprint.Summary)
+			}
+		}
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info(constants.LOG_MESSAGE_PAYOUTS_EXECUTED, constants.LOG_FIELD_CYCLES, bundle.Cycles, "phase", "result")
+		default:
+			utils.PrintBatchResults(executionResult.BatchResults, fmt.Sprintf("Results of #%s", utils.FormatCycleNumbers(bundle.Cycles...)), config.Network.Explorer)
+		}
+		PrintPayoutWalletRemainingBalance(collector, signer)
+	},
+}
+
+func init() {
+	broadcastCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms broadcasting")
+	broadcastCmd.Flags().BoolP(SILENT_FLAG, "s", false, "suppresses notifications")
+	broadcastCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
+	RootCmd.AddCommand(broadcastCmd)
+}
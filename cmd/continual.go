@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
-	"os"
 	"time"
 
 	"github.com/samber/lo"
@@ -13,7 +12,7 @@ import (
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/core"
-	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	topup_engines "github.com/tez-capital/tezpay/engines/topup"
 	"github.com/tez-capital/tezpay/extension"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
@@ -26,7 +25,27 @@ var (
 	endCycle              int64
 )
 
-func processCycleInContinualMode(context *configurationAndEngines, forceConfirmationPrompt bool, mixInContractCalls bool, mixInFATransfers bool, isDryRun bool, silent bool) (processed bool) {
+type continualOptions struct {
+	ForceConfirmationPrompt bool
+	MixInContractCalls      bool
+	MixInFATransfers        bool
+	IsDryRun                bool
+	Silent                  bool
+	Confirmations           int64
+}
+
+func getContinualOptions(cmd *cobra.Command) continualOptions {
+	options := continualOptions{}
+	options.MixInContractCalls, _ = cmd.Flags().GetBool(DISABLE_SEPERATE_SC_PAYOUTS_FLAG)
+	options.MixInFATransfers, _ = cmd.Flags().GetBool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG)
+	options.ForceConfirmationPrompt, _ = cmd.Flags().GetBool(FORCE_CONFIRMATION_PROMPT_FLAG)
+	options.IsDryRun, _ = cmd.Flags().GetBool(DRY_RUN_FLAG)
+	options.Silent, _ = cmd.Flags().GetBool(SILENT_FLAG)
+	options.Confirmations, _ = cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
+	return options
+}
+
+func processCycleInContinualMode(context *configurationAndEngines, options continualOptions) (processed bool) {
 	processed = true
 	retry := func() bool {
 		processed = false
@@ -37,12 +56,13 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 		switch {
 		case processed:
 			lastProcessedCycle = cycleToProcess
+			daemon.setLastProcessedCycle(lastProcessedCycle)
 			slog.Info("cycle processed successfully", "cycle", cycleToProcess)
 			slog.Info("===================== PROCESSING -END- =====================")
 			extension.CloseScopedExtensions()
 			if endCycle != 0 && lastProcessedCycle >= endCycle {
 				slog.Info("end cycle reached, exiting")
-				os.Exit(0)
+				exit(0)
 			}
 		default:
 			slog.Info("cycle processing failed, retrying in 5 minutes")
@@ -51,9 +71,7 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 	}()
 
 	config, collector, signer, transactor := context.Unwrap()
-	fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
-		DryRun: isDryRun,
-	})
+	reporter := context.Reporter
 
 	// refresh engine params - for protoocol upgrades
 	if err := errors.Join(transactor.RefreshParams(), collector.RefreshParams()); err != nil {
@@ -72,7 +90,7 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 	slog.Info("===================== PROCESSING START =====================")
 	slog.Info("processing cycle", "cycle", cycleToProcess)
 
-	generationResult, err := core.GeneratePayouts(config, common.NewGeneratePayoutsEngines(collector, signer, notifyAdminFactory(config)),
+	generationResult, err := core.GeneratePayouts(config, context.NewGeneratePayoutsEngineContext(),
 		&common.GeneratePayoutsOptions{
 			Cycle:                    cycleToProcess,
 			WaitForSufficientBalance: true,
@@ -88,7 +106,7 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 
 	slog.Info("checking reports of past payouts")
 	preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-		return core.PrepareCyclePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{})
+		return core.PrepareCyclePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, reporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{})
 	}, EXIT_OPERTION_FAILED)
 
 	if len(preparationResult.ValidPayouts) == 0 {
@@ -98,10 +116,10 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 
 	slog.Info("processing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.AccumulatedPayouts), "already_successfull", len(preparationResult.ReportsOfPastSuccesfulPayouts))
 
-	if forceConfirmationPrompt && utils.IsTty() {
+	if options.ForceConfirmationPrompt && utils.IsTty() {
 		PrintPreparationResults(preparationResult, generationResult.Cycle)
 		msg := "Do you want to pay out above VALID payouts?"
-		if isDryRun {
+		if options.IsDryRun {
 			msg = msg + " (dry-run)"
 		}
 		assertRequireConfirmation(msg)
@@ -109,10 +127,11 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 
 	slog.Info("executing payouts", "valid", len(preparationResult.ValidPayouts), "invalid", len(preparationResult.InvalidPayouts), "accumulated", len(preparationResult.AccumulatedPayouts), "already_successfull", len(preparationResult.ReportsOfPastSuccesfulPayouts))
 	executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
-		return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, fsReporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
-			MixInContractCalls: mixInContractCalls,
-			MixInFATransfers:   mixInFATransfers,
-			DryRun:             isDryRun,
+		return core.ExecutePayouts(preparationResult, config, context.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
+			MixInContractCalls: options.MixInContractCalls,
+			MixInFATransfers:   options.MixInFATransfers,
+			DryRun:             options.IsDryRun,
+			Confirmations:      options.Confirmations,
 		})
 	}, EXIT_OPERTION_FAILED)
 
@@ -127,120 +146,144 @@ func processCycleInContinualMode(context *configurationAndEngines, forceConfirma
 			slog.Info("all operations succeeded", "total", len(executionResult.BatchResults), "cycle", cycleToProcess, "phase", "cycle_processing_success")
 		}
 	}
-	if !silent && !isDryRun {
+	if !options.IsDryRun {
+		autoStakeIncome(context, &generationResult.Summary, reporter, options.Confirmations)
+	}
+	if !options.Silent && !options.IsDryRun {
 		notifyPayoutsProcessedThroughAllNotificators(config, &generationResult.Summary)
 	}
 	PrintPayoutWalletRemainingBalance(collector, signer)
 	return
 }
 
-var continualCmd = &cobra.Command{
-	Use:   "continual",
-	Short: "continual payout",
-	Long:  "runs payout until stopped manually",
-	Run: func(cmd *cobra.Command, args []string) {
-		configurationContext := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
-		config, collector, _, _ := configurationContext.Unwrap()
-		defer extension.CloseExtensions()
-		initialCycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
-		endCycle, _ = cmd.Flags().GetInt64(END_CYCLE_FLAG)
-		mixInContractCalls, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_SC_PAYOUTS_FLAG)
-		mixInFATransfers, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG)
-		forceConfirmationPrompt, _ := cmd.Flags().GetBool(FORCE_CONFIRMATION_PROMPT_FLAG)
-		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
-		silent, _ := cmd.Flags().GetBool(SILENT_FLAG)
-
-		if isDryRun {
-			slog.Info("Dry run mode enabled")
-		}
+// runContinual processes cycles until stopped, onReady is called once engines are loaded and reporter is set
+func runContinual(cmd *cobra.Command, onReady func(context *configurationAndEngines, options continualOptions)) {
+	configurationContext := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+	config, collector, _, _ := configurationContext.Unwrap()
+	defer extension.CloseExtensions()
+	initialCycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
+	endCycle, _ = cmd.Flags().GetInt64(END_CYCLE_FLAG)
+	options := getContinualOptions(cmd)
+
+	if options.IsDryRun {
+		slog.Info("Dry run mode enabled")
+	}
+
+	configurationContext.Reporter = loadReporter(config, &common.ReporterEngineOptions{
+		DryRun: options.IsDryRun,
+	})
 
+	if topUpConfiguration := config.PayoutConfiguration.TopUp; topUpConfiguration != nil && !options.IsDryRun {
+		configurationContext.TopUp = assertRunWithResultAndErrorMessage(func() (common.TopUpEngine, error) {
+			return topup_engines.InitDefaultTopUpEngine(topUpConfiguration, configurationContext.Transactor)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load top up engine")
+		slog.Info("payout wallet top up enabled", "daily_limit", common.MutezToTezS(topUpConfiguration.DailyLimit.Int64()))
+	}
+
+	if utils.IsTty() {
+		assertRequireConfirmation("\n\n\t !!! ATTENTION !!!\n\nPreliminary testing has been conducted on the continual mode, but potential for undiscovered bugs still exists.\n Do you want to proceed?")
+	}
+	if options.ForceConfirmationPrompt {
 		if utils.IsTty() {
-			assertRequireConfirmation("\n\n\t !!! ATTENTION !!!\n\nPreliminary testing has been conducted on the continual mode, but potential for undiscovered bugs still exists.\n Do you want to proceed?")
-		}
-		if forceConfirmationPrompt {
-			if utils.IsTty() {
-				slog.Info("you will be prompted for confirmation before each payout")
-				time.Sleep(time.Second * 5)
-			} else {
-				slog.Error("force confirmation mode is not supported in non-interactive mode")
-				os.Exit(EXIT_IVNALID_ARGS)
-			}
+			slog.Info("you will be prompted for confirmation before each payout")
+			time.Sleep(time.Second * 5)
+		} else {
+			slog.Error("force confirmation mode is not supported in non-interactive mode")
+			exit(EXIT_IVNALID_ARGS)
 		}
+	}
 
-		if !state.Global.IsDonationPromptDisabled() && !config.IsDonatingToTezCapital() {
-			assertRequireConfirmation("⚠️  With your current configuration you are not going to donate to tez.capital.😔 Do you want to proceed?")
-		}
+	if !state.Global.IsDonationPromptDisabled() && !config.IsDonatingToTezCapital() {
+		assertRequireConfirmation("⚠️  With your current configuration you are not going to donate to tez.capital.😔 Do you want to proceed?")
+	}
 
-		monitor := assertRunWithResultAndErrorMessage(func() (common.CycleMonitor, error) {
-			return collector.CreateCycleMonitor(common.CycleMonitorOptions{
-				CheckFrequency:    10,
-				NotificationDelay: rand.Int63n(config.PayoutConfiguration.MaximumDelayBlocks-config.PayoutConfiguration.MinimumDelayBlocks) + config.PayoutConfiguration.MinimumDelayBlocks,
-			})
-		}, EXIT_OPERTION_FAILED, "failed to init cycle monitor")
-
-		// last completed cycle at the time we started continual mode on
-		onchainCompletedCycle = assertRunWithResultAndErrorMessage(func() (int64, error) {
-			return collector.GetLastCompletedCycle()
-		}, EXIT_OPERTION_FAILED, "failed to get last completed cycle")
-
-		lastProcessedCycle = onchainCompletedCycle
-		if initialCycle != 0 {
-			if initialCycle > 0 {
-				lastProcessedCycle = initialCycle - 1
-			} else {
-				lastProcessedCycle = onchainCompletedCycle + initialCycle
-			}
-		}
+	monitor := assertRunWithResultAndErrorMessage(func() (common.CycleMonitor, error) {
+		return collector.CreateCycleMonitor(common.CycleMonitorOptions{
+			CheckFrequency:    10,
+			NotificationDelay: rand.Int63n(config.PayoutConfiguration.MaximumDelayBlocks-config.PayoutConfiguration.MinimumDelayBlocks) + config.PayoutConfiguration.MinimumDelayBlocks,
+		})
+	}, EXIT_OPERTION_FAILED, "failed to init cycle monitor")
 
-		notifiedNewVersionAvailable := false
+	// last completed cycle at the time we started continual mode on
+	onchainCompletedCycle = assertRunWithResultAndErrorMessage(func() (int64, error) {
+		return collector.GetLastCompletedCycle()
+	}, EXIT_OPERTION_FAILED, "failed to get last completed cycle")
 
-		startupProtocol := GetProtocolWithRetry(collector)
-		if !config.Network.IgnoreProtocolChanges {
-			slog.Info("Continual mode started in safe mode. In the event of a protocol change, TezPay will stop processing payouts and you will be notified.")
+	daemon.setOnchainCompletedCycle(onchainCompletedCycle)
+	lastProcessedCycle = onchainCompletedCycle
+	if initialCycle != 0 {
+		if initialCycle > 0 {
+			lastProcessedCycle = initialCycle - 1
+		} else {
+			lastProcessedCycle = onchainCompletedCycle + initialCycle
 		}
-		defer func() {
-			notifyAdmin(config, fmt.Sprintf("Continual payouts stopped on cycle #%d", lastProcessedCycle+1))
-		}()
-		notifyAdmin(config, fmt.Sprintf("Continual payouts started on cycle #%d (tezpay %s, protocol %s)", lastProcessedCycle+1, constants.VERSION, startupProtocol))
-		for {
-			if lastProcessedCycle >= onchainCompletedCycle {
-				slog.Info("waiting for next cycle to complete", "phase", "waiting_for_next_cycle")
-				var err error
-				onchainCompletedCycle, err = monitor.WaitForNextCompletedCycle(lastProcessedCycle)
-				if err != nil {
-					if errors.Is(err, constants.ErrMonitoringCanceled) {
-						slog.Info("cycle monitoring canceled", "phase", "cycle_monitoring_canceled")
-						notifyAdmin(config, "Cycle monitoring canceled.")
-					} else {
-						slog.Error("failed to wait for next completed cycle", "error", err.Error(), "phase", "failed_to_wait_for_next_completed_cycle")
-						notifyAdmin(config, "Failed to wait for next completed cycle.")
-					}
-					return
+	}
+	daemon.setLastProcessedCycle(lastProcessedCycle)
+	if onReady != nil {
+		onReady(configurationContext, options)
+	}
+
+	notifiedNewVersionAvailable := false
+
+	startupProtocol := GetProtocolWithRetry(collector)
+	if !config.Network.IgnoreProtocolChanges {
+		slog.Info("Continual mode started in safe mode. In the event of a protocol change, TezPay will stop processing payouts and you will be notified.")
+	}
+	defer func() {
+		notifyAdmin(config, fmt.Sprintf("Continual payouts stopped on cycle #%d", lastProcessedCycle+1))
+	}()
+	notifyAdmin(config, fmt.Sprintf("Continual payouts started on cycle #%d (tezpay %s, protocol %s)", lastProcessedCycle+1, constants.VERSION, startupProtocol))
+	for {
+		if lastProcessedCycle >= onchainCompletedCycle {
+			slog.Info("waiting for next cycle to complete", "phase", "waiting_for_next_cycle")
+			var err error
+			onchainCompletedCycle, err = monitor.WaitForNextCompletedCycle(lastProcessedCycle)
+			if err != nil {
+				if errors.Is(err, constants.ErrMonitoringCanceled) {
+					slog.Info("cycle monitoring canceled", "phase", "cycle_monitoring_canceled")
+					notifyAdmin(config, "Cycle monitoring canceled.")
+				} else {
+					slog.Error("failed to wait for next completed cycle", "error", err.Error(), "phase", "failed_to_wait_for_next_completed_cycle")
+					notifyAdmin(config, "Failed to wait for next completed cycle.")
 				}
+				return
 			}
+			daemon.setOnchainCompletedCycle(onchainCompletedCycle)
+		}
 
-			if !config.Network.IgnoreProtocolChanges {
-				slog.Debug("checking for protocol changes")
-				currentProtocol := GetProtocolWithRetry(collector)
-				if currentProtocol != startupProtocol {
-					/// we can not exit here. Users may configure recover mechanism in case of crashes/exits so we really want to wait for the operator to take action
-					slog.Warn("protocol changed, operator action required", "old_protocol", startupProtocol, "new_protocol", currentProtocol, "phase", "waiting_for_operator_action")
-					notifyAdmin(config, fmt.Sprintf("Protocol changed from %s to %s, waiting for the operator to take action.", startupProtocol, currentProtocol))
-					continue
-				}
+		if !config.Network.IgnoreProtocolChanges {
+			slog.Debug("checking for protocol changes")
+			currentProtocol := GetProtocolWithRetry(collector)
+			if currentProtocol != startupProtocol {
+				/// we can not exit here. Users may configure recover mechanism in case of crashes/exits so we really want to wait for the operator to take action
+				slog.Warn("protocol changed, operator action required", "old_protocol", startupProtocol, "new_protocol", currentProtocol, "phase", "waiting_for_operator_action")
+				notifyAdmin(config, fmt.Sprintf("Protocol changed from %s to %s, waiting for the operator to take action.", startupProtocol, currentProtocol))
+				continue
 			}
+		}
 
-			cycleToProcess = lastProcessedCycle + 1
+		cycleToProcess = lastProcessedCycle + 1
 
-			if !notifiedNewVersionAvailable {
-				if available, latest := checkForNewVersionAvailable(); available {
-					notifyAdmin(config, fmt.Sprintf("New tezpay version available - %s", latest))
-					notifiedNewVersionAvailable = true
-				}
+		if !notifiedNewVersionAvailable {
+			if available, latest := checkForNewVersionAvailable(); available {
+				notifyAdmin(config, fmt.Sprintf("New tezpay version available - %s", latest))
+				notifiedNewVersionAvailable = true
 			}
-
-			processCycleInContinualMode(configurationContext, forceConfirmationPrompt, mixInContractCalls, mixInFATransfers, isDryRun, silent)
 		}
+
+		daemon.waitWhilePaused()
+		daemon.runExclusive(func() {
+			processCycleInContinualMode(configurationContext, options)
+		})
+	}
+}
+
+var continualCmd = &cobra.Command{
+	Use:   "continual",
+	Short: "continual payout",
+	Long:  "runs payout until stopped manually",
+	Run: func(cmd *cobra.Command, args []string) {
+		runContinual(cmd, nil)
 	},
 }
 
@@ -251,6 +294,7 @@ func init() {
 	continualCmd.Flags().Bool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG, false, "disables fa transfers separation (mixes txs and fa transfers within batches)")
 	continualCmd.Flags().BoolP(FORCE_CONFIRMATION_PROMPT_FLAG, "a", false, "ask for confirmation on each payout")
 	continualCmd.Flags().Bool(DRY_RUN_FLAG, false, "skips payout wallet balance check")
+	continualCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
 
 	RootCmd.AddCommand(continualCmd)
 }
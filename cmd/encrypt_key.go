@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/constants"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func getNewPassphrase() ([]byte, error) {
+	if !utils.IsTty() {
+		return signer_engines.GetPassphrase()
+	}
+	passphrase, confirmation := "", ""
+	if err := survey.AskOne(&survey.Password{Message: "New private key passphrase:"}, &passphrase, survey.WithValidator(survey.Required)); err != nil {
+		return nil, err
+	}
+	if err := survey.AskOne(&survey.Password{Message: "Repeat passphrase:"}, &confirmation); err != nil {
+		return nil, err
+	}
+	if passphrase != confirmation {
+		return nil, constants.ErrPrivateKeyPassphraseMismatch
+	}
+	return []byte(passphrase), nil
+}
+
+func encryptPrivateKey(key tezos.PrivateKey) (string, error) {
+	passphrase, err := getNewPassphrase()
+	if err != nil {
+		return "", err
+	}
+	getPassphrase := func() ([]byte, error) { return passphrase, nil }
+	encrypted, err := key.Encrypt(getPassphrase)
+	if err != nil {
+		return "", err
+	}
+	// make sure the key can be recovered before the plaintext one is replaced
+	decrypted, err := tezos.ParseEncryptedPrivateKey(encrypted, getPassphrase)
+	if err != nil || !decrypted.Address().Equal(key.Address()) {
+		return "", errors.Join(errors.New("encrypted key verification failed"), err)
+	}
+	return encrypted, nil
+}
+
+func writePrivateKeyFile(privateKeyFile string, key string) error {
+	tmpFile := privateKeyFile + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte(key), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, privateKeyFile); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	return nil
+}
+
+func encryptPrivateKeyFile(privateKeyFile string) error {
+	keyBytes, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return errors.Join(constants.ErrPrivateKeyEncryptionFailed, err)
+	}
+	plainKey := strings.TrimPrefix(strings.TrimSpace(string(keyBytes)), "unencrypted:")
+	if tezos.IsEncryptedKey(strings.TrimPrefix(plainKey, "encrypted:")) {
+		return constants.ErrPrivateKeyAlreadyEncrypted
+	}
+	key, err := tezos.ParsePrivateKey(plainKey)
+	if err != nil {
+		return errors.Join(constants.ErrPrivateKeyEncryptionFailed, err)
+	}
+
+	encrypted, err := encryptPrivateKey(key)
+	if err != nil {
+		return errors.Join(constants.ErrPrivateKeyEncryptionFailed, err)
+	}
+	if err := writePrivateKeyFile(privateKeyFile, encrypted); err != nil {
+		return errors.Join(constants.ErrPrivateKeyEncryptionFailed, err)
+	}
+	slog.Info("private key encrypted", "path", privateKeyFile, "address", key.Address().String())
+	return nil
+}
+
+var encryptKeyCmd = &cobra.Command{
+	Use:   "encrypt-key",
+	Short: "encrypts payout wallet private key",
+	Long: `encrypts plaintext payout wallet private key file in place
+
+The passphrase is taken from the interactive prompt, PRIVATE_KEY_PASSPHRASE environment variable or file descriptor specified by --passphrase-fd (PRIVATE_KEY_PASSPHRASE_FD).
+The same sources are used to decrypt the key when loading the signer.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		privateKeyFile := state.Global.GetPrivateKeyFilePath()
+		assertRunWithParamAndErrorMessage(encryptPrivateKeyFile, privateKeyFile, EXIT_OPERTION_FAILED, "failed to encrypt private key", "path", privateKeyFile)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(encryptKeyCmd)
+}
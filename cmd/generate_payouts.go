@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -46,7 +46,7 @@ var generatePayoutsCmd = &cobra.Command{
 		}
 		if err != nil {
 			slog.Error("failed to generate payouts", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 
 		targetFile, _ := cmd.Flags().GetString(TO_FILE_FLAG)
@@ -64,6 +64,7 @@ var generatePayoutsCmd = &cobra.Command{
 			slog.Info(constants.LOG_MESSAGE_PAYOUTS_GENERATED, constants.LOG_FIELD_CYCLES, cycles, constants.LOG_FIELD_CYCLE_PAYOUT_BLUEPRINT, generationResult, "phase", "result")
 		default:
 			utils.PrintPayouts(utils.OnlyInvalidPayouts(generationResult.Payouts), utils.FormatCycleNumbers(cycles...), false)
+			utils.PrintInvalidPayoutsBreakdowns(generationResult.Summary.InvalidPayouts, fmt.Sprintf("Invalid Payouts Breakdown - %s", utils.FormatCycleNumbers(cycles...)))
 			utils.PrintPayouts(utils.OnlyValidPayouts(generationResult.Payouts), utils.FormatCycleNumbers(cycles...), true)
 		}
 	},
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+	"github.com/tez-capital/tezpay/extension"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "multisig payout wallet",
+	Long:  "approves and submits payouts proposals exported with 'pay --export-unsigned' when paying out from multisig",
+}
+
+var multisigApproveCmd = &cobra.Command{
+	Use:   "approve <proposals file>",
+	Short: "approves multisig proposals",
+	Long:  "signs multisig proposals with the payout wallet or signer passed through --signer, can be run on an offline machine",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		bundleFile := args[0]
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		toFile, _ := cmd.Flags().GetString(TO_FILE_FLAG)
+		if toFile == "" {
+			toFile = bundleFile
+		}
+
+		bundle := assertRunWithResultAndErrorMessage(func() (*common.MultisigProposalBundle, error) {
+			return loadMultisigProposalBundleFromFile(bundleFile)
+		}, EXIT_PAYOUTS_READ_FAILURE, "failed to load multisig proposals")
+		loaded := assertRunWithResultAndErrorMessage(loadConfigurationAndSigner, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load signer")
+
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info("payouts to approve", "contract", bundle.Contract, "cycles", bundle.Cycles, "payouts", bundle.GetPayouts(), "created_at", bundle.CreatedAt, "phase", "payouts_to_approve")
+		default:
+			utils.PrintPayouts(bundle.GetPayouts(), fmt.Sprintf("To Approve - %s", utils.FormatCycleNumbers(bundle.Cycles...)), true)
+		}
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to approve above %d payouts in %d proposals from %s as %s?", len(bundle.GetPayouts()), len(bundle.Proposals), bundle.Contract, loaded.Signer.GetPKH()))
+		}
+
+		policy := assertRunWithResultAndErrorMessage(func() (*common.SigningPolicy, error) {
+			return loadSigningPolicy(loaded.Configuration, bundle.Cycles, bundle.PreparationResult.ReportsOfPastSuccesfulPayouts)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load signing policy")
+		assertRunWithErrorMessage(func() error {
+			return bundle.Sign(loaded.Signer, policy)
+		}, EXIT_OPERTION_FAILED, "failed to approve proposals")
+		assertRunWithParamAndErrorMessage(func(bundle *common.MultisigProposalBundle) error {
+			return writeMultisigProposalBundleToFile(toFile, bundle)
+		}, bundle, EXIT_PAYOUT_WRITE_FAILURE, "failed to write approved proposals")
+		slog.Info("proposals approved", "path", toFile, "proposals", len(bundle.Proposals), "signer", loaded.Signer.GetPKH(), "phase", "result")
+		exit(0)
+	},
+}
+
+var multisigSubmitCmd = &cobra.Command{
+	Use:   "submit <proposals file>",
+	Short: "submits approved multisig proposals",
+	Long:  "collects missing approvals from configured multisig signers, submits proposals through the payout wallet and reports them as usual",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		engines := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		config, collector, signer, _ := engines.Unwrap()
+		defer extension.CloseExtensions()
+
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
+
+		bundle := assertRunWithResultAndErrorMessage(func() (*common.MultisigProposalBundle, error) {
+			return loadMultisigProposalBundleFromFile(args[0])
+		}, EXIT_PAYOUTS_READ_FAILURE, "failed to load multisig proposals")
+		if config.PayoutConfiguration.Multisig == nil || !config.PayoutConfiguration.Multisig.Contract.Equal(bundle.Contract) {
+			slog.Error("proposals do not belong to configured multisig", "contract", bundle.Contract, "error", constants.ErrMultisigBundleContractMismatch.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+
+		reporter := loadReporter(config, &common.ReporterEngineOptions{})
+
+		slog.Info("acquiring lock", "cycles", bundle.Cycles, "phase", "acquiring_lock")
+		unlock, err := lockCyclesWithTimeout(time.Minute*10, bundle.Cycles...)
+		if err != nil {
+			slog.Error("failed to acquire lock", "error", err.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+		defer unlock()
+
+		slog.Info("checking past reports")
+		assertNotPaidOut(bundle.GetPayouts(), bundle.Cycles, config, collector, reporter)
+
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info("payouts to submit", "cycles", bundle.Cycles, "payouts", bundle.GetPayouts(), "phase", "payouts_to_submit")
+		default:
+			utils.PrintPayouts(bundle.GetPayouts(), fmt.Sprintf("Proposed - %s", utils.FormatCycleNumbers(bundle.Cycles...)), true)
+		}
+
+		if !confirmed {
+			assertRequireConfirmation("Do you want to submit above multisig proposals?")
+		}
+
+		slog.Info("submitting multisig proposals")
+		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
+			return core.ExecuteMultisigProposals(bundle, config, engines.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
+				Confirmations: confirmations,
+			})
+		}, EXIT_OPERTION_FAILED)
+
+		failedCount := lo.CountBy(executionResult.BatchResults, func(br common.BatchResult) bool { return !br.IsSuccess })
+		if len(executionResult.BatchResults) > 0 && failedCount > 0 {
+			slog.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults))
+			exit(EXIT_OPERTION_FAILED)
+		}
+		if silent, _ := cmd.Flags().GetBool(SILENT_FLAG); !silent {
+			for _, blueprint := range bundle.PreparationResult.Blueprints {
+				notifyPayoutsProcessedThroughAllNotificators(config, &blueprint.Summary)
+			}
+		}
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info(constants.LOG_MESSAGE_PAYOUTS_EXECUTED, constants.LOG_FIELD_CYCLES, bundle.Cycles, "phase", "result")
+		default:
+			utils.PrintBatchResults(executionResult.BatchResults, fmt.Sprintf("Results of #%s", utils.FormatCycleNumbers(bundle.Cycles...)), config.Network.Explorer)
+		}
+		PrintPayoutWalletRemainingBalance(collector, signer)
+	},
+}
+
+func init() {
+	multisigApproveCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms approval")
+	multisigApproveCmd.Flags().String(TO_FILE_FLAG, "", "writes approved proposals to file instead of overwriting the source file")
+	multisigSubmitCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms submission")
+	multisigSubmitCmd.Flags().BoolP(SILENT_FLAG, "s", false, "suppresses notifications")
+	multisigSubmitCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
+
+	multisigCmd.AddCommand(multisigApproveCmd)
+	multisigCmd.AddCommand(multisigSubmitCmd)
+	RootCmd.AddCommand(multisigCmd)
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"time"
 
 	"github.com/samber/lo"
@@ -23,7 +22,8 @@ var payDateRangeCmd = &cobra.Command{
 	Short: "EXPERIMENTAL: payout for date range",
 	Long:  "EXPERIMENTAL: runs payout for date range",
 	Run: func(cmd *cobra.Command, args []string) {
-		config, collector, signer, transactor := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		engines := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		config, collector, signer, _ := engines.Unwrap()
 		defer extension.CloseExtensions()
 
 		skipBalanceCheck, _ := cmd.Flags().GetBool(SKIP_BALANCE_CHECK_FLAG)
@@ -31,8 +31,9 @@ var payDateRangeCmd = &cobra.Command{
 		mixInContractCalls, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_SC_PAYOUTS_FLAG)
 		mixInFATransfers, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG)
 		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
 
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
+		configuredReporter := loadReporter(config, &common.ReporterEngineOptions{
 			DryRun: isDryRun,
 		})
 		stdioReporter := reporter_engines.NewStdioReporter(config)
@@ -44,12 +45,12 @@ var payDateRangeCmd = &cobra.Command{
 		startDate, endDate, err := parseDateFlags(cmd)
 		if err != nil {
 			slog.Error("failed to parse date flags", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 
 		if endDate.After(time.Now()) {
 			slog.Error("end date cannot be in the future")
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 
 		assertRequireConfirmation(fmt.Sprintf("NOTE: The payout for date ranges is an EXPERIMENTAL feature. Exercise caution!\n\nDo you want to generate payouts for date range: %s - %s?", startDate.Format(time.RFC3339), endDate.Format(time.RFC3339)))
@@ -57,14 +58,14 @@ var payDateRangeCmd = &cobra.Command{
 		cycles, err := collector.GetCyclesInDateRange(startDate, endDate)
 		if err != nil {
 			slog.Error("failed to get cycles in date selected range", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 
 		slog.Info("acquiring lock", "cycles", cycles, "phase", "acquiring_lock")
 		unlock, err := lockCyclesWithTimeout(time.Minute*10, cycles...)
 		if err != nil {
 			slog.Error("failed to acquire lock", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 		defer unlock()
 
@@ -88,7 +89,7 @@ var payDateRangeCmd = &cobra.Command{
 				}
 				if err != nil {
 					slog.Error("failed to generate payouts", "error", err.Error())
-					os.Exit(EXIT_OPERTION_FAILED)
+					exit(EXIT_OPERTION_FAILED)
 				}
 				ch <- generationResult
 			}()
@@ -102,7 +103,7 @@ var payDateRangeCmd = &cobra.Command{
 
 		slog.Info("checking reports of past payouts")
 		preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
+			return core.PreparePayouts(generationResults, config, common.NewPreparePayoutsEngineContext(collector, signer, configuredReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{
 				Accumulate: true,
 			})
 		}, EXIT_OPERTION_FAILED)
@@ -126,7 +127,7 @@ var payDateRangeCmd = &cobra.Command{
 			if notificator != "" { // rerun notification through notificator if specified manually
 				notifyPayoutsProcessed(config, generationResults.GetSummary(), notificator)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
 		if !confirmed {
@@ -140,14 +141,15 @@ var payDateRangeCmd = &cobra.Command{
 		slog.Info("executing payout")
 		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
 			var reporter common.ReporterEngine
-			reporter = fsReporter
+			reporter = configuredReporter
 			if reportToStdout, _ := cmd.Flags().GetBool(REPORT_TO_STDOUT); reportToStdout {
 				reporter = stdioReporter
 			}
-			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
+			return core.ExecutePayouts(preparationResult, config, engines.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
 				MixInContractCalls: mixInContractCalls,
 				MixInFATransfers:   mixInFATransfers,
 				DryRun:             isDryRun,
+				Confirmations:      confirmations,
 			})
 		}, EXIT_OPERTION_FAILED)
 
@@ -155,7 +157,7 @@ var payDateRangeCmd = &cobra.Command{
 		failedCount := lo.CountBy(executionResult.BatchResults, func(br common.BatchResult) bool { return !br.IsSuccess })
 		if len(executionResult.BatchResults) > 0 && failedCount > 0 {
 			slog.Error("failed operations detected", "failed_count", failedCount, "total_count", len(executionResult.BatchResults))
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 		if silent, _ := cmd.Flags().GetBool(SILENT_FLAG); !silent && !isDryRun {
 			summary := generationResults.GetSummary()
@@ -184,6 +186,7 @@ func init() {
 	payDateRangeCmd.Flags().String(NOTIFICATOR_FLAG, "", "Notify through specific notificator")
 	payDateRangeCmd.Flags().Bool(SKIP_BALANCE_CHECK_FLAG, false, "skips payout wallet balance check")
 	payDateRangeCmd.Flags().Bool(DRY_RUN_FLAG, false, "skips payout wallet balance check")
+	payDateRangeCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
 
 	RootCmd.AddCommand(payDateRangeCmd)
 }
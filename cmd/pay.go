@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"time"
 
 	"github.com/samber/lo"
@@ -23,7 +22,8 @@ var payCmd = &cobra.Command{
 	Short: "manual payout",
 	Long:  "runs manual payout",
 	Run: func(cmd *cobra.Command, args []string) {
-		config, collector, signer, transactor := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		engines := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE)
+		config, collector, signer, _ := engines.Unwrap()
 		defer extension.CloseExtensions()
 
 		cycle, _ := cmd.Flags().GetInt64(CYCLE_FLAG)
@@ -32,8 +32,9 @@ var payCmd = &cobra.Command{
 		mixInContractCalls, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_SC_PAYOUTS_FLAG)
 		mixInFATransfers, _ := cmd.Flags().GetBool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG)
 		isDryRun, _ := cmd.Flags().GetBool(DRY_RUN_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
 
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{
+		configuredReporter := loadReporter(config, &common.ReporterEngineOptions{
 			DryRun: isDryRun,
 		})
 		stdioReporter := reporter_engines.NewStdioReporter(config)
@@ -72,7 +73,7 @@ var payCmd = &cobra.Command{
 			}
 			if err != nil {
 				slog.Error("failed to generate payouts", "error", err.Error())
-				os.Exit(EXIT_OPERTION_FAILED)
+				exit(EXIT_OPERTION_FAILED)
 			}
 		}
 
@@ -87,13 +88,13 @@ var payCmd = &cobra.Command{
 		unlock, err := lockCyclesWithTimeout(time.Minute*10, cycles...)
 		if err != nil {
 			slog.Error("failed to acquire lock", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 		defer unlock()
 
 		slog.Info("checking past reports")
 		preparationResult := assertRunWithResult(func() (*common.PreparePayoutsResult, error) {
-			return core.PrepareCyclePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, fsReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{})
+			return core.PrepareCyclePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, configuredReporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{})
 		}, EXIT_OPERTION_FAILED)
 
 		switch {
@@ -115,28 +116,62 @@ var payCmd = &cobra.Command{
 			if notificator != "" { // rerun notification through notificator if specified manually
 				notifyPayoutsProcessed(config, &generationResult.Summary, notificator)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
+		exportUnsigned, _ := cmd.Flags().GetString(EXPORT_UNSIGNED_FLAG)
 		if !confirmed {
 			msg := "Do you want to pay out above VALID payouts?"
-			if isDryRun {
+			switch {
+			case exportUnsigned != "":
+				msg = "Do you want to export above VALID payouts for offline signing?"
+			case isDryRun:
 				msg = msg + " (dry-run)"
 			}
 			assertRequireConfirmation(msg)
 		}
 
+		if exportUnsigned != "" && config.PayoutConfiguration.Multisig != nil {
+			slog.Info("creating multisig proposals")
+			bundle := assertRunWithResult(func() (*common.MultisigProposalBundle, error) {
+				return core.ProposeMultisigPayouts(preparationResult, config, engines.NewExecutePayoutsEngineContext(configuredReporter), &common.ExecutePayoutsOptions{
+					MixInContractCalls: mixInContractCalls,
+					MixInFATransfers:   mixInFATransfers,
+				})
+			}, EXIT_OPERTION_FAILED)
+			assertRunWithParamAndErrorMessage(func(bundle *common.MultisigProposalBundle) error {
+				return writeMultisigProposalBundleToFile(exportUnsigned, bundle)
+			}, bundle, EXIT_PAYOUT_WRITE_FAILURE, "failed to export multisig proposals")
+			slog.Info("multisig proposals exported, approve them with 'tezpay multisig approve' and submit with 'tezpay multisig submit'", "path", exportUnsigned, "proposals", len(bundle.Proposals), "phase", "result")
+			return
+		}
+
+		if exportUnsigned != "" {
+			slog.Info("forging payouts for offline signing")
+			bundle := assertRunWithResult(func() (*common.OfflineSigningBundle, error) {
+				return core.ForgePayoutsForOfflineSigning(preparationResult, config, engines.NewExecutePayoutsEngineContext(configuredReporter), &common.ExecutePayoutsOptions{
+					MixInContractCalls: mixInContractCalls,
+					MixInFATransfers:   mixInFATransfers,
+				})
+			}, EXIT_OPERTION_FAILED)
+			assertRunWithParamAndErrorMessage(func(bundle *common.OfflineSigningBundle) error {
+				return writeOfflineSigningBundleToFile(exportUnsigned, bundle)
+			}, bundle, EXIT_PAYOUT_WRITE_FAILURE, "failed to export unsigned payouts")
+			slog.Info("unsigned payouts exported, sign them with 'tezpay sign' and broadcast with 'tezpay broadcast'", "path", exportUnsigned, "batches", len(bundle.Batches), "ttl", bundle.TTL, "phase", "result")
+			return
+		}
+
 		slog.Info("executing payouts")
+		var reporter common.ReporterEngine = configuredReporter
+		if reportToStdout, _ := cmd.Flags().GetBool(REPORT_TO_STDOUT); reportToStdout {
+			reporter = stdioReporter
+		}
 		executionResult := assertRunWithResult(func() (*common.ExecutePayoutsResult, error) {
-			var reporter common.ReporterEngine
-			reporter = fsReporter
-			if reportToStdout, _ := cmd.Flags().GetBool(REPORT_TO_STDOUT); reportToStdout {
-				reporter = stdioReporter
-			}
-			return core.ExecutePayouts(preparationResult, config, common.NewExecutePayoutsEngineContext(signer, transactor, reporter, notifyAdminFactory(config)), &common.ExecutePayoutsOptions{
+			return core.ExecutePayouts(preparationResult, config, engines.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
 				MixInContractCalls: mixInContractCalls,
 				MixInFATransfers:   mixInFATransfers,
 				DryRun:             isDryRun,
+				Confirmations:      confirmations,
 			})
 		}, EXIT_OPERTION_FAILED)
 
@@ -144,7 +179,10 @@ var payCmd = &cobra.Command{
 		failedCount := lo.CountBy(executionResult.BatchResults, func(br common.BatchResult) bool { return !br.IsSuccess })
 		if len(executionResult.BatchResults) > 0 && failedCount > 0 {
 			slog.Error("failed operations detected", "failed", failedCount, "total", len(executionResult.BatchResults))
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
+		}
+		if !isDryRun {
+			autoStakeIncome(engines, &generationResult.Summary, reporter, confirmations)
 		}
 		if silent, _ := cmd.Flags().GetBool(SILENT_FLAG); !silent && !isDryRun {
 			notifyPayoutsProcessedThroughAllNotificators(config, &generationResult.Summary)
@@ -171,6 +209,8 @@ func init() {
 	payCmd.Flags().String(NOTIFICATOR_FLAG, "", "Notify through specific notificator")
 	payCmd.Flags().Bool(SKIP_BALANCE_CHECK_FLAG, false, "skips payout wallet balance check")
 	payCmd.Flags().Bool(DRY_RUN_FLAG, false, "skips payout wallet balance check")
+	payCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
+	payCmd.Flags().String(EXPORT_UNSIGNED_FLAG, "", "exports forged unsigned payouts (or multisig proposals) to file for offline signing instead of paying out")
 
 	RootCmd.AddCommand(payCmd)
 }
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+func printReportIssues(issues []reporter_engines.ReportIssue) {
+	if state.Global.GetWantsOutputJson() {
+		slog.Info("report issues", "issues", issues, "phase", "report_issues")
+		return
+	}
+	utils.PrintTable([]string{"Cycle", "File", "Row", "Kind", "Reason", "Repairable"}, lo.Map(issues, func(issue reporter_engines.ReportIssue, _ int) []string {
+		row := ""
+		if issue.Row > 0 {
+			row = strconv.Itoa(issue.Row)
+		}
+		return []string{strconv.FormatInt(issue.Cycle, 10), issue.File, row, string(issue.Kind), issue.Reason, strconv.FormatBool(issue.Repairable)}
+	}), "Report Issues")
+}
+
+var reportsCmd = &cobra.Command{
+	Use:   "reports",
+	Short: "payout reports maintenance",
+	Long:  "checks and repairs payout reports written by the file system reporter",
+}
+
+var reportsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "checks integrity of payout reports",
+	Long:  "validates schema, duplicates, summary totals and operation hashes of every reported cycle, inconsistencies can be repaired with --repair",
+	Run: func(cmd *cobra.Command, args []string) {
+		repair, _ := cmd.Flags().GetBool(REPAIR_FLAG)
+		checkChain, _ := cmd.Flags().GetBool(CHECK_CHAIN_FLAG)
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+
+		config, collector, _, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		if config.Reports.Engine != enums.REPORTER_ENGINE_FS && config.Reports.Engine != "" {
+			slog.Error("reports check supports only file system reports", "engine", config.Reports.Engine)
+			exit(EXIT_CONFIGURATION_LOAD_FAILURE)
+		}
+		engine := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{})
+		var chainCollector common.CollectorEngine
+		if checkChain {
+			slog.Info("checking operations on chain")
+			chainCollector = collector
+		}
+		check := func(cycles ...int64) []reporter_engines.ReportIssue {
+			return assertRunWithResultAndErrorMessage(func() ([]reporter_engines.ReportIssue, error) {
+				return engine.CheckReports(chainCollector, cycles...)
+			}, EXIT_PAYOUTS_READ_FAILURE, "failed to check reports")
+		}
+		getRepairable := func(issues []reporter_engines.ReportIssue) []reporter_engines.ReportIssue {
+			return lo.Filter(issues, func(issue reporter_engines.ReportIssue, _ int) bool { return issue.Repairable })
+		}
+
+		issues := check()
+		if len(issues) == 0 {
+			slog.Info("payout reports are consistent", "phase", "result")
+			return
+		}
+		printReportIssues(issues)
+		repairable := getRepairable(issues)
+		if !repair || len(repairable) == 0 {
+			slog.Error("payout reports check failed", "issues", len(issues), "repairable", len(repairable), "phase", "result")
+			exit(EXIT_OPERTION_FAILED)
+		}
+
+		cycles := lo.Uniq(lo.Map(repairable, func(issue reporter_engines.ReportIssue, _ int) int64 { return issue.Cycle }))
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to repair %d issues in cycles %s?", len(repairable), utils.FormatCycleNumbers(cycles...)))
+		}
+		unlock := assertRunWithResultAndErrorMessage(func() (func() error, error) {
+			return lockCyclesWithTimeout(time.Minute, cycles...)
+		}, EXIT_OPERTION_FAILED, "failed to lock cycles, payouts may be in progress")
+		defer unlock()
+
+		// reports may have changed before the lock was acquired, repair only issues found under the lock
+		backupDirectory, err := engine.RepairReports(getRepairable(check(cycles...)))
+		if err != nil {
+			slog.Error("failed to repair reports", "backup", backupDirectory, "error", err.Error())
+			unlock()
+			exit(EXIT_PAYOUT_WRITE_FAILURE)
+		}
+		slog.Info("reports repaired", "cycles", cycles, "backup", backupDirectory)
+		if config.Reports.Ledger {
+			slog.Warn("payout ledger still records original reports, verify-ledger will report repaired cycles")
+		}
+
+		issues = check()
+		if len(issues) > 0 {
+			printReportIssues(issues)
+			slog.Error("payout reports still contain issues", "issues", len(issues), "phase", "result")
+			unlock()
+			exit(EXIT_OPERTION_FAILED)
+		}
+		slog.Info("payout reports are consistent", "phase", "result")
+	},
+}
+
+func init() {
+	reportsCheckCmd.Flags().Bool(REPAIR_FLAG, false, "rebuilds summaries, drops identical duplicates and marks payouts failed on chain, reports are backed up first")
+	reportsCheckCmd.Flags().Bool(CHECK_CHAIN_FLAG, false, "check operations of successful payouts were applied on chain")
+	reportsCheckCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms repair")
+	reportsCmd.AddCommand(reportsCheckCmd)
+	RootCmd.AddCommand(reportsCmd)
+}
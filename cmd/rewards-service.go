@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/api"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+	collector_engines "github.com/tez-capital/tezpay/engines/collector"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	DEFAULT_REWARDS_SERVICE_LISTEN_ADDRESS = "127.0.0.1:8788"
+)
+
+// publicRewardsCache keeps reports of recent cycles in memory so lookups do not touch reporter
+type publicRewardsCache struct {
+	config    *configuration.RuntimeConfiguration
+	collector common.CollectorEngine
+	reporter  common.ReporterEngine
+	cycles    int64
+
+	reports    []common.PayoutReport
+	firstCycle int64
+	lastCycle  int64
+	mtx        sync.RWMutex
+}
+
+func (c *publicRewardsCache) refresh() error {
+	lastCycle, err := c.collector.GetLastCompletedCycle()
+	if err != nil {
+		return err
+	}
+	firstCycle := max(lastCycle-c.cycles+1, 0)
+	cycles := make([]int64, 0, c.cycles)
+	for cycle := firstCycle; cycle <= lastCycle; cycle++ {
+		cycles = append(cycles, cycle)
+	}
+	reports := loadReportsOfCycles(c.reporter, cycles)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.reports, c.firstCycle, c.lastCycle = reports, firstCycle, lastCycle
+	slog.Debug("rewards cache refreshed", "first_cycle", firstCycle, "last_cycle", lastCycle, "reports", len(reports))
+	return nil
+}
+
+func (c *publicRewardsCache) lookup(delegator tezos.Address) (*common.PublicDelegatorRewards, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return core.BuildPublicDelegatorRewards(c.reports, delegator, c.firstCycle, c.lastCycle, c.config.Network.Explorer), nil
+}
+
+var rewardsServiceCmd = &cobra.Command{
+	Use:   "rewards-service",
+	Short: "public rewards lookup",
+	Long: `runs read only rewards lookup for delegators
+
+Delegators can look up their paid and pending rewards through html page at '/' or as json at '/rewards/<address>'.
+Only delegator payouts are exposed, baker income, donations and internal report fields are never served.
+The service does not load payout wallet, it needs only configuration and reports.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listen, _ := cmd.Flags().GetString(LISTEN_FLAG)
+		cycles, _ := cmd.Flags().GetInt64(CYCLES_FLAG)
+		rateLimit, _ := cmd.Flags().GetInt(RATE_LIMIT_FLAG)
+		refreshInterval, _ := cmd.Flags().GetDuration(REFRESH_INTERVAL_FLAG)
+
+		config := assertRunWithResultAndErrorMessage(func() (*configuration.RuntimeConfiguration, error) {
+			config, err := configuration.Load()
+			if err != nil {
+				return nil, errors.Join(constants.ErrConfigurationLoadFailed, err)
+			}
+			return config, nil
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load configuration")
+		collector := assertRunWithResultAndErrorMessage(func() (common.CollectorEngine, error) {
+			return collector_engines.InitDefaultRpcAndTzktColletor(config)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load collector")
+
+		cache := &publicRewardsCache{
+			config:    config,
+			collector: collector,
+			reporter:  loadReporter(config, &common.ReporterEngineOptions{}),
+			cycles:    max(cycles, 1),
+		}
+		assertRunWithErrorMessage(cache.refresh, EXIT_OPERTION_FAILED, "failed to load reports")
+		if refreshInterval > 0 {
+			go func() {
+				for range time.Tick(refreshInterval) {
+					if err := cache.refresh(); err != nil {
+						slog.Warn("failed to refresh reports", "error", err.Error())
+					}
+				}
+			}()
+		}
+
+		app := api.NewPublicApp(cache.lookup, api.PublicAppOptions{
+			Baker:     config.BakerPKH,
+			RateLimit: rateLimit,
+		})
+		slog.Info("starting rewards lookup", "address", listen)
+		assertRunWithErrorMessage(func() error {
+			return app.Listen(listen)
+		}, EXIT_OPERTION_FAILED, "rewards lookup failed")
+	},
+}
+
+func init() {
+	rewardsServiceCmd.Flags().String(LISTEN_FLAG, DEFAULT_REWARDS_SERVICE_LISTEN_ADDRESS, "address to serve rewards lookup at")
+	rewardsServiceCmd.Flags().Int64(CYCLES_FLAG, 30, "number of recent cycles to serve rewards of")
+	rewardsServiceCmd.Flags().Int(RATE_LIMIT_FLAG, 30, "number of requests allowed per minute from single ip (0 disables limit)")
+	rewardsServiceCmd.Flags().Duration(REFRESH_INTERVAL_FLAG, 10*time.Minute, "how often reports are reloaded (0 disables reloading)")
+	RootCmd.AddCommand(rewardsServiceCmd)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/metrics"
 
 	"log/slog"
 
@@ -22,11 +23,13 @@ const (
 	LOG_LEVEL_FLAG               = "log-level"
 	LOG_SERVER_FLAG              = "log-server"
 	LOG_FILE_FLAG                = "log-file"
+	METRICS_SERVER_FLAG          = "metrics-server"
 	PATH_FLAG                    = "path"
 	VERSION_FLAG                 = "version"
 	DISABLE_DONATION_PROMPT_FLAG = "disable-donation-prompt"
 	OUTPUT_FORMAT_FLAG           = "output-format"
 	PAY_ONLY_ADDRESS_PREFIX      = "pay-only-address-prefix"
+	PASSPHRASE_FD_FLAG           = "passphrase-fd"
 )
 
 var (
@@ -49,10 +52,10 @@ func setupLumberjackLogger(logFile string) io.Writer {
 	}
 }
 
-func setupLogger(level slog.Level, logServerAddress string, logFile string, format string) {
+func setupLogger(level slog.Level, logServerAddress string, logFile string, format string, metricsServerAddress string) {
 	var jsonWriters []io.Writer
 	if logServerAddress != "" {
-		jsonWriters = append(jsonWriters, utils.NewLogServer(logServerAddress))
+		jsonWriters = append(jsonWriters, utils.NewLogServer(logServerAddress, metricsServerAddress == logServerAddress))
 	}
 	if logFile != "" {
 		jsonWriters = append(jsonWriters, setupLumberjackLogger(logFile))
@@ -100,11 +103,18 @@ Copyright © %d alis.is
 			level, _ := cmd.Flags().GetString(LOG_LEVEL_FLAG)
 			logServer, _ := cmd.Flags().GetString(LOG_SERVER_FLAG)
 			logFile, _ := cmd.Flags().GetString(LOG_FILE_FLAG)
+			metricsServer, _ := cmd.Flags().GetString(METRICS_SERVER_FLAG)
 
-			setupLogger(LOG_LEVEL_MAP[level], logServer, logFile, format)
+			setupLogger(LOG_LEVEL_MAP[level], logServer, logFile, format, metricsServer)
 			slog.Debug("logger configured", "format", format, "level", level)
+			if metricsServer != "" && metricsServer != logServer {
+				metrics.StartServer(metricsServer)
+			}
 
 			workingDirectory, _ := cmd.Flags().GetString(PATH_FLAG)
+			if passphraseFd, _ := cmd.Flags().GetInt(PASSPHRASE_FD_FLAG); passphraseFd >= 0 {
+				signer_engines.SetPassphraseFd(passphraseFd)
+			}
 			singerFlagData, _ := cmd.Flags().GetString(SIGNER_FLAG)
 			var signerOverride common.SignerEngine
 			if singerFlagData != "" {
@@ -129,7 +139,7 @@ Copyright © %d alis.is
 			}
 			if err := state.Init(workingDirectory, stateOptions); err != nil {
 				slog.Error("Failed to initialize state", "error", err.Error())
-				os.Exit(EXIT_STATE_LOAD_FAILURE)
+				exit(EXIT_STATE_LOAD_FAILURE)
 			}
 
 			skipVersionCheck, _ := cmd.Flags().GetBool(SKIP_VERSION_CHECK_FLAG)
@@ -137,6 +147,9 @@ Copyright © %d alis.is
 				promptIfNewVersionAvailable()
 			}
 		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			closeReporters()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			version, _ := cmd.Flags().GetBool(VERSION_FLAG)
 			if version {
@@ -160,7 +173,9 @@ func init() {
 	RootCmd.PersistentFlags().StringP(LOG_LEVEL_FLAG, "l", "info", "Sets log level format (trace/debug/info/warn/error)")
 	RootCmd.PersistentFlags().String(LOG_SERVER_FLAG, "", "launches log server at specified address")
 	RootCmd.PersistentFlags().String(LOG_FILE_FLAG, "", "Logs to file")
+	RootCmd.PersistentFlags().String(METRICS_SERVER_FLAG, "", "launches prometheus metrics endpoint at specified address (use --log-server address to serve metrics from log server)")
 	RootCmd.PersistentFlags().String(SIGNER_FLAG, "", "Override signer")
+	RootCmd.PersistentFlags().Int(PASSPHRASE_FD_FLAG, -1, "Reads encrypted private key passphrase from file descriptor")
 	RootCmd.PersistentFlags().Bool(SKIP_VERSION_CHECK_FLAG, false, "Skip version check")
 	RootCmd.PersistentFlags().Bool(DISABLE_DONATION_PROMPT_FLAG, false, "Disable donation prompt")
 	RootCmd.PersistentFlags().String(PAY_ONLY_ADDRESS_PREFIX, "", "Pays only to addresses starting with the prefix (e.g. KT, usually you do not want to use this, just for recovering in case of issues)")
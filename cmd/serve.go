@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/api"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core"
+)
+
+const (
+	API_TOKEN_ENV              = "TEZPAY_API_TOKEN"
+	DEFAULT_API_LISTEN_ADDRESS = "127.0.0.1:8787"
+)
+
+type serveBackend struct {
+	context        *configurationAndEngines
+	options        continualOptions
+	dryRunReporter common.ReporterEngine
+
+	lastPayout    *api.PayoutResult
+	lastPayoutMtx sync.Mutex
+}
+
+func (b *serveBackend) GetStatus() api.Status {
+	return api.Status{
+		Version:               constants.VERSION,
+		StartedAt:             daemon.startedAt,
+		DryRun:                b.options.IsDryRun,
+		Paused:                daemon.paused.Load(),
+		Processing:            daemon.processing.Load(),
+		LastProcessedCycle:    daemon.lastProcessedCycle.Load(),
+		OnchainCompletedCycle: daemon.onchainCompletedCycle.Load(),
+		PendingNotifications:  len(daemon.getAdminNotifications()),
+	}
+}
+
+func (b *serveBackend) GetCycleReports(cycle int64) (*api.CycleReports, error) {
+	reporter := b.context.Reporter
+	reports, err := reporter.GetExistingReports(cycle)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &api.CycleReports{Cycle: cycle, Payouts: []common.PayoutReport{}}, nil
+		}
+		return nil, err
+	}
+	summary, err := reporter.GetExistingCycleSummary(cycle)
+	if err != nil {
+		slog.Debug("failed to read cycle summary", "cycle", cycle, "error", err.Error())
+		summary = nil
+	}
+	return &api.CycleReports{Cycle: cycle, Summary: summary, Payouts: reports}, nil
+}
+
+func (b *serveBackend) GenerateBlueprint(cycle int64) (*common.CyclePayoutBlueprint, error) {
+	config := b.context.Configuration
+	return core.GeneratePayouts(config, b.context.NewGeneratePayoutsEngineContext(), &common.GeneratePayoutsOptions{
+		Cycle:            cycle,
+		SkipBalanceCheck: true,
+	})
+}
+
+// ExecutePayout runs the same pipeline as continual mode in background, but reports errors instead of exiting
+func (b *serveBackend) ExecutePayout(cycle int64, dryRun bool) error {
+	dryRun = dryRun || b.options.IsDryRun
+	// held until the initial state is recorded so the background run can not overwrite it
+	b.lastPayoutMtx.Lock()
+	defer b.lastPayoutMtx.Unlock()
+	err := daemon.tryStartExclusive(func() {
+		result, err := b.executePayout(cycle, dryRun)
+		if result == nil {
+			result = &api.PayoutResult{Cycle: cycle, DryRun: dryRun}
+		}
+		result.Finished = true
+		if err != nil {
+			slog.Error("payout requested through api failed", "cycle", cycle, "error", err.Error())
+			result.Error = err.Error()
+		}
+		b.lastPayoutMtx.Lock()
+		defer b.lastPayoutMtx.Unlock()
+		b.lastPayout = result
+	})
+	if err != nil {
+		return err
+	}
+	b.lastPayout = &api.PayoutResult{Cycle: cycle, DryRun: dryRun}
+	return nil
+}
+
+func (b *serveBackend) GetLastPayout() *api.PayoutResult {
+	b.lastPayoutMtx.Lock()
+	defer b.lastPayoutMtx.Unlock()
+	if b.lastPayout == nil {
+		return nil
+	}
+	result := *b.lastPayout
+	return &result
+}
+
+func (b *serveBackend) executePayout(cycle int64, dryRun bool) (*api.PayoutResult, error) {
+	config, collector, signer, _ := b.context.Unwrap()
+	reporter := b.context.Reporter
+	if dryRun {
+		reporter = b.dryRunReporter
+	}
+
+	generationResult, err := core.GeneratePayouts(config, b.context.NewGeneratePayoutsEngineContext(), &common.GeneratePayoutsOptions{
+		Cycle:            cycle,
+		SkipBalanceCheck: dryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockCyclesWithTimeout(time.Minute, cycle)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	preparationResult, err := core.PrepareCyclePayouts(generationResult, config, common.NewPreparePayoutsEngineContext(collector, signer, reporter, notifyAdminFactory(config)), &common.PreparePayoutsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := &api.PayoutResult{Cycle: cycle, DryRun: dryRun, Preparation: preparationResult}
+	if len(preparationResult.ValidPayouts) == 0 {
+		slog.Info("nothing to pay out", "cycle", cycle)
+		return result, nil
+	}
+
+	result.Execution, err = core.ExecutePayouts(preparationResult, config, b.context.NewExecutePayoutsEngineContext(reporter), &common.ExecutePayoutsOptions{
+		MixInContractCalls: b.options.MixInContractCalls,
+		MixInFATransfers:   b.options.MixInFATransfers,
+		DryRun:             dryRun,
+		Confirmations:      b.options.Confirmations,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun || lo.SomeBy(result.Execution.BatchResults, func(br common.BatchResult) bool { return !br.IsSuccess }) {
+		return result, nil
+	}
+	autoStakeIncome(b.context, &generationResult.Summary, reporter, b.options.Confirmations)
+	if !b.options.Silent {
+		notifyPayoutsProcessedThroughAllNotificators(config, &generationResult.Summary)
+	}
+	return result, nil
+}
+
+func (b *serveBackend) Pause() {
+	if !daemon.paused.Swap(true) {
+		slog.Info("continual processing paused")
+	}
+}
+
+func (b *serveBackend) Resume() {
+	if daemon.paused.Swap(false) {
+		slog.Info("continual processing resumed")
+	}
+}
+
+func (b *serveBackend) GetAdminNotifications() []api.AdminNotification {
+	return daemon.getAdminNotifications()
+}
+
+func (b *serveBackend) AcknowledgeAdminNotifications(upToId int64) int {
+	return daemon.acknowledgeAdminNotifications(upToId)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "continual payout with management api",
+	Long: `runs continual payout together with authenticated management api
+
+The api requires bearer token set through ` + API_TOKEN_ENV + ` environment variable.
+
+Endpoints (prefixed with ` + api.API_PREFIX + `):
+	GET    /status                      - daemon status
+	GET    /reports/:cycle              - reports and summary of cycle
+	GET    /blueprints/:cycle           - preview of generated payouts
+	POST   /payouts/:cycle?dry_run=true - starts payout of cycle in background
+	GET    /payouts/last                - state and result of the last payout started through api
+	POST   /pause, /resume              - pauses or resumes continual processing
+	GET    /notifications               - pending admin notifications
+	DELETE /notifications?up_to=<id>    - acknowledges admin notifications`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listen, _ := cmd.Flags().GetString(LISTEN_FLAG)
+		paused, _ := cmd.Flags().GetBool(PAUSED_FLAG)
+		token, ok := os.LookupEnv(API_TOKEN_ENV)
+		if !ok || token == "" {
+			slog.Error("api token is required", "env", API_TOKEN_ENV)
+			exit(EXIT_IVNALID_ARGS)
+		}
+		daemon.paused.Store(paused)
+
+		runContinual(cmd, func(context *configurationAndEngines, options continualOptions) {
+			api.Start(&serveBackend{
+				context:        context,
+				options:        options,
+				dryRunReporter: loadReporter(context.Configuration, &common.ReporterEngineOptions{DryRun: true}),
+			}, token, listen)
+		})
+	},
+}
+
+func init() {
+	serveCmd.Flags().String(LISTEN_FLAG, DEFAULT_API_LISTEN_ADDRESS, "address to serve management api at")
+	serveCmd.Flags().Bool(PAUSED_FLAG, false, "starts with continual processing paused")
+	serveCmd.Flags().Int64P(CYCLE_FLAG, "c", 0, "initial cycle")
+	serveCmd.Flags().Int64P(END_CYCLE_FLAG, "e", 0, "end cycle")
+	serveCmd.Flags().Bool(DISABLE_SEPERATE_SC_PAYOUTS_FLAG, false, "disables smart contract separation (mixes txs and smart contract calls within batches)")
+	serveCmd.Flags().Bool(DISABLE_SEPERATE_FA_PAYOUTS_FLAG, false, "disables fa transfers separation (mixes txs and fa transfers within batches)")
+	serveCmd.Flags().BoolP(FORCE_CONFIRMATION_PROMPT_FLAG, "a", false, "ask for confirmation on each payout")
+	serveCmd.Flags().BoolP(SILENT_FLAG, "s", false, "suppresses notifications")
+	serveCmd.Flags().Bool(DRY_RUN_FLAG, false, "runs payouts without broadcasting transactions and writing reports")
+	serveCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider batch confirmed")
+
+	RootCmd.AddCommand(serveCmd)
+}
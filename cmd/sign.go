@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/core/execute"
+	collector_engines "github.com/tez-capital/tezpay/engines/collector"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+type configurationAndSigner struct {
+	Configuration *configuration.RuntimeConfiguration
+	Signer        common.SignerEngine
+}
+
+// loadConfigurationAndSigner loads signer without touching network, configuration is optional if signer is overridden
+func loadConfigurationAndSigner() (*configurationAndSigner, error) {
+	config, err := configuration.Load()
+	if err != nil {
+		if state.Global.SignerOverride == nil {
+			return nil, errors.Join(constants.ErrConfigurationLoadFailed, err)
+		}
+		slog.Warn("configuration not available, signing policy will not be enforced", "error", err.Error())
+		config = nil
+	}
+
+	signer := state.Global.SignerOverride
+	if signer == nil {
+		if signer, err = signer_engines.Load(string(config.PayoutConfiguration.WalletMode)); err != nil {
+			return nil, err
+		}
+	}
+	return &configurationAndSigner{Configuration: config, Signer: signer}, nil
+}
+
+// loadSigningPolicy creates signing policy if configuration is available, restricted recipients require
+// network access to fetch delegators of the cycles as allowed recipients can not be taken from the bundle
+func loadSigningPolicy(config *configuration.RuntimeConfiguration, cycles []int64, pastReports []common.PayoutReport) (*common.SigningPolicy, error) {
+	if config == nil {
+		return nil, nil
+	}
+	var collector common.CollectorEngine
+	if config.SigningPolicy.RestrictRecipients {
+		var err error
+		if collector, err = collector_engines.InitDefaultRpcAndTzktColletor(config); err != nil {
+			return nil, errors.Join(constants.ErrSigningPolicyLoadFailed, err)
+		}
+	}
+	return execute.NewSigningPolicy(config, collector, cycles, pastReports, nil)
+}
+
+var signCmd = &cobra.Command{
+	Use:   "sign <unsigned payouts file>",
+	Short: "signs exported payouts",
+	Long:  "signs payouts exported with 'pay --export-unsigned', intended to be run on an offline machine",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		bundleFile := args[0]
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		toFile, _ := cmd.Flags().GetString(TO_FILE_FLAG)
+		if toFile == "" {
+			toFile = bundleFile
+		}
+
+		bundle := assertRunWithResultAndErrorMessage(func() (*common.OfflineSigningBundle, error) {
+			return loadOfflineSigningBundleFromFile(bundleFile)
+		}, EXIT_PAYOUTS_READ_FAILURE, "failed to load unsigned payouts")
+		loaded := assertRunWithResultAndErrorMessage(loadConfigurationAndSigner, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load signer")
+		assertRunWithErrorMessage(bundle.Verify, EXIT_OPERTION_FAILED, "unsigned payouts verification failed")
+
+		if bundle.IsSigned() {
+			slog.Warn("payouts are already signed, signatures will be replaced")
+		}
+
+		switch {
+		case state.Global.GetWantsOutputJson():
+			slog.Info("payouts to sign", "source", bundle.Source, "cycles", bundle.Cycles, "payouts", bundle.GetPayouts(), "created_at", bundle.CreatedAt, "phase", "payouts_to_sign")
+		default:
+			utils.PrintPayouts(bundle.GetPayouts(), fmt.Sprintf("To Sign - %s", utils.FormatCycleNumbers(bundle.Cycles...)), true)
+		}
+		slog.Info("operations have to be broadcasted within the signing window", "created_at", bundle.CreatedAt, "ttl_blocks", bundle.TTL)
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("Do you want to sign above %d payouts in %d batches from %s?", len(bundle.GetPayouts()), len(bundle.Batches), bundle.Source))
+		}
+
+		// offline machine is not expected to reach notificators
+		policy := assertRunWithResultAndErrorMessage(func() (*common.SigningPolicy, error) {
+			return loadSigningPolicy(loaded.Configuration, bundle.Cycles, bundle.PreparationResult.ReportsOfPastSuccesfulPayouts)
+		}, EXIT_CONFIGURATION_LOAD_FAILURE, "failed to load signing policy")
+		assertRunWithErrorMessage(func() error {
+			return bundle.Sign(loaded.Signer, policy)
+		}, EXIT_OPERTION_FAILED, "failed to sign payouts")
+		assertRunWithParamAndErrorMessage(func(bundle *common.OfflineSigningBundle) error {
+			return writeOfflineSigningBundleToFile(toFile, bundle)
+		}, bundle, EXIT_PAYOUT_WRITE_FAILURE, "failed to write signed payouts")
+		slog.Info("payouts signed", "path", toFile, "batches", len(bundle.Batches), "phase", "result")
+		exit(0)
+	},
+}
+
+func init() {
+	signCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms signing")
+	signCmd.Flags().String(TO_FILE_FLAG, "", "writes signed payouts to file instead of overwriting the source file")
+	RootCmd.AddCommand(signCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var statementCmd = &cobra.Command{
+	Use:   "statement",
+	Short: "exports delegator statements",
+	Long:  "exports statements of rewards paid to delegators over cycle range or date range as csv, json and html files",
+	Run: func(cmd *cobra.Command, args []string) {
+		delegatorFlag, _ := cmd.Flags().GetString(DELEGATOR_FLAG)
+		outputDirectory, _ := cmd.Flags().GetString(OUTPUT_DIRECTORY_FLAG)
+		formatFlags, _ := cmd.Flags().GetStringSlice(FORMAT_FLAG)
+
+		delegator := tezos.InvalidAddress
+		if delegatorFlag != "" {
+			delegator = assertRunWithResultAndErrorMessage(func() (tezos.Address, error) {
+				return tezos.ParseAddress(delegatorFlag)
+			}, EXIT_IVNALID_ARGS, "invalid delegator address", "delegator", delegatorFlag)
+		}
+		formats := lo.Map(formatFlags, func(format string, _ int) enums.EStatementFormat { return enums.EStatementFormat(format) })
+		if unsupported, _ := lo.Difference(formats, enums.SUPPORTED_STATEMENT_FORMATS); len(unsupported) > 0 {
+			slog.Error("unsupported statement format", "formats", unsupported)
+			exit(EXIT_IVNALID_ARGS)
+		}
+
+		config, collector, _, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		reporter := loadReporter(config, &common.ReporterEngineOptions{})
+		cycles := getCyclesFromRangeFlags(cmd, collector)
+
+		reports := loadReportsOfCycles(reporter, cycles)
+
+		statements := core.BuildDelegatorStatements(config.BakerPKH, reports, delegator)
+		if len(statements) == 0 {
+			slog.Warn("no payouts found", "cycles", cycles, "delegator", delegatorFlag)
+			return
+		}
+		for _, statement := range statements {
+			files, err := core.WriteDelegatorStatement(&statement, outputDirectory, formats)
+			if err != nil {
+				slog.Error("failed to write statement", "delegator", statement.Delegator.String(), "error", err.Error())
+				exit(EXIT_PAYOUT_WRITE_FAILURE)
+			}
+			slog.Debug("statement written", "delegator", statement.Delegator.String(), "files", files)
+		}
+		slog.Info("statements exported", "statements", len(statements), "cycles", cycles, "directory", outputDirectory, "phase", "result")
+	},
+}
+
+func init() {
+	statementCmd.Flags().String(DELEGATOR_FLAG, "", "delegator to export statement for (exports statements of all delegators if not specified)")
+	statementCmd.Flags().Int64(CYCLE_FLAG, 0, "cycle to export statements for")
+	statementCmd.Flags().Int64(FIRST_CYCLE_FLAG, 0, "first cycle of the exported range (defaults to last cycle)")
+	statementCmd.Flags().Int64(LAST_CYCLE_FLAG, 0, "last cycle of the exported range (defaults to last completed cycle)")
+	statementCmd.Flags().String(START_DATE_FLAG, "", "start date of the exported range (format: 2024-02-01)")
+	statementCmd.Flags().String(END_DATE_FLAG, "", "end date of the exported range (format: 2024-02-01)")
+	statementCmd.Flags().String(MONTH_FLAG, "", "month to export statements for (format: 2024-02)")
+	statementCmd.Flags().String(OUTPUT_DIRECTORY_FLAG, "statements", "directory to write statements to")
+	statementCmd.Flags().StringSlice(FORMAT_FLAG, []string{"csv", "json", "html"}, "statement formats to write (csv, json, html)")
+	RootCmd.AddCommand(statementCmd)
+}
@@ -3,59 +3,166 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/tez-capital/tezpay/common"
-	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
 )
 
+type statisticsRow interface {
+	GetTableHeaders() []string
+	ToTableRowData() []string
+}
+
+// getStatisticsCycles uses range flags if any is set, otherwise last n cycles up to last cycle
+func getStatisticsCycles(cmd *cobra.Command, collector common.CollectorEngine) []int64 {
+	if lo.SomeBy([]string{CYCLE_FLAG, FIRST_CYCLE_FLAG, START_DATE_FLAG, MONTH_FLAG}, cmd.Flags().Changed) {
+		return getCyclesFromRangeFlags(cmd, collector)
+	}
+	n, _ := cmd.Flags().GetInt(CYCLES_FLAG)
+	lastCycle, _ := cmd.Flags().GetInt64(LAST_CYCLE_FLAG)
+	if lastCycle == 0 {
+		lastCycle = assertRunWithResult(collector.GetLastCompletedCycle, EXIT_OPERTION_FAILED)
+	}
+	cycles := make([]int64, 0, n)
+	for cycle := lastCycle - int64(n-1); cycle <= lastCycle; cycle++ {
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+func printStatisticsTable[T statisticsRow](data []T, header string) {
+	if len(data) == 0 {
+		slog.Warn("no statistics available")
+		return
+	}
+	utils.PrintTable(data[0].GetTableHeaders(), lo.Map(data, func(row T, _ int) []string { return row.ToTableRowData() }), header)
+}
+
 var statisticsCmd = &cobra.Command{
 	Use:   "statistics",
 	Short: "prints earning stats",
-	Long:  "prints out earning statiscs",
+	Long: `prints out earning statiscs
+
+Views:
+	summary - combined summary of selected cycles
+	delegators - per delegator history with received amounts and effective yield
+	cycles - per cycle trend of rewards, fees, delegators and missed rewards
+	churn - delegators joined and left between cycles
+	apy - estimated delegator, staker bonus and baker yields`,
 	Run: func(cmd *cobra.Command, args []string) {
-		n, _ := cmd.Flags().GetInt(CYCLES_FLAG)
-		lastCycle, _ := cmd.Flags().GetInt64(LAST_CYCLE_FLAG)
+		view := enums.EStatisticsView(lo.Must(cmd.Flags().GetString(VIEW_FLAG)))
+		format := enums.EStatisticsFormat(lo.Must(cmd.Flags().GetString(FORMAT_FLAG)))
+		outputFile, _ := cmd.Flags().GetString(OUTPUT_FILE_FLAG)
+		if !slices.Contains(enums.SUPPORTED_STATISTICS_VIEWS, view) {
+			slog.Error("unsupported statistics view", "view", view)
+			exit(EXIT_IVNALID_ARGS)
+		}
+		if !slices.Contains(enums.SUPPORTED_STATISTICS_FORMATS, format) {
+			slog.Error("unsupported statistics format", "format", format)
+			exit(EXIT_IVNALID_ARGS)
+		}
+		if format == enums.STATISTICS_FORMAT_TABLE && outputFile != "" {
+			slog.Error("output file requires csv or json format")
+			exit(EXIT_IVNALID_ARGS)
+		}
 
 		config, collector, _, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
-		if lastCycle == 0 {
-			lastCycle = assertRunWithResult(collector.GetLastCompletedCycle, EXIT_OPERTION_FAILED)
+		reporter := loadReporter(config, &common.ReporterEngineOptions{})
+		cycles := getStatisticsCycles(cmd, collector)
+		if len(cycles) == 0 {
+			slog.Error("no cycles selected, check cycle range flags")
+			exit(EXIT_IVNALID_ARGS)
 		}
-		fsReporter := reporter_engines.NewFileSystemReporter(config, &common.ReporterEngineOptions{})
 
 		var total common.CyclePayoutSummary
-		ok := 0
-		collectedCycles := make([]int64, 0, n)
-		for i := 0; i < n; i++ {
-			cycle := lastCycle - int64(i)
-			summary, err := fsReporter.GetExistingCycleSummary(cycle)
+		summaries := make([]common.CyclePayoutSummary, 0, len(cycles))
+		collectedCycles := make([]int64, 0, len(cycles))
+		for _, cycle := range cycles {
+			summary, err := reporter.GetExistingCycleSummary(cycle)
 			if err != nil {
 				slog.Warn("failed to read report", "cycle", cycle, "error", err.Error())
 				continue
 			}
 			total = *total.CombineNumericData(summary)
+			summaries = append(summaries, *summary)
 			collectedCycles = append(collectedCycles, cycle)
-			ok++
+		}
+		var reports []common.PayoutReport
+		if view == enums.STATISTICS_VIEW_DELEGATORS || view == enums.STATISTICS_VIEW_CHURN || view == enums.STATISTICS_VIEW_APY {
+			reports = loadReportsOfCycles(reporter, collectedCycles)
 		}
 
-		firstCycle := lastCycle - int64(n-1)
-		header := fmt.Sprintf("Statistics #%d - #%d", firstCycle, lastCycle)
-		if firstCycle == lastCycle {
-			header = fmt.Sprintf("Statistics #%d", lastCycle)
+		header := fmt.Sprintf("Statistics #%d - #%d", cycles[0], cycles[len(cycles)-1])
+		if len(cycles) == 1 {
+			header = fmt.Sprintf("Statistics #%d", cycles[0])
+		}
+
+		var data any
+		switch view {
+		case enums.STATISTICS_VIEW_DELEGATORS:
+			data = core.BuildDelegatorStatistics(reports)
+		case enums.STATISTICS_VIEW_CYCLES:
+			data = core.BuildCycleStatistics(summaries)
+		case enums.STATISTICS_VIEW_CHURN:
+			data = core.BuildChurnStatistics(reports)
+		case enums.STATISTICS_VIEW_APY:
+			data = core.BuildApyStatistics(summaries, reports)
+		default:
+			data = &total
+		}
+
+		if format != enums.STATISTICS_FORMAT_TABLE {
+			output := assertRunWithResultAndErrorMessage(func() ([]byte, error) {
+				return core.MarshalStatistics(data, format)
+			}, EXIT_OPERTION_FAILED, "failed to export statistics", "view", view, "format", format)
+			if outputFile == "" {
+				fmt.Print(string(output))
+				return
+			}
+			assertRunWithErrorMessage(func() error {
+				return os.WriteFile(outputFile, output, 0644)
+			}, EXIT_PAYOUT_WRITE_FAILURE, "failed to write statistics", "file", outputFile)
+			slog.Info("statistics exported", "view", view, "format", format, "file", outputFile)
+			return
 		}
 
 		if state.Global.GetWantsOutputJson() {
-			slog.Info("statistics generated", "result", total, "cycles", collectedCycles, "phase", "result")
+			slog.Info("statistics generated", "view", view, "result", data, "cycles", collectedCycles, "phase", "result")
 			return
 		}
-		utils.PrintCycleSummary(total, header)
+		switch data := data.(type) {
+		case []common.DelegatorStatistics:
+			printStatisticsTable(lo.ToSlicePtr(data), header)
+		case []common.CycleStatistics:
+			printStatisticsTable(lo.ToSlicePtr(data), header)
+		case []common.ChurnStatistics:
+			printStatisticsTable(lo.ToSlicePtr(data), header)
+		case []common.ApyStatistics:
+			printStatisticsTable(lo.ToSlicePtr(data), header)
+		default:
+			utils.PrintCycleSummary(total, header)
+			utils.PrintInvalidPayoutsBreakdowns(total.InvalidPayouts, "Invalid Payouts Breakdown")
+		}
 	},
 }
 
 func init() {
 	statisticsCmd.Flags().Int(CYCLES_FLAG, 10, "number of cycles to collect statistics from")
 	statisticsCmd.Flags().Int64(LAST_CYCLE_FLAG, 0, "last cycle to collect statistics from (has priority over --cycles)")
+	statisticsCmd.Flags().Int64(FIRST_CYCLE_FLAG, 0, "first cycle to collect statistics from (replaces --cycles)")
+	statisticsCmd.Flags().Int64(CYCLE_FLAG, 0, "single cycle to collect statistics from")
+	statisticsCmd.Flags().String(START_DATE_FLAG, "", "start date of the range to collect statistics from (format: 2024-02-01)")
+	statisticsCmd.Flags().String(END_DATE_FLAG, "", "end date of the range to collect statistics from (format: 2024-02-01)")
+	statisticsCmd.Flags().String(MONTH_FLAG, "", "month to collect statistics from (format: 2024-02)")
+	statisticsCmd.Flags().String(VIEW_FLAG, string(enums.STATISTICS_VIEW_SUMMARY), "statistics view (summary, delegators, cycles, churn, apy)")
+	statisticsCmd.Flags().String(FORMAT_FLAG, string(enums.STATISTICS_FORMAT_TABLE), "output format (table, csv, json)")
+	statisticsCmd.Flags().String(OUTPUT_FILE_FLAG, "", "file to write csv or json output to (prints to stdout if not set)")
 	RootCmd.AddCommand(statisticsCmd)
 }
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"log/slog"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/tez-capital/tezpay/constants/enums"
@@ -26,14 +25,14 @@ var extensionTestCmd = &cobra.Command{
 		}
 		if err := extension.ExecuteHook(enums.EXTENSION_HOOK_TEST_NOTIFY, "0.1", &data); err != nil {
 			slog.Error("failed to execute hook", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 			return
 		}
 		slog.Info("test-notify hook executed successfully")
 		extension.CloseScopedExtensions()
 		if err := extension.ExecuteHook(enums.EXTENSION_HOOK_TEST_REQUEST, "0.1", &data); err != nil {
 			slog.Error("failed to execute hook", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 			return
 		}
 		slog.Info("test-request hook executed successfully", "response message", data.Message)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 
@@ -26,7 +25,7 @@ var transferCmd = &cobra.Command{
 
 		if len(args)%2 != 0 {
 			slog.Error("invalid number of arguments (expects pairs of destination and amount)")
-			os.Exit(EXIT_IVNALID_ARGS)
+			exit(EXIT_IVNALID_ARGS)
 		}
 		total := int64(0)
 
@@ -38,13 +37,13 @@ var transferCmd = &cobra.Command{
 			destination, err := tezos.ParseAddress(args[i])
 			if err != nil {
 				slog.Error("invalid destination address", "address", args[i], "error", err.Error())
-				os.Exit(EXIT_IVNALID_ARGS)
+				exit(EXIT_IVNALID_ARGS)
 			}
 
 			amount, err := strconv.ParseFloat(args[i+1], 64)
 			if err != nil {
 				slog.Error("invalid amount", "amount", args[i+1], "error", err.Error())
-				os.Exit(EXIT_IVNALID_ARGS)
+				exit(EXIT_IVNALID_ARGS)
 			}
 			if !mutez {
 				amount *= constants.MUTEZ_FACTOR
@@ -57,7 +56,7 @@ var transferCmd = &cobra.Command{
 		}
 
 		if err := requireConfirmation(fmt.Sprintf("do you really want to transfer %s to %s", common.MutezToTezS(total), strings.Join(destinations, ", "))); err != nil {
-			os.Exit(EXIT_OPERTION_CANCELED)
+			exit(EXIT_OPERTION_CANCELED)
 		}
 		slog.Info("transferring tez", "total", common.MutezToTezS(total), "destinations", strings.Join(destinations, ", "), "confirmations_required", constants.DEFAULT_REQUIRED_CONFIRMATIONS)
 		opts := rpc.DefaultOptions
@@ -67,11 +66,11 @@ var transferCmd = &cobra.Command{
 		rcpt, err := transactor.Send(op, &opts)
 		if err != nil {
 			slog.Error("failed to confirm tx", "error", err.Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 		if !rcpt.IsSuccess() {
 			slog.Error("tx failed", "error", rcpt.Error().Error())
-			os.Exit(EXIT_OPERTION_FAILED)
+			exit(EXIT_OPERTION_FAILED)
 		}
 		slog.Info("transfer successful")
 	},
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	reporter_engines "github.com/tez-capital/tezpay/engines/reporter"
+)
+
+var verifyLedgerCmd = &cobra.Command{
+	Use:   "verify-ledger",
+	Short: "verifies payout ledger",
+	Long:  "verifies integrity of the hash chained payout ledger and cross-checks it against payout reports",
+	Run: func(cmd *cobra.Command, args []string) {
+		checkChain, _ := cmd.Flags().GetBool(CHECK_CHAIN_FLAG)
+
+		config, collector, _, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		options := &common.ReporterEngineOptions{}
+		entries := assertRunWithResultAndErrorMessage(func() ([]reporter_engines.LedgerEntry, error) {
+			return reporter_engines.ReadLedger(config, options)
+		}, EXIT_PAYOUTS_READ_FAILURE, "failed to read payout ledger")
+		if len(entries) == 0 {
+			slog.Warn("payout ledger is empty, enable 'reports.ledger' in configuration to record payouts")
+			return
+		}
+		reporter := loadReporter(config, options)
+
+		violations := reporter_engines.VerifyLedgerChain(entries)
+		violations = append(violations, reporter_engines.VerifyLedgerReports(entries, reporter)...)
+		if checkChain {
+			slog.Info("checking operations on chain")
+			violations = append(violations, reporter_engines.VerifyLedgerOperations(entries, collector)...)
+		}
+
+		for _, violation := range violations {
+			slog.Error("ledger violation", "index", violation.Index, "cycle", violation.Cycle, "reason", violation.Reason)
+		}
+		if len(violations) > 0 {
+			slog.Error("payout ledger verification failed", "entries", len(entries), "violations", len(violations), "phase", "result")
+			exit(EXIT_OPERTION_FAILED)
+		}
+		slog.Info("payout ledger verified", "entries", len(entries), "phase", "result")
+	},
+}
+
+func init() {
+	verifyLedgerCmd.Flags().Bool(CHECK_CHAIN_FLAG, false, "check operations of successful payouts were applied on chain")
+	RootCmd.AddCommand(verifyLedgerCmd)
+}
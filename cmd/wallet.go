@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "payout wallet management",
+	Long:  "shows payout wallet status, reveals payout wallet and rotates payout wallet key",
+}
+
+var walletStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "shows payout wallet status",
+	Long:  "shows payout wallet balance, reveal state and counter",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, collector, signer, _ := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+
+		status := assertRunWithResultAndErrorMessage(func() (*common.WalletStatus, error) {
+			return core.GetWalletStatus(collector, signer.GetPKH())
+		}, EXIT_OPERTION_FAILED, "failed to get payout wallet status")
+
+		slog.Info("payout wallet status", "wallet", status.Address.String(), "balance", common.FormatTezAmount(status.Balance.Int64()), "revealed", status.IsRevealed, "counter", status.Counter, "phase", "payout_wallet_status")
+	},
+}
+
+var walletRevealCmd = &cobra.Command{
+	Use:   "reveal",
+	Short: "reveals payout wallet",
+	Long:  "publishes payout wallet public key so the wallet is able to send payouts",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, collector, signer, transactor := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
+
+		if !confirmed {
+			assertRequireConfirmation(fmt.Sprintf("do you really want to reveal payout wallet %s", signer.GetPKH()))
+		}
+		slog.Info("revealing payout wallet", "wallet", signer.GetPKH().String(), "confirmations_required", confirmations)
+		rcpt, err := core.RevealWallet(collector, signer, transactor, confirmations)
+		switch {
+		case errors.Is(err, constants.ErrWalletAlreadyRevealed):
+			slog.Info("payout wallet is already revealed", "wallet", signer.GetPKH().String())
+		case err != nil:
+			slog.Error("failed to reveal payout wallet", "error", err.Error())
+			exit(EXIT_OPERTION_FAILED)
+		default:
+			slog.Info("payout wallet revealed", "wallet", signer.GetPKH().String(), "op_hash", rcpt.Op.Hash.String())
+		}
+	},
+}
+
+// loadRotationKey imports key from file (encrypted keys are decrypted with the usual passphrase sources) or generates a new ed25519 key
+func loadRotationKey(importKeyFile string) (tezos.PrivateKey, error) {
+	if importKeyFile == "" {
+		return tezos.GenerateKey(tezos.KeyTypeEd25519)
+	}
+	keyBytes, err := os.ReadFile(importKeyFile)
+	if err != nil {
+		return tezos.PrivateKey{}, err
+	}
+	signer, err := signer_engines.InitInMemorySigner(strings.TrimSpace(string(keyBytes)))
+	if err != nil {
+		return tezos.PrivateKey{}, err
+	}
+	return signer.Key, nil
+}
+
+func isPrivateKeyFileEncrypted(privateKeyFile string) (bool, error) {
+	keyBytes, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return false, err
+	}
+	key := strings.TrimSpace(string(keyBytes))
+	return strings.HasPrefix(key, "encrypted:") || tezos.IsEncryptedKey(key), nil
+}
+
+// replacePrivateKeyFile archives current private key file and writes the new key in its place.
+// New key is encrypted if the current one was.
+func replacePrivateKeyFile(privateKeyFile string, oldAddress tezos.Address, newKey tezos.PrivateKey) (string, error) {
+	encrypted, err := isPrivateKeyFileEncrypted(privateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	newKeyContent := newKey.String()
+	if encrypted {
+		slog.Info("current private key is encrypted, the new one will be encrypted as well")
+		if newKeyContent, err = encryptPrivateKey(newKey); err != nil {
+			return "", err
+		}
+	}
+
+	oldKeyBytes, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	archiveFile := fmt.Sprintf("%s.%s.%d.archived", privateKeyFile, oldAddress, time.Now().Unix())
+	if err := os.WriteFile(archiveFile, oldKeyBytes, 0600); err != nil {
+		return "", err
+	}
+	if err := writePrivateKeyFile(privateKeyFile, newKeyContent); err != nil {
+		return archiveFile, err
+	}
+	return archiveFile, nil
+}
+
+var walletRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "rotates payout wallet key",
+	Long: `replaces payout wallet key with a newly generated or imported one
+
+The current private key file is archived next to the original one, the new key is written in its place
+and the remaining balance is transferred from the old wallet to the new one. The new wallet is revealed afterwards.
+Only local private key wallet mode is supported.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, collector, signer, transactor := assertRunWithResult(loadConfigurationEnginesExtensions, EXIT_CONFIGURATION_LOAD_FAILURE).Unwrap()
+		confirmed, _ := cmd.Flags().GetBool(CONFIRM_FLAG)
+		importKeyFile, _ := cmd.Flags().GetString(IMPORT_KEY_FILE_FLAG)
+		skipTransfer, _ := cmd.Flags().GetBool(SKIP_TRANSFER_FLAG)
+		skipReveal, _ := cmd.Flags().GetBool(SKIP_REVEAL_FLAG)
+		confirmations, _ := cmd.Flags().GetInt64(CONFIRMATIONS_FLAG)
+
+		walletMode := config.PayoutConfiguration.WalletMode
+		if state.Global.SignerOverride != nil || (walletMode != enums.WALLET_MODE_LOCAL_PRIVATE_KEY && walletMode != enums.WALLET_MODE_LOCAL_PRIVATE_KEY2) {
+			slog.Error("failed to rotate payout wallet key", "wallet_mode", walletMode, "error", constants.ErrWalletRotationUnsupported.Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+
+		newKey := assertRunWithResultAndErrorMessage(func() (tezos.PrivateKey, error) {
+			return loadRotationKey(importKeyFile)
+		}, EXIT_OPERTION_FAILED, "failed to load new key", "import_key_file", importKeyFile)
+		if newKey.Address().Equal(signer.GetPKH()) {
+			slog.Error("failed to rotate payout wallet key", "error", constants.ErrWalletRotationSameKey.Error())
+			exit(EXIT_IVNALID_ARGS)
+		}
+
+		var sweep *common.WalletSweep
+		msg := fmt.Sprintf("do you really want to rotate payout wallet %s to %s without transferring remaining balance", signer.GetPKH(), newKey.Address())
+		if !skipTransfer {
+			sweep = assertRunWithResultAndErrorMessage(func() (*common.WalletSweep, error) {
+				return core.EstimateWalletSweep(collector, config, signer.GetKey(), newKey.Address())
+			}, EXIT_OPERTION_FAILED, "failed to estimate transfer of remaining balance, use --skip-transfer to rotate the key without moving funds")
+			msg = fmt.Sprintf("do you really want to rotate payout wallet %s to %s and transfer %s (fee %s, burn %s)", signer.GetPKH(), newKey.Address(), common.MutezToTezS(sweep.Amount), common.MutezToTezS(sweep.Fee), common.MutezToTezS(sweep.Burn))
+		}
+		if !confirmed {
+			assertRequireConfirmation(msg)
+		}
+
+		// the new key has to be stored before any funds are moved to it
+		privateKeyFile := state.Global.GetPrivateKeyFilePath()
+		archiveFile, err := replacePrivateKeyFile(privateKeyFile, signer.GetPKH(), newKey)
+		if err != nil {
+			slog.Error("failed to rotate payout wallet key", "path", privateKeyFile, "archive", archiveFile, "error", errors.Join(constants.ErrWalletRotationFailed, err).Error())
+			exit(EXIT_OPERTION_FAILED)
+		}
+		slog.Info("payout wallet key replaced", "old_wallet", signer.GetPKH().String(), "new_wallet", newKey.Address().String(), "path", privateKeyFile, "archive", archiveFile)
+
+		if sweep != nil {
+			slog.Info("transferring remaining balance", "amount", common.MutezToTezS(sweep.Amount), "destination", sweep.Destination.String(), "confirmations_required", confirmations)
+			rcpt, err := core.SweepWallet(sweep, signer, transactor, confirmations)
+			if err != nil {
+				slog.Error("failed to transfer remaining balance, funds remain in the old wallet, its key is archived", "archive", archiveFile, "error", err.Error())
+				exit(EXIT_OPERTION_FAILED)
+			}
+			slog.Info("remaining balance transferred", "op_hash", rcpt.Op.Hash.String())
+		}
+
+		if !skipReveal {
+			newSigner := &signer_engines.InMemorySigner{Key: newKey}
+			slog.Info("revealing new payout wallet", "wallet", newSigner.GetPKH().String())
+			if _, err := core.RevealWallet(collector, newSigner, transactor, confirmations); err != nil && !errors.Is(err, constants.ErrWalletAlreadyRevealed) {
+				slog.Warn("failed to reveal new payout wallet, reveal it with 'tezpay wallet reveal' once funded", "wallet", newSigner.GetPKH().String(), "error", err.Error())
+			}
+		}
+		slog.Info("payout wallet key rotated", "old_wallet", signer.GetPKH().String(), "new_wallet", newKey.Address().String())
+	},
+}
+
+func init() {
+	walletRevealCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms reveal")
+	walletRevealCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider reveal confirmed")
+
+	walletRotateCmd.Flags().Bool(CONFIRM_FLAG, false, "automatically confirms rotation")
+	walletRotateCmd.Flags().String(IMPORT_KEY_FILE_FLAG, "", "imports new key from file instead of generating one")
+	walletRotateCmd.Flags().Bool(SKIP_TRANSFER_FLAG, false, "does not transfer remaining balance to the new wallet")
+	walletRotateCmd.Flags().Bool(SKIP_REVEAL_FLAG, false, "does not reveal the new wallet")
+	walletRotateCmd.Flags().Int64(CONFIRMATIONS_FLAG, constants.DEFAULT_REQUIRED_CONFIRMATIONS, "number of blocks on top of the operation block required to consider operations confirmed")
+
+	walletCmd.AddCommand(walletStatusCmd)
+	walletCmd.AddCommand(walletRevealCmd)
+	walletCmd.AddCommand(walletRotateCmd)
+	RootCmd.AddCommand(walletCmd)
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// AccountingRecord is successful payout valued in fiat currencies at the time of payment
+type AccountingRecord struct {
+	Timestamp      time.Time                 `json:"timestamp"`
+	Cycle          int64                     `json:"cycle"`
+	Category       enums.EAccountingCategory `json:"category"`
+	Kind           enums.EPayoutKind         `json:"kind"`
+	Delegator      tezos.Address             `json:"delegator,omitempty"`
+	Recipient      tezos.Address             `json:"recipient"`
+	Amount         tezos.Z                   `json:"amount"`
+	Fee            tezos.Z                   `json:"fee"`
+	TransactionFee tezos.Z                   `json:"tx_fee"`
+	OpHash         tezos.OpHash              `json:"op_hash"`
+	// Prices of 1 tez by currency at the time of payment
+	Prices map[string]float64 `json:"prices"`
+}
+
+func (record *AccountingRecord) GetFiatValue(amount tezos.Z, currency string) float64 {
+	return float64(amount.Int64()) / constants.MUTEZ_FACTOR * record.Prices[currency]
+}
@@ -55,9 +55,11 @@ func (b *batchBlueprint) ToBatch() RecipeBatch {
 
 type RecipeBatch []PayoutRecipe
 
-func (b *RecipeBatch) ToOpExecutionContext(signer SignerEngine, transactor TransactorEngine) (*OpExecutionContext, error) {
-	op := codec.NewOp().WithSource(signer.GetPKH())
-	op.WithTTL(constants.MAX_OPERATION_TTL)
+// ToUnsignedOp forges the batch into an operation which is completed (branch, counter) but not signed.
+// If counter is greater than 0 it is used for the first content instead of the one fetched from the chain.
+func (b *RecipeBatch) ToUnsignedOp(source tezos.Address, key tezos.Key, transactor TransactorEngine, ttl int64, counter int64) (*codec.Op, error) {
+	op := codec.NewOp().WithSource(source)
+	op.WithTTL(ttl)
 
 	serializationGasLimit := lo.Reduce(*b, func(acc int64, p PayoutRecipe, _ int) int64 {
 		return acc + p.OpLimits.DeserializationGasLimit
@@ -68,17 +70,34 @@ func (b *RecipeBatch) ToOpExecutionContext(signer SignerEngine, transactor Trans
 		if i == 0 {
 			buffer = serializationGasLimit
 		}
-		InjectTransferContentsWithLimits(op, signer.GetPKH(), &p, tezos.Limits{
+		InjectTransferContentsWithLimits(op, source, &p, tezos.Limits{
 			Fee:          p.OpLimits.TransactionFee,
 			GasLimit:     p.OpLimits.GasLimit + buffer,
 			StorageLimit: p.OpLimits.StorageLimit,
 		})
 	}
 
-	err := transactor.Complete(op, signer.GetKey())
+	if counter > 0 {
+		for i := range op.Contents {
+			op.Contents[i].WithCounter(counter + int64(i))
+		}
+	}
+
+	err := transactor.Complete(op, key)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (b *RecipeBatch) ToOpExecutionContext(signer SignerEngine, transactor TransactorEngine, policy *SigningPolicy) (*OpExecutionContext, error) {
+	op, err := b.ToUnsignedOp(signer.GetPKH(), signer.GetKey(), transactor, constants.MAX_OPERATION_TTL, 0)
 	if err != nil {
 		return nil, err
 	}
+	if err := policy.Check(*b, op); err != nil {
+		return nil, err
+	}
 
 	slog.Debug("new op context", "op", op.Bytes(), "op_hash", op.Hash())
 	err = signer.Sign(op)
@@ -6,10 +6,11 @@ import (
 )
 
 type BatchResult struct {
-	Payouts   []PayoutRecipe `json:"payouts"`
-	OpHash    tezos.OpHash   `json:"op_hash"`
-	IsSuccess bool           `json:"is_success"`
-	Err       error          `json:"err"`
+	Payouts           []PayoutRecipe             `json:"payouts"`
+	OpHash            tezos.OpHash               `json:"op_hash"`
+	IsSuccess         bool                       `json:"is_success"`
+	Err               error                      `json:"err"`
+	ConfirmationState OperationConfirmationState `json:"confirmation_state,omitempty"`
 }
 
 func NewFailedBatchResult(payouts []PayoutRecipe, err error) *BatchResult {
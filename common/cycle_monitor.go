@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/trilitech/tzgo/rpc"
 )
 
@@ -70,6 +71,10 @@ func (monitor *cycleMonitor) CreateBlockHeaderMonitor() error {
 				continue
 			}
 			cycle := metadata.LevelInfo.Cycle
+			if params := monitor.rpc.Params; params != nil && params.BlocksPerCycle > 0 {
+				remainingBlocks := params.BlocksPerCycle - metadata.LevelInfo.CyclePosition
+				metrics.SetNextCycleExpectedAt(time.Now().Add(time.Duration(remainingBlocks) * params.MinimalBlockDelay))
+			}
 
 			if metadata.LevelInfo.CyclePosition >= monitor.options.NotificationDelay {
 				monitor.Cycle <- cycle
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/micheline"
 	"github.com/trilitech/tzgo/rpc"
 	"github.com/trilitech/tzgo/signer"
 	"github.com/trilitech/tzgo/tezos"
@@ -18,6 +19,16 @@ const (
 	OPERATION_STATUS_UNKNOWN    OperationStatus = "unknown"
 )
 
+type OperationConfirmationState string
+
+const (
+	OPERATION_CONFIRMATION_STATE_INCLUDED  OperationConfirmationState = "included"
+	OPERATION_CONFIRMATION_STATE_CONFIRMED OperationConfirmationState = "confirmed"
+	OPERATION_CONFIRMATION_STATE_FINALIZED OperationConfirmationState = "finalized"
+	OPERATION_CONFIRMATION_STATE_REORGED   OperationConfirmationState = "reorged"
+	OPERATION_CONFIRMATION_STATE_RETRY     OperationConfirmationState = "retry"
+)
+
 type CollectorEngine interface {
 	GetId() string
 	RefreshParams() error
@@ -33,6 +44,8 @@ type CollectorEngine interface {
 	SendAnalytics(bakerId string, version string)
 	GetCurrentProtocol() (tezos.ProtocolHash, error)
 	IsRevealed(addr tezos.Address) (bool, error)
+	GetMempoolFeeLevels() (*MempoolFeeLevels, error)
+	GetWalletStatus(addr tezos.Address) (*WalletStatus, error)
 }
 
 type SignerEngine interface {
@@ -43,9 +56,17 @@ type SignerEngine interface {
 	GetSigner() signer.Signer
 }
 
+// PayloadSignerEngine is implemented by signers able to sign arbitrary (packed michelson) data, e.g. multisig proposals
+type PayloadSignerEngine interface {
+	SignPayload(data []byte) (tezos.Signature, error)
+}
+
 type OpResult interface {
 	GetOpHash() tezos.OpHash
 	WaitForApply() error
+	// WaitForFinality tracks included operation until it is confirmed by required number of blocks and finalized.
+	// Returns ErrOperationDroppedByReorg if the operation disappears from the chain and can not be included anymore.
+	WaitForFinality(confirmations int64, onStateChange func(state OperationConfirmationState, level int64)) error
 }
 
 type TransactorEngine interface {
@@ -55,6 +76,21 @@ type TransactorEngine interface {
 	Dispatch(op *codec.Op, opts *rpc.CallOptions) (OpResult, error)
 	Send(op *codec.Op, opts *rpc.CallOptions) (*rpc.Receipt, error)
 	GetLimits() (*OperationLimits, error)
+	GetChainId() (tezos.ChainIdHash, error)
+	GetContractStorage(contract tezos.Address) (micheline.Prim, error)
+	Simulate(op *codec.Op, key tezos.Key) (*rpc.Receipt, error)
+}
+
+type TopUpEngine interface {
+	GetId() string
+	// TopUp transfers the shortfall increased by configured buffer to the destination and returns transferred amount
+	TopUp(destination tezos.Address, shortfall tezos.Z) (tezos.Z, error)
+}
+
+type PriceEngine interface {
+	GetId() string
+	// GetPrice returns price of 1 tez in the currency at the time
+	GetPrice(currency string, at time.Time) (float64, error)
 }
 
 type NotificatorEngine interface {
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// InvalidPayoutsBreakdown sums invalid payouts of a single reason. Amount is the share of rewards
+// the invalid recipes would have received before fees. Invalid payouts are never sent, so nothing
+// is burned - the amount is either redistributed to other delegators or kept by the baker.
+type InvalidPayoutsBreakdown struct {
+	Reason           enums.EPayoutInvalidReason `json:"reason" csv:"reason"`
+	Count            int                        `json:"count" csv:"count"`
+	DelegatedBalance tezos.Z                    `json:"delegated_balance" csv:"delegated_balance"`
+	Amount           tezos.Z                    `json:"amount" csv:"amount"`
+	Redistributed    tezos.Z                    `json:"redistributed" csv:"redistributed"`
+	KeptByBaker      tezos.Z                    `json:"kept_by_baker" csv:"kept_by_baker"`
+}
+
+func (breakdown *InvalidPayoutsBreakdown) Add(another *InvalidPayoutsBreakdown) {
+	breakdown.Count += another.Count
+	breakdown.DelegatedBalance = breakdown.DelegatedBalance.Add(another.DelegatedBalance)
+	breakdown.Amount = breakdown.Amount.Add(another.Amount)
+	breakdown.Redistributed = breakdown.Redistributed.Add(another.Redistributed)
+	breakdown.KeptByBaker = breakdown.KeptByBaker.Add(another.KeptByBaker)
+}
+
+func (breakdown *InvalidPayoutsBreakdown) GetTableHeaders() []string {
+	return []string{"Reason", "Count", "Delegated Balance", "Amount", "Redistributed", "Kept By Baker"}
+}
+
+func (breakdown *InvalidPayoutsBreakdown) ToTableRowData() []string {
+	return []string{
+		string(breakdown.Reason),
+		strconv.Itoa(breakdown.Count),
+		MutezToTezS(breakdown.DelegatedBalance.Int64()),
+		MutezToTezS(breakdown.Amount.Int64()),
+		MutezToTezS(breakdown.Redistributed.Int64()),
+		MutezToTezS(breakdown.KeptByBaker.Int64()),
+	}
+}
+
+// CombineInvalidPayoutsBreakdowns merges breakdowns by reason, result is ordered by reason
+func CombineInvalidPayoutsBreakdowns(breakdowns ...[]InvalidPayoutsBreakdown) []InvalidPayoutsBreakdown {
+	combined := make([]InvalidPayoutsBreakdown, 0)
+	for _, list := range breakdowns {
+		for _, breakdown := range list {
+			index := slices.IndexFunc(combined, func(existing InvalidPayoutsBreakdown) bool { return existing.Reason == breakdown.Reason })
+			if index < 0 {
+				combined = append(combined, InvalidPayoutsBreakdown{Reason: breakdown.Reason})
+				index = len(combined) - 1
+			}
+			combined[index].Add(&breakdown)
+		}
+	}
+	slices.SortFunc(combined, func(a, b InvalidPayoutsBreakdown) int {
+		switch {
+		case a.Reason < b.Reason:
+			return -1
+		case a.Reason > b.Reason:
+			return 1
+		}
+		return 0
+	})
+	return combined
+}
+
+// GetInvalidPayoutsTemplateData returns totals of invalid payouts for notification templates,
+// totals of individual reasons are available as InvalidPayouts_<REASON>_<Field>
+func (summary *CyclePayoutSummary) GetInvalidPayoutsTemplateData() map[string]string {
+	total := InvalidPayoutsBreakdown{}
+	data := make(map[string]string)
+	for _, breakdown := range summary.InvalidPayouts {
+		total.Add(&breakdown)
+		data[fmt.Sprintf("InvalidPayouts_%s_Count", breakdown.Reason)] = strconv.Itoa(breakdown.Count)
+		data[fmt.Sprintf("InvalidPayouts_%s_Amount", breakdown.Reason)] = MutezToTezS(breakdown.Amount.Int64())
+	}
+	data["InvalidPayoutsCount"] = strconv.Itoa(total.Count)
+	data["InvalidPayoutsDelegatedBalance"] = MutezToTezS(total.DelegatedBalance.Int64())
+	data["InvalidPayoutsAmount"] = MutezToTezS(total.Amount.Int64())
+	data["InvalidPayoutsRedistributed"] = MutezToTezS(total.Redistributed.Int64())
+	data["InvalidPayoutsKeptByBaker"] = MutezToTezS(total.KeptByBaker.Int64())
+	return data
+}
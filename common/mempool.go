@@ -0,0 +1,26 @@
+package common
+
+import (
+	"math"
+	"slices"
+)
+
+// MempoolFeeLevels describes fees required by the node mempool filter and fees paid by pending operations
+type MempoolFeeLevels struct {
+	MinimalFees              int64   `json:"minimal_fees"`
+	MinimalNanotezPerGasUnit float64 `json:"minimal_nanotez_per_gas_unit"`
+	MinimalNanotezPerByte    float64 `json:"minimal_nanotez_per_byte"`
+	// fee rates of pending manager operations in nanotez per gas unit
+	PendingFeeRates []float64 `json:"pending_fee_rates,omitempty"`
+}
+
+// GetPendingFeeRate returns fee rate (nanotez per gas unit) at given percentile (0-100) of pending operations
+func (levels *MempoolFeeLevels) GetPendingFeeRate(percentile float64) float64 {
+	if len(levels.PendingFeeRates) == 0 {
+		return 0
+	}
+	rates := slices.Clone(levels.PendingFeeRates)
+	slices.Sort(rates)
+	index := int(math.Ceil(percentile/100*float64(len(rates)))) - 1
+	return rates[max(0, min(index, len(rates)-1))]
+}
@@ -0,0 +1,365 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/micheline"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	MULTISIG_MAIN_ENTRYPOINT = "main"
+)
+
+// MultisigStorage is the storage of the generic multisig contract - (pair (nat %stored_counter) (pair (nat %threshold) (list %keys key)))
+type MultisigStorage struct {
+	Counter   int64       `json:"counter"`
+	Threshold int64       `json:"threshold"`
+	Keys      []tezos.Key `json:"keys"`
+}
+
+func flattenPair(prim micheline.Prim) []micheline.Prim {
+	if prim.OpCode != micheline.D_PAIR || len(prim.Args) < 2 {
+		return []micheline.Prim{prim}
+	}
+	result := append([]micheline.Prim{}, prim.Args[:len(prim.Args)-1]...)
+	return append(result, flattenPair(prim.Args[len(prim.Args)-1])...)
+}
+
+func parseMichelineKey(prim micheline.Prim) (tezos.Key, error) {
+	switch prim.Type {
+	case micheline.PrimString:
+		return tezos.ParseKey(prim.String)
+	case micheline.PrimBytes:
+		return tezos.DecodeKey(prim.Bytes)
+	default:
+		return tezos.InvalidKey, fmt.Errorf("unexpected key primitive %s", prim.Dump())
+	}
+}
+
+func ParseMultisigStorage(storage micheline.Prim) (*MultisigStorage, error) {
+	values := flattenPair(storage)
+	if len(values) != 3 || values[0].Type != micheline.PrimInt || values[1].Type != micheline.PrimInt || values[2].Type != micheline.PrimSequence {
+		return nil, constants.ErrMultisigInvalidStorage
+	}
+	keys := make([]tezos.Key, 0, len(values[2].Args))
+	for _, prim := range values[2].Args {
+		key, err := parseMichelineKey(prim)
+		if err != nil {
+			return nil, errors.Join(constants.ErrMultisigInvalidStorage, err)
+		}
+		keys = append(keys, key)
+	}
+	return &MultisigStorage{
+		Counter:   values[0].Int.Int64(),
+		Threshold: values[1].Int.Int64(),
+		Keys:      keys,
+	}, nil
+}
+
+func (storage *MultisigStorage) IsAuthorized(key tezos.Key) bool {
+	for _, k := range storage.Keys {
+		if k.IsEqual(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func failIfNone() micheline.Prim {
+	return micheline.NewCode(micheline.I_IF_NONE,
+		micheline.NewSeq(micheline.NewCode(micheline.I_UNIT), micheline.NewCode(micheline.I_FAILWITH)),
+		micheline.NewSeq(),
+	)
+}
+
+// buildMultisigTransfer builds michelson code which adds the transfer on top of the operation list on stack.
+// Addresses are encoded as bytes so the code packs the same way as the contract does.
+func buildMultisigTransfer(source tezos.Address, p TransferArgs) ([]micheline.Prim, error) {
+	address := micheline.NewPrim(micheline.T_ADDRESS)
+	nat := micheline.NewPrim(micheline.T_NAT)
+	switch p.GetTxKind() {
+	case enums.PAYOUT_TX_KIND_FA1_2:
+		if p.GetFAContract().Equal(tezos.ZeroAddress) || p.GetFAContract().Equal(tezos.InvalidAddress) {
+			return nil, constants.ErrOperationInvalidContractAddress
+		}
+		paramType := micheline.NewPairType(address, micheline.NewPairType(address, nat))
+		return []micheline.Prim{
+			micheline.NewCode(micheline.I_PUSH, address, micheline.NewAddress(p.GetFAContract())),
+			micheline.NewCodeAnno(micheline.I_CONTRACT, "%transfer", paramType),
+			failIfNone(),
+			micheline.NewCode(micheline.I_PUSH, micheline.NewPrim(micheline.T_MUTEZ), micheline.NewInt64(0)),
+			micheline.NewCode(micheline.I_PUSH, paramType, micheline.NewPair(
+				micheline.NewAddress(source),
+				micheline.NewPair(micheline.NewAddress(p.GetDestination()), micheline.NewZ(p.GetAmount())),
+			)),
+			micheline.NewCode(micheline.I_TRANSFER_TOKENS),
+			micheline.NewCode(micheline.I_CONS),
+		}, nil
+	case enums.PAYOUT_TX_KIND_FA2:
+		if p.GetFAContract().Equal(tezos.ZeroAddress) || p.GetFAContract().Equal(tezos.InvalidAddress) {
+			return nil, constants.ErrOperationInvalidContractAddress
+		}
+		txType := micheline.NewPairType(address, micheline.NewPairType(nat, nat))
+		paramType := micheline.NewCode(micheline.T_LIST, micheline.NewPairType(address, micheline.NewCode(micheline.T_LIST, txType)))
+		return []micheline.Prim{
+			micheline.NewCode(micheline.I_PUSH, address, micheline.NewAddress(p.GetFAContract())),
+			micheline.NewCodeAnno(micheline.I_CONTRACT, "%transfer", paramType),
+			failIfNone(),
+			micheline.NewCode(micheline.I_PUSH, micheline.NewPrim(micheline.T_MUTEZ), micheline.NewInt64(0)),
+			micheline.NewCode(micheline.I_PUSH, paramType, micheline.NewSeq(micheline.NewPair(
+				micheline.NewAddress(source),
+				micheline.NewSeq(micheline.NewPair(
+					micheline.NewAddress(p.GetDestination()),
+					micheline.NewPair(micheline.NewZ(p.GetFATokenId()), micheline.NewZ(p.GetAmount())),
+				)),
+			))),
+			micheline.NewCode(micheline.I_TRANSFER_TOKENS),
+			micheline.NewCode(micheline.I_CONS),
+		}, nil
+	default:
+		return []micheline.Prim{
+			micheline.NewCode(micheline.I_PUSH, address, micheline.NewAddress(p.GetDestination())),
+			micheline.NewCode(micheline.I_CONTRACT, micheline.NewPrim(micheline.T_UNIT)),
+			failIfNone(),
+			micheline.NewCode(micheline.I_PUSH, micheline.NewPrim(micheline.T_MUTEZ), micheline.NewZ(p.GetAmount())),
+			micheline.NewCode(micheline.I_UNIT),
+			micheline.NewCode(micheline.I_TRANSFER_TOKENS),
+			micheline.NewCode(micheline.I_CONS),
+		}, nil
+	}
+}
+
+// GetMultisigTransferSize returns size of the lambda code transferring the payout from the multisig,
+// it replaces size of the transaction content when estimating fee of payouts included in multisig call
+func GetMultisigTransferSize(source tezos.Address, p TransferArgs) (int64, error) {
+	transfer, err := buildMultisigTransfer(source, p)
+	if err != nil {
+		return 0, err
+	}
+	data, err := micheline.NewSeq(transfer...).MarshalBinary()
+	return int64(len(data)), err
+}
+
+type MultisigProposal struct {
+	Id       string            `json:"id"`
+	Contract tezos.Address     `json:"contract"`
+	ChainId  tezos.ChainIdHash `json:"chain_id"`
+	Counter  int64             `json:"counter"`
+	Payouts  RecipeBatch       `json:"payouts"`
+	// public key -> signature
+	Signatures map[string]string `json:"signatures,omitempty"`
+}
+
+func NewMultisigProposal(id string, contract tezos.Address, chainId tezos.ChainIdHash, counter int64, batch RecipeBatch) *MultisigProposal {
+	return &MultisigProposal{
+		Id:         id,
+		Contract:   contract,
+		ChainId:    chainId,
+		Counter:    counter,
+		Payouts:    batch,
+		Signatures: make(map[string]string),
+	}
+}
+
+// GetLambda returns the lambda executed by the multisig - transfers of all payouts of the proposal
+func (p *MultisigProposal) GetLambda() (micheline.Prim, error) {
+	code := []micheline.Prim{
+		micheline.NewCode(micheline.I_DROP),
+		micheline.NewCode(micheline.I_NIL, micheline.NewPrim(micheline.T_OPERATION)),
+	}
+	// operations are consed, so we go from the last one to keep the order of payouts
+	for i := len(p.Payouts) - 1; i >= 0; i-- {
+		transfer, err := buildMultisigTransfer(p.Contract, &p.Payouts[i])
+		if err != nil {
+			return micheline.InvalidPrim, errors.Join(constants.ErrMultisigProposalCreationFailed, err)
+		}
+		code = append(code, transfer...)
+	}
+	return micheline.NewSeq(code...), nil
+}
+
+func (p *MultisigProposal) getPayload() (micheline.Prim, error) {
+	lambda, err := p.GetLambda()
+	if err != nil {
+		return micheline.InvalidPrim, err
+	}
+	return micheline.NewPair(micheline.NewInt64(p.Counter), micheline.NewCode(micheline.D_LEFT, lambda)), nil
+}
+
+// GetBytesToSign returns packed data the multisig checks signatures against
+func (p *MultisigProposal) GetBytesToSign() ([]byte, error) {
+	payload, err := p.getPayload()
+	if err != nil {
+		return nil, err
+	}
+	data := micheline.NewPair(
+		micheline.NewPair(micheline.NewBytes(p.ChainId.Bytes()), micheline.NewAddress(p.Contract)),
+		payload,
+	)
+	return data.Pack(), nil
+}
+
+func (p *MultisigProposal) GetDigest() ([]byte, error) {
+	data, err := p.GetBytesToSign()
+	if err != nil {
+		return nil, err
+	}
+	digest := tezos.Digest(data)
+	return digest[:], nil
+}
+
+func (p *MultisigProposal) AddSignature(key tezos.Key, signature tezos.Signature) error {
+	digest, err := p.GetDigest()
+	if err != nil {
+		return err
+	}
+	if err := key.Verify(digest, signature); err != nil {
+		return errors.Join(constants.ErrMultisigInvalidSignature, fmt.Errorf("proposal %s, key %s", p.Id, key), err)
+	}
+	if p.Signatures == nil {
+		p.Signatures = make(map[string]string)
+	}
+	p.Signatures[key.String()] = signature.String()
+	return nil
+}
+
+func (p *MultisigProposal) IsSignedBy(key tezos.Key) bool {
+	_, ok := p.Signatures[key.String()]
+	return ok
+}
+
+func (p *MultisigProposal) Sign(signer SignerEngine) error {
+	payloadSigner, ok := signer.(PayloadSignerEngine)
+	if !ok {
+		return errors.Join(constants.ErrMultisigSignerCannotSign, fmt.Errorf("signer %s", signer.GetId()))
+	}
+	data, err := p.GetBytesToSign()
+	if err != nil {
+		return err
+	}
+	signature, err := payloadSigner.SignPayload(data)
+	if err != nil {
+		return errors.Join(constants.ErrMultisigSignerCannotSign, err)
+	}
+	return p.AddSignature(signer.GetKey(), signature)
+}
+
+// getSignatures returns valid signatures of authorized keys in order of keys in the storage
+func (p *MultisigProposal) getSignatures(storage *MultisigStorage) ([]micheline.Prim, int64, error) {
+	digest, err := p.GetDigest()
+	if err != nil {
+		return nil, 0, err
+	}
+	signatures := make([]micheline.Prim, 0, len(storage.Keys))
+	count := int64(0)
+	for _, key := range storage.Keys {
+		encoded, ok := p.Signatures[key.String()]
+		if !ok {
+			signatures = append(signatures, micheline.NewCode(micheline.D_NONE))
+			continue
+		}
+		signature, err := tezos.ParseSignature(encoded)
+		if err != nil {
+			return nil, 0, errors.Join(constants.ErrMultisigInvalidSignature, err)
+		}
+		if err := key.Verify(digest, signature); err != nil {
+			return nil, 0, errors.Join(constants.ErrMultisigInvalidSignature, fmt.Errorf("proposal %s, key %s", p.Id, key), err)
+		}
+		signatures = append(signatures, micheline.NewOption(micheline.NewString(signature.String())))
+		count++
+	}
+	return signatures, count, nil
+}
+
+func (p *MultisigProposal) CountValidSignatures(storage *MultisigStorage) (int64, error) {
+	_, count, err := p.getSignatures(storage)
+	return count, err
+}
+
+// GetParameters builds the call of the main entrypoint of the multisig, fails if threshold is not reached
+func (p *MultisigProposal) GetParameters(storage *MultisigStorage) (*micheline.Parameters, error) {
+	if p.Counter != storage.Counter {
+		return nil, errors.Join(constants.ErrMultisigCounterMismatch, fmt.Errorf("proposal %s counter %d, contract counter %d", p.Id, p.Counter, storage.Counter))
+	}
+	signatures, count, err := p.getSignatures(storage)
+	if err != nil {
+		return nil, err
+	}
+	if count < storage.Threshold {
+		return nil, errors.Join(constants.ErrMultisigThresholdNotReached, fmt.Errorf("proposal %s has %d of %d required signatures", p.Id, count, storage.Threshold))
+	}
+	payload, err := p.getPayload()
+	if err != nil {
+		return nil, err
+	}
+	return &micheline.Parameters{
+		Entrypoint: MULTISIG_MAIN_ENTRYPOINT,
+		Value:      micheline.NewPair(payload, micheline.NewSeq(signatures...)),
+	}, nil
+}
+
+// ToTransferOp builds operation with direct transfers from the multisig matching the proposal.
+// It is used to check the proposal against the signing policy.
+func (p *MultisigProposal) ToTransferOp(fee int64) *codec.Op {
+	op := codec.NewOp().WithSource(p.Contract)
+	for i := range p.Payouts {
+		InjectTransferContents(op, p.Contract, &p.Payouts[i])
+	}
+	if len(op.Contents) > 0 {
+		op.Contents[0].WithLimits(tezos.Limits{Fee: fee})
+	}
+	return op
+}
+
+type MultisigProposalBundle struct {
+	Version           int                   `json:"version"`
+	CreatedAt         time.Time             `json:"created_at"`
+	Contract          tezos.Address         `json:"contract"`
+	ChainId           tezos.ChainIdHash     `json:"chain_id"`
+	Cycles            []int64               `json:"cycles"`
+	PreparationResult *PreparePayoutsResult `json:"preparation_result"`
+	Proposals         []MultisigProposal    `json:"proposals"`
+}
+
+func (b *MultisigProposalBundle) Validate() error {
+	if b.Version != constants.MULTISIG_PROPOSAL_BUNDLE_VERSION {
+		return errors.Join(constants.ErrMultisigBundleUnsupportedVersion, fmt.Errorf("version %d", b.Version))
+	}
+	if b.PreparationResult == nil {
+		return errors.Join(constants.ErrMultisigBundleLoadFailed, errors.New("missing preparation result"))
+	}
+	for _, proposal := range b.Proposals {
+		if !proposal.Contract.Equal(b.Contract) || !proposal.ChainId.Equal(b.ChainId) {
+			return errors.Join(constants.ErrMultisigBundleContractMismatch, fmt.Errorf("proposal %s", proposal.Id))
+		}
+	}
+	return nil
+}
+
+// Sign adds signature of the signer to all proposals of the bundle
+func (b *MultisigProposalBundle) Sign(signer SignerEngine, policy *SigningPolicy) error {
+	for i := range b.Proposals {
+		proposal := &b.Proposals[i]
+		if err := policy.Check(proposal.Payouts, proposal.ToTransferOp(0)); err != nil {
+			return err
+		}
+		if err := proposal.Sign(signer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *MultisigProposalBundle) GetPayouts() []PayoutRecipe {
+	result := make([]PayoutRecipe, 0)
+	for _, proposal := range b.Proposals {
+		result = append(result, proposal.Payouts...)
+	}
+	return result
+}
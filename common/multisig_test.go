@@ -0,0 +1,91 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/micheline"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var multisigTestContract = tezos.MustParseAddress("KT1VqarPDicMFn1ejmQqqshUkUXTCTXwmkCN")
+
+func multisigTestKeys(t *testing.T, count int) []tezos.PrivateKey {
+	keys := make([]tezos.PrivateKey, 0, count)
+	for i := 0; i < count; i++ {
+		key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func multisigTestStorage(counter int64, threshold int64, keys []tezos.PrivateKey) micheline.Prim {
+	prims := make([]micheline.Prim, 0, len(keys))
+	for _, key := range keys {
+		prims = append(prims, micheline.NewString(key.Public().String()))
+	}
+	return micheline.NewPair(micheline.NewInt64(counter), micheline.NewPair(micheline.NewInt64(threshold), micheline.NewSeq(prims...)))
+}
+
+func TestParseMultisigStorage(t *testing.T) {
+	assert := assert.New(t)
+	keys := multisigTestKeys(t, 2)
+
+	storage, err := ParseMultisigStorage(multisigTestStorage(7, 2, keys))
+	assert.Nil(err)
+	assert.Equal(int64(7), storage.Counter)
+	assert.Equal(int64(2), storage.Threshold)
+	assert.Len(storage.Keys, 2)
+	assert.True(storage.IsAuthorized(keys[1].Public()))
+	assert.False(storage.IsAuthorized(multisigTestKeys(t, 1)[0].Public()))
+
+	_, err = ParseMultisigStorage(micheline.NewPair(micheline.NewInt64(7), micheline.NewInt64(2)))
+	assert.ErrorIs(err, constants.ErrMultisigInvalidStorage)
+}
+
+func TestMultisigProposalApprovals(t *testing.T) {
+	assert := assert.New(t)
+	keys := multisigTestKeys(t, 3)
+	storage, err := ParseMultisigStorage(multisigTestStorage(3, 2, keys))
+	assert.Nil(err)
+
+	proposal := NewMultisigProposal("1", multisigTestContract, tezos.Mainnet, 3, RecipeBatch{
+		policyTestPayout(policyTestDelegator, 1000000, 0),
+		policyTestPayout(policyTestOther, 2000000, 0),
+	})
+	digest, err := proposal.GetDigest()
+	assert.Nil(err)
+
+	sign := func(key tezos.PrivateKey) tezos.Signature {
+		signature, err := key.Sign(digest)
+		assert.Nil(err)
+		return signature
+	}
+
+	assert.ErrorIs(proposal.AddSignature(keys[0].Public(), sign(keys[1])), constants.ErrMultisigInvalidSignature)
+	assert.Nil(proposal.AddSignature(keys[2].Public(), sign(keys[2])))
+	assert.True(proposal.IsSignedBy(keys[2].Public()))
+
+	_, err = proposal.GetParameters(storage)
+	assert.ErrorIs(err, constants.ErrMultisigThresholdNotReached)
+
+	assert.Nil(proposal.AddSignature(keys[0].Public(), sign(keys[0])))
+	count, err := proposal.CountValidSignatures(storage)
+	assert.Nil(err)
+	assert.Equal(int64(2), count)
+
+	parameters, err := proposal.GetParameters(storage)
+	assert.Nil(err)
+	assert.Equal(MULTISIG_MAIN_ENTRYPOINT, parameters.Entrypoint)
+	signatures := parameters.Value.Args[1].Args
+	assert.Len(signatures, 3)
+	assert.Equal(micheline.D_SOME, signatures[0].OpCode)
+	assert.Equal(micheline.D_NONE, signatures[1].OpCode)
+	assert.Equal(micheline.D_SOME, signatures[2].OpCode)
+
+	storage.Counter = 4
+	_, err = proposal.GetParameters(storage)
+	assert.ErrorIs(err, constants.ErrMultisigCounterMismatch)
+}
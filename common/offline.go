@@ -0,0 +1,223 @@
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type OfflineSigningBatch struct {
+	Id                string      `json:"id"`
+	Payouts           RecipeBatch `json:"payouts"`
+	UnsignedOperation string      `json:"unsigned_operation"`
+	Signature         string      `json:"signature,omitempty"`
+}
+
+func NewOfflineSigningBatch(id string, batch RecipeBatch, op *codec.Op) OfflineSigningBatch {
+	return OfflineSigningBatch{
+		Id:                id,
+		Payouts:           batch,
+		UnsignedOperation: hex.EncodeToString(op.Bytes()),
+	}
+}
+
+func (b *OfflineSigningBatch) IsSigned() bool {
+	return b.Signature != ""
+}
+
+// GetUnsignedOp decodes the forged operation and checks it re-encodes to the exact same bytes
+func (b *OfflineSigningBatch) GetUnsignedOp() (*codec.Op, error) {
+	data, err := hex.DecodeString(b.UnsignedOperation)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleInvalidOperation, err)
+	}
+	op, err := codec.DecodeOp(data)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleInvalidOperation, err)
+	}
+	if hex.EncodeToString(op.Bytes()) != b.UnsignedOperation {
+		return nil, errors.Join(constants.ErrOfflineBundleInvalidOperation, errors.New("operation does not re-encode to the same bytes"))
+	}
+	return op, nil
+}
+
+// GetSignedOp returns the forged operation with the attached signature verified against the key
+func (b *OfflineSigningBatch) GetSignedOp(key tezos.Key) (*codec.Op, error) {
+	if !b.IsSigned() {
+		return nil, errors.Join(constants.ErrOfflineBundleNotSigned, fmt.Errorf("batch %s", b.Id))
+	}
+	op, err := b.GetUnsignedOp()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := tezos.ParseSignature(b.Signature)
+	if err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleInvalidSignature, err)
+	}
+	if err := key.Verify(op.Digest(), signature); err != nil {
+		return nil, errors.Join(constants.ErrOfflineBundleInvalidSignature, fmt.Errorf("batch %s", b.Id), err)
+	}
+	return op.WithSignature(signature), nil
+}
+
+// Verify checks that the forged operation transfers exactly what the payouts of the batch describe
+// including token transfer parameters and limits the payouts were estimated with
+func (b *OfflineSigningBatch) Verify(source tezos.Address) error {
+	op, err := b.GetUnsignedOp()
+	if err != nil {
+		return err
+	}
+	if len(op.Contents) != len(b.Payouts) {
+		return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s has %d operations but %d payouts", b.Id, len(op.Contents), len(b.Payouts)))
+	}
+	serializationGasLimit := lo.Reduce(b.Payouts, func(acc int64, p PayoutRecipe, _ int) int64 {
+		if p.OpLimits == nil {
+			return acc
+		}
+		return acc + p.OpLimits.DeserializationGasLimit
+	}, int64(0))
+	for i, content := range op.Contents {
+		payout := b.Payouts[i]
+		tx, ok := content.(*codec.Transaction)
+		if !ok {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s contains unexpected operation kind %s", b.Id, content.Kind()))
+		}
+		if !tx.Source.Equal(source) {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s contains operation from %s", b.Id, tx.Source))
+		}
+		switch payout.TxKind {
+		case enums.PAYOUT_TX_KIND_FA1_2, enums.PAYOUT_TX_KIND_FA2:
+			if !tx.Destination.Equal(payout.FAContract) || tx.Amount.Int64() != 0 {
+				return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - fa transfer to %s does not match", b.Id, payout.Recipient))
+			}
+		default:
+			if !tx.Destination.Equal(payout.Recipient) || tx.Amount.Int64() != payout.Amount.Int64() {
+				return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - transfer to %s does not match", b.Id, payout.Recipient))
+			}
+		}
+		if payout.OpLimits == nil {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - payout to %s has no limits", b.Id, payout.Recipient))
+		}
+		limits := tezos.Limits{
+			Fee:          payout.OpLimits.TransactionFee,
+			GasLimit:     payout.OpLimits.GasLimit,
+			StorageLimit: payout.OpLimits.StorageLimit,
+		}
+		if i == 0 {
+			limits.GasLimit += serializationGasLimit
+		}
+		if tx.Fee.Int64() != limits.Fee {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - fee for %s does not match", b.Id, payout.Recipient))
+		}
+		if tx.GasLimit.Int64() != limits.GasLimit || tx.StorageLimit.Int64() != limits.StorageLimit {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - gas or storage limit for %s does not match", b.Id, payout.Recipient))
+		}
+
+		// forge the expected content to compare encoded transfer parameters (token recipient and amount)
+		expected := codec.NewOp().WithSource(source)
+		if err := InjectTransferContentsWithLimits(expected, source, &payout, limits); err != nil {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - transfer to %s", b.Id, payout.Recipient), err)
+		}
+		expected.Contents[0].WithCounter(tx.Counter.Int64())
+		expectedBytes, err := expected.Contents[0].MarshalBinary()
+		if err != nil {
+			return errors.Join(constants.ErrOfflineBundleInvalidOperation, err)
+		}
+		actualBytes, err := tx.MarshalBinary()
+		if err != nil {
+			return errors.Join(constants.ErrOfflineBundleInvalidOperation, err)
+		}
+		if !bytes.Equal(expectedBytes, actualBytes) {
+			return errors.Join(constants.ErrOfflineBundleContentsMismatch, fmt.Errorf("batch %s - transfer parameters for %s do not match", b.Id, payout.Recipient))
+		}
+	}
+	return nil
+}
+
+func (b *OfflineSigningBatch) Sign(signer SignerEngine, policy *SigningPolicy) error {
+	op, err := b.GetUnsignedOp()
+	if err != nil {
+		return err
+	}
+	if err := policy.Check(b.Payouts, op); err != nil {
+		return err
+	}
+	if err := signer.Sign(op); err != nil {
+		return err
+	}
+	b.Signature = op.Signature.String()
+	return nil
+}
+
+type OfflineSigningBundle struct {
+	Version           int                   `json:"version"`
+	CreatedAt         time.Time             `json:"created_at"`
+	Source            tezos.Address         `json:"source"`
+	PublicKey         tezos.Key             `json:"public_key"`
+	TTL               int64                 `json:"ttl"`
+	Cycles            []int64               `json:"cycles"`
+	PreparationResult *PreparePayoutsResult `json:"preparation_result"`
+	Batches           []OfflineSigningBatch `json:"batches"`
+}
+
+func (b *OfflineSigningBundle) Validate() error {
+	if b.Version != constants.OFFLINE_SIGNING_BUNDLE_VERSION {
+		return errors.Join(constants.ErrOfflineBundleUnsupportedVersion, fmt.Errorf("version %d", b.Version))
+	}
+	if b.PreparationResult == nil {
+		return errors.Join(constants.ErrOfflineBundleLoadFailed, errors.New("missing preparation result"))
+	}
+	if !b.PublicKey.Address().Equal(b.Source) {
+		return errors.Join(constants.ErrOfflineBundleSourceMismatch, errors.New("public key does not match source"))
+	}
+	return nil
+}
+
+func (b *OfflineSigningBundle) Verify() error {
+	for i := range b.Batches {
+		if err := b.Batches[i].Verify(b.Source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *OfflineSigningBundle) IsSigned() bool {
+	for _, batch := range b.Batches {
+		if !batch.IsSigned() {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *OfflineSigningBundle) Sign(signer SignerEngine, policy *SigningPolicy) error {
+	if !signer.GetPKH().Equal(b.Source) {
+		return errors.Join(constants.ErrOfflineBundleSourceMismatch, fmt.Errorf("bundle source %s, signer %s", b.Source, signer.GetPKH()))
+	}
+	if err := b.Verify(); err != nil {
+		return err
+	}
+	for i := range b.Batches {
+		if err := b.Batches[i].Sign(signer, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *OfflineSigningBundle) GetPayouts() []PayoutRecipe {
+	result := make([]PayoutRecipe, 0)
+	for _, batch := range b.Batches {
+		result = append(result, batch.Payouts...)
+	}
+	return result
+}
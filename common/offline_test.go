@@ -0,0 +1,139 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type testSigner struct {
+	key tezos.PrivateKey
+}
+
+func (s *testSigner) GetId() string            { return "test" }
+func (s *testSigner) GetPKH() tezos.Address    { return s.key.Address() }
+func (s *testSigner) GetKey() tezos.Key        { return s.key.Public() }
+func (s *testSigner) Sign(op *codec.Op) error  { return op.Sign(s.key) }
+func (s *testSigner) GetSigner() signer.Signer { return signer.NewFromKey(s.key) }
+
+func forgeTestBundle(t *testing.T, key tezos.PrivateKey) *OfflineSigningBundle {
+	source := key.Address()
+	batch := RecipeBatch{
+		{
+			Recipient: tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"),
+			Amount:    tezos.NewZ(1000000),
+			OpLimits:  &OpLimits{TransactionFee: 500, GasLimit: 1000},
+		},
+		{
+			Recipient: tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE"),
+			Amount:    tezos.NewZ(2000000),
+			OpLimits:  &OpLimits{TransactionFee: 600, GasLimit: 1000},
+		},
+	}
+	op := codec.NewOp().WithSource(source).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	for i, p := range batch {
+		assert.NoError(t, InjectTransferContentsWithLimits(op, source, &p, tezos.Limits{Fee: p.OpLimits.TransactionFee, GasLimit: p.OpLimits.GasLimit}))
+		op.Contents[i].WithCounter(int64(10 + i))
+	}
+
+	return &OfflineSigningBundle{
+		Version:           constants.OFFLINE_SIGNING_BUNDLE_VERSION,
+		Source:            source,
+		PublicKey:         key.Public(),
+		PreparationResult: &PreparePayoutsResult{ValidPayouts: batch},
+		Batches:           []OfflineSigningBatch{NewOfflineSigningBatch("1/1", batch, op)},
+	}
+}
+
+func TestOfflineSigningBundleRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.NoError(err)
+	bundle := forgeTestBundle(t, key)
+	assert.NoError(bundle.Validate())
+	assert.NoError(bundle.Verify())
+	assert.False(bundle.IsSigned())
+
+	_, err = bundle.Batches[0].GetSignedOp(key.Public())
+	assert.ErrorIs(err, constants.ErrOfflineBundleNotSigned)
+
+	assert.NoError(bundle.Sign(&testSigner{key: key}, nil))
+	assert.True(bundle.IsSigned())
+
+	data, err := json.Marshal(bundle)
+	assert.NoError(err)
+	loaded := &OfflineSigningBundle{}
+	assert.NoError(json.Unmarshal(data, loaded))
+	assert.NoError(loaded.Validate())
+
+	op, err := loaded.Batches[0].GetSignedOp(loaded.PublicKey)
+	assert.NoError(err)
+	assert.Len(op.Contents, 2)
+	assert.True(op.Signature.IsValid())
+}
+
+func TestOfflineSigningBundleRejectsTampering(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.NoError(err)
+	otherKey, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.NoError(err)
+
+	bundle := forgeTestBundle(t, key)
+	assert.ErrorIs(bundle.Sign(&testSigner{key: otherKey}, nil), constants.ErrOfflineBundleSourceMismatch)
+
+	bundle.Batches[0].Payouts[1].Amount = tezos.NewZ(3000000)
+	assert.ErrorIs(bundle.Verify(), constants.ErrOfflineBundleContentsMismatch)
+
+	bundle = forgeTestBundle(t, key)
+	assert.NoError(bundle.Sign(&testSigner{key: key}, nil))
+	_, err = bundle.Batches[0].GetSignedOp(otherKey.Public())
+	assert.ErrorIs(err, constants.ErrOfflineBundleInvalidSignature)
+}
+
+func TestOfflineSigningBatchVerifiesTransferParameters(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.NoError(err)
+	source := key.Address()
+	payout := PayoutRecipe{
+		Recipient:  tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"),
+		TxKind:     enums.PAYOUT_TX_KIND_FA2,
+		FAContract: tezos.MustParseAddress("KT1GRSvLoikDsXujKgZPsGLX8k8VvR2Tq95b"),
+		FATokenId:  tezos.NewZ(1),
+		Amount:     tezos.NewZ(1000),
+		OpLimits:   &OpLimits{TransactionFee: 900, GasLimit: 3000, StorageLimit: 70},
+	}
+	forge := func(transfer PayoutRecipe, limits tezos.Limits) OfflineSigningBatch {
+		op := codec.NewOp().WithSource(source).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+		assert.NoError(InjectTransferContentsWithLimits(op, source, &transfer, limits))
+		op.Contents[0].WithCounter(10)
+		return NewOfflineSigningBatch("1/1", RecipeBatch{payout}, op)
+	}
+	limits := tezos.Limits{Fee: 900, GasLimit: 3000, StorageLimit: 70}
+
+	batch := forge(payout, limits)
+	assert.NoError(batch.Verify(source))
+
+	tampered := payout
+	tampered.Recipient = tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+	batch = forge(tampered, limits)
+	assert.ErrorIs(batch.Verify(source), constants.ErrOfflineBundleContentsMismatch)
+
+	tampered = payout
+	tampered.Amount = tezos.NewZ(1_000_000)
+	batch = forge(tampered, limits)
+	assert.ErrorIs(batch.Verify(source), constants.ErrOfflineBundleContentsMismatch)
+
+	batch = forge(payout, tezos.Limits{Fee: 900, GasLimit: 3000, StorageLimit: 60_000})
+	assert.ErrorIs(batch.Verify(source), constants.ErrOfflineBundleContentsMismatch)
+}
@@ -46,6 +46,13 @@ func (ctx *OpExecutionContext) WaitForApply() error {
 	return ctx.result.WaitForApply()
 }
 
+func (ctx *OpExecutionContext) WaitForFinality(confirmations int64, onStateChange func(state OperationConfirmationState, level int64)) error {
+	if ctx.result == nil {
+		return constants.ErrOperationNotDispatched
+	}
+	return ctx.result.WaitForFinality(confirmations, onStateChange)
+}
+
 type TransferArgs interface {
 	GetTxKind() enums.EPayoutTransactionKind
 	GetFAContract() tezos.Address
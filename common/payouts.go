@@ -94,6 +94,15 @@ func (identifier *PayoutRecipeIdentifier) ToJSON() ([]byte, error) {
 	return json.Marshal(identifier)
 }
 
+func (identifier *PayoutRecipeIdentifier) GetHash() string {
+	k, err := identifier.ToJSON()
+	if err != nil {
+		return ""
+	}
+	hashBytes := sha256.Sum256(k)
+	return hex.EncodeToString(hashBytes[:])
+}
+
 func (recipe *PayoutRecipe) GetIdentifier() string {
 	identifier := PayoutRecipeIdentifier{
 		Delegator:  recipe.Delegator,
@@ -104,13 +113,7 @@ func (recipe *PayoutRecipe) GetIdentifier() string {
 		FAContract: recipe.FAContract,
 		IsValid:    recipe.IsValid,
 	}
-	k, err := identifier.ToJSON()
-	if err != nil {
-		return ""
-	}
-	hashBytes := sha256.Sum256(k)
-	hash := hex.EncodeToString(hashBytes[:])
-	return hash
+	return identifier.GetHash()
 }
 
 func (recipe *PayoutRecipe) GetShortIdentifier() string {
@@ -205,6 +208,7 @@ func (pr *PayoutRecipe) ToPayoutReport() PayoutReport {
 		FeeRate:          pr.FeeRate,
 		Fee:              pr.Fee,
 		TransactionFee:   txFee,
+		TxFeeCollected:   &pr.TxFeeCollected,
 		OpHash:           tezos.ZeroOpHash,
 		IsSuccess:        false,
 		Note:             pr.Note,
@@ -294,14 +298,28 @@ type CyclePayoutSummary struct {
 	ExternalDelegatedBalance tezos.Z   `json:"external_delegated_balance"`
 	EarnedFees               tezos.Z   `json:"cycle_fees"`
 	EarnedRewards            tezos.Z   `json:"cycle_rewards"`
+	EarnedStakingEdge        tezos.Z   `json:"cycle_staking_edge"`
+	MissedRewards            tezos.Z   `json:"missed_rewards"`
 	DistributedRewards       tezos.Z   `json:"distributed_rewards"`
+	StakerBonuses            tezos.Z   `json:"staker_bonuses"`
 	BondIncome               tezos.Z   `json:"bond_income"`
 	FeeIncome                tezos.Z   `json:"fee_income"`
+	StakingEdgeIncome        tezos.Z   `json:"staking_edge_income"`
 	IncomeTotal              tezos.Z   `json:"total_income"`
 	DonatedBonds             tezos.Z   `json:"donated_bonds"`
 	DonatedFees              tezos.Z   `json:"donated_fees"`
+	DonatedStakingEdge       tezos.Z   `json:"donated_staking_edge"`
 	DonatedTotal             tezos.Z   `json:"donated_total"`
 	Timestamp                time.Time `json:"timestamp"`
+	// AutoStake is set after income is staked by post-payout action
+	AutoStake      *AutoStakeSummary         `json:"auto_stake,omitempty"`
+	InvalidPayouts []InvalidPayoutsBreakdown `json:"invalid_payouts,omitempty"`
+}
+
+type AutoStakeSummary struct {
+	Source tezos.Address `json:"source"`
+	Amount tezos.Z       `json:"amount"`
+	OpHash tezos.OpHash  `json:"op_hash"`
 }
 
 func (summary *CyclePayoutSummary) GetTotalStakedBalance() tezos.Z {
@@ -320,13 +338,19 @@ func (summary *CyclePayoutSummary) CombineNumericData(another *CyclePayoutSummar
 		ExternalDelegatedBalance: summary.ExternalDelegatedBalance.Add(another.ExternalDelegatedBalance),
 		EarnedFees:               summary.EarnedFees.Add(another.EarnedFees),
 		EarnedRewards:            summary.EarnedRewards.Add(another.EarnedRewards),
+		EarnedStakingEdge:        summary.EarnedStakingEdge.Add(another.EarnedStakingEdge),
+		MissedRewards:            summary.MissedRewards.Add(another.MissedRewards),
 		DistributedRewards:       summary.DistributedRewards.Add(another.DistributedRewards),
+		StakerBonuses:            summary.StakerBonuses.Add(another.StakerBonuses),
 		BondIncome:               summary.BondIncome.Add(another.BondIncome),
 		FeeIncome:                summary.FeeIncome.Add(another.FeeIncome),
+		StakingEdgeIncome:        summary.StakingEdgeIncome.Add(another.StakingEdgeIncome),
 		IncomeTotal:              summary.IncomeTotal.Add(another.IncomeTotal),
 		DonatedBonds:             summary.DonatedBonds.Add(another.DonatedBonds),
 		DonatedFees:              summary.DonatedFees.Add(another.DonatedFees),
+		DonatedStakingEdge:       summary.DonatedStakingEdge.Add(another.DonatedStakingEdge),
 		DonatedTotal:             summary.DonatedTotal.Add(another.DonatedTotal),
+		InvalidPayouts:           CombineInvalidPayoutsBreakdowns(summary.InvalidPayouts, another.InvalidPayouts),
 	}
 }
 
@@ -341,6 +365,8 @@ type GeneratePayoutsEngineContext struct {
 	collector   CollectorEngine
 	signer      SignerEngine
 	adminNotify func(msg string)
+
+	topUp TopUpEngine
 }
 
 func NewGeneratePayoutsEngines(collector CollectorEngine, signer SignerEngine, adminNotify func(msg string)) *GeneratePayoutsEngineContext {
@@ -351,6 +377,16 @@ func NewGeneratePayoutsEngines(collector CollectorEngine, signer SignerEngine, a
 	}
 }
 
+// WithTopUp enables topping up the payout wallet when its balance is insufficient
+func (engines *GeneratePayoutsEngineContext) WithTopUp(topUp TopUpEngine) *GeneratePayoutsEngineContext {
+	engines.topUp = topUp
+	return engines
+}
+
+func (engines *GeneratePayoutsEngineContext) GetTopUp() TopUpEngine {
+	return engines.topUp
+}
+
 func (engines *GeneratePayoutsEngineContext) GetSigner() SignerEngine {
 	return engines.signer
 }
@@ -455,6 +491,9 @@ type ExecutePayoutsEngineContext struct {
 	transactor  TransactorEngine
 	reporter    ReporterEngine
 	adminNotify func(msg string)
+
+	collector       CollectorEngine
+	multisigSigners []SignerEngine
 }
 
 func NewExecutePayoutsEngineContext(signer SignerEngine, transactor TransactorEngine, reporter ReporterEngine, adminNotify func(msg string)) *ExecutePayoutsEngineContext {
@@ -478,6 +517,26 @@ func (engines *ExecutePayoutsEngineContext) GetReporter() ReporterEngine {
 	return engines.reporter
 }
 
+// WithCollector sets collector used to load delegators allowed by signing policy
+func (engines *ExecutePayoutsEngineContext) WithCollector(collector CollectorEngine) *ExecutePayoutsEngineContext {
+	engines.collector = collector
+	return engines
+}
+
+func (engines *ExecutePayoutsEngineContext) GetCollector() CollectorEngine {
+	return engines.collector
+}
+
+// WithMultisigSigners sets signers approving multisig proposals
+func (engines *ExecutePayoutsEngineContext) WithMultisigSigners(signers []SignerEngine) *ExecutePayoutsEngineContext {
+	engines.multisigSigners = signers
+	return engines
+}
+
+func (engines *ExecutePayoutsEngineContext) GetMultisigSigners() []SignerEngine {
+	return engines.multisigSigners
+}
+
 func (engines *ExecutePayoutsEngineContext) AdminNotify(msg string) {
 	if engines.adminNotify != nil {
 		engines.adminNotify(msg)
@@ -501,6 +560,8 @@ type ExecutePayoutsOptions struct {
 	MixInContractCalls bool `json:"mix_in_contract_calls,omitempty"`
 	MixInFATransfers   bool `json:"mix_in_fa_transfers,omitempty"`
 	DryRun             bool `json:"dry_run,omitempty"`
+
+	Confirmations int64 `json:"confirmations,omitempty"`
 }
 
 type ExecutePayoutsResult struct {
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"time"
+
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type EPublicRewardStatus string
+
+const (
+	PUBLIC_REWARD_STATUS_PAID        EPublicRewardStatus = "paid"
+	PUBLIC_REWARD_STATUS_ACCUMULATED EPublicRewardStatus = "accumulated"
+	PUBLIC_REWARD_STATUS_FAILED      EPublicRewardStatus = "failed"
+)
+
+// PublicRewardLine is delegator facing view of payout report, it intentionally leaves out internal fields
+type PublicRewardLine struct {
+	Cycle            int64               `json:"cycle"`
+	Timestamp        time.Time           `json:"timestamp"`
+	Kind             enums.EPayoutKind   `json:"kind"`
+	Status           EPublicRewardStatus `json:"status"`
+	DelegatedBalance tezos.Z             `json:"delegated_balance"`
+	Amount           tezos.Z             `json:"amount"`
+	Fee              tezos.Z             `json:"fee"`
+	FeeRate          float64             `json:"fee_rate"`
+	OpHash           string              `json:"op_hash,omitempty"`
+	ExplorerUrl      string              `json:"explorer_url,omitempty"`
+	// PaidWithCycle is set for accumulated rewards, they are paid within the combined payout of the cycle
+	PaidWithCycle int64 `json:"paid_with_cycle,omitempty"`
+}
+
+type PublicDelegatorRewards struct {
+	Delegator tezos.Address      `json:"delegator"`
+	Paid      []PublicRewardLine `json:"paid"`
+	Pending   []PublicRewardLine `json:"pending"`
+	// TotalPaid is paid tez amount, accumulated rewards are included in the combined payout
+	TotalPaid tezos.Z `json:"total_paid"`
+	// TotalPending is amount failed to be paid and not paid by retry yet
+	TotalPending tezos.Z `json:"total_pending"`
+	// EffectiveFee is fee relative to amount before fee of paid rewards
+	EffectiveFee float64 `json:"effective_fee"`
+	FirstCycle   int64   `json:"first_cycle"`
+	LastCycle    int64   `json:"last_cycle"`
+}
@@ -21,12 +21,13 @@ type PayoutReport struct {
 	FADecimals       int                          `json:"fa_decimals,omitempty" csv:"fa_decimals"`
 	Delegator        tezos.Address                `json:"delegator,omitempty" csv:"delegator"`
 	DelegatedBalance tezos.Z                      `json:"delegator_balance,omitempty" csv:"delegator_balance"`
-	StakedBalance    tezos.Z                      `json:"-" csv:"-"` // enable when relevant
+	StakedBalance    tezos.Z                      `json:"staked_balance,omitempty" csv:"staked_balance"`
 	Recipient        tezos.Address                `json:"recipient,omitempty" csv:"recipient"`
 	Amount           tezos.Z                      `json:"amount,omitempty" csv:"amount"`
 	FeeRate          float64                      `json:"fee_rate,omitempty" csv:"fee_rate"`
 	Fee              tezos.Z                      `json:"fee,omitempty" csv:"fee"`
 	TransactionFee   int64                        `json:"tx_fee,omitempty" csv:"tx_fee"`
+	TxFeeCollected   *bool                        `json:"tx_fee_collected,omitempty" csv:"tx_fee_collected,omitempty"` // nil for reports written before it was recorded
 	OpHash           tezos.OpHash                 `json:"op_hash,omitempty" csv:"op_hash"`
 	IsSuccess        bool                         `json:"success" csv:"success"`
 	Note             string                       `json:"note,omitempty" csv:"note"`
@@ -36,6 +37,20 @@ func (pr *PayoutReport) GetTransactionFee() int64 {
 	return pr.TransactionFee
 }
 
+// GetIdentifier matches identifier of the valid recipe the report was created from
+func (pr *PayoutReport) GetIdentifier() string {
+	identifier := PayoutRecipeIdentifier{
+		Delegator:  pr.Delegator,
+		Recipient:  pr.Recipient,
+		Kind:       pr.Kind,
+		TxKind:     pr.TxKind,
+		FATokenId:  pr.FATokenId,
+		FAContract: pr.FAContract,
+		IsValid:    true,
+	}
+	return identifier.GetHash()
+}
+
 func (pr *PayoutReport) ToTableRowData() []string {
 	return []string{
 		ShortenAddress(pr.Delegator),
@@ -0,0 +1,193 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type SigningPolicyLimits struct {
+	MaximumTotalPerCycle   int64
+	MaximumPerRecipient    int64
+	MaximumFeePerOperation int64
+}
+
+// SigningPolicy guards what is signed by the payout wallet.
+// Limits set to 0 are not enforced. AllowedRecipients set to nil disables the recipient check.
+type SigningPolicy struct {
+	Limits SigningPolicyLimits
+	// allowed recipients, nil means any recipient is allowed
+	AllowedRecipients map[string]bool
+	// recipients allowed to receive delegator rewards instead of delegator (overrides)
+	DelegatorRecipients map[string]tezos.Address
+
+	adminNotify        func(msg string)
+	mtx                sync.Mutex
+	signedPerCycle     map[int64]int64
+	signedPerRecipient map[string]int64
+}
+
+func NewSigningPolicy(limits SigningPolicyLimits, adminNotify func(msg string)) *SigningPolicy {
+	return &SigningPolicy{
+		Limits:             limits,
+		adminNotify:        adminNotify,
+		signedPerCycle:     make(map[int64]int64),
+		signedPerRecipient: make(map[string]int64),
+	}
+}
+
+func (policy *SigningPolicy) AllowRecipients(addresses ...tezos.Address) *SigningPolicy {
+	if policy.AllowedRecipients == nil {
+		policy.AllowedRecipients = make(map[string]bool)
+	}
+	for _, address := range addresses {
+		policy.AllowedRecipients[address.String()] = true
+	}
+	return policy
+}
+
+func (policy *SigningPolicy) AllowDelegatorRecipient(delegator tezos.Address, recipient tezos.Address) *SigningPolicy {
+	if policy.DelegatorRecipients == nil {
+		policy.DelegatorRecipients = make(map[string]tezos.Address)
+	}
+	policy.DelegatorRecipients[delegator.String()] = recipient
+	return policy.AllowRecipients(recipient)
+}
+
+func isTezTransfer(txKind enums.EPayoutTransactionKind) bool {
+	return txKind != enums.PAYOUT_TX_KIND_FA1_2 && txKind != enums.PAYOUT_TX_KIND_FA2
+}
+
+// RegisterPastPayouts accounts already paid out payouts towards the limits
+func (policy *SigningPolicy) RegisterPastPayouts(reports []PayoutReport) {
+	policy.mtx.Lock()
+	defer policy.mtx.Unlock()
+	for _, report := range reports {
+		if !isTezTransfer(report.TxKind) {
+			continue
+		}
+		policy.signedPerCycle[report.Cycle] += report.Amount.Int64()
+		policy.signedPerRecipient[report.Recipient.String()] += report.Amount.Int64()
+	}
+}
+
+func (policy *SigningPolicy) violation(format string, args ...any) error {
+	return errors.Join(constants.ErrSigningPolicyViolation, fmt.Errorf(format, args...))
+}
+
+func (policy *SigningPolicy) check(batch RecipeBatch, op *codec.Op) error {
+	transactions := make([]*codec.Transaction, 0, len(op.Contents))
+	totalFee := int64(0)
+	for _, content := range op.Contents {
+		totalFee += content.Limits().Fee
+		switch content.Kind() {
+		case tezos.OpTypeReveal:
+		case tezos.OpTypeTransaction:
+			transactions = append(transactions, content.(*codec.Transaction))
+		default:
+			return policy.violation("unexpected operation kind %s", content.Kind())
+		}
+	}
+	if policy.Limits.MaximumFeePerOperation > 0 && totalFee > policy.Limits.MaximumFeePerOperation {
+		return policy.violation("operation fee %s exceeds limit %s", MutezToTezS(totalFee), MutezToTezS(policy.Limits.MaximumFeePerOperation))
+	}
+	if len(transactions) != len(batch) {
+		return policy.violation("operation contains %d transactions but batch has %d payouts", len(transactions), len(batch))
+	}
+
+	perCycle := make(map[int64]int64)
+	perRecipient := make(map[string]int64)
+	for i, payout := range batch {
+		tx := transactions[i]
+		if policy.AllowedRecipients != nil {
+			if !policy.AllowedRecipients[payout.Recipient.String()] {
+				return policy.violation("recipient %s is not allowed", payout.Recipient)
+			}
+			if payout.Kind == enums.PAYOUT_KIND_DELEGATOR_REWARD && !payout.Recipient.Equal(payout.Delegator) {
+				if expected, ok := policy.DelegatorRecipients[payout.Delegator.String()]; !ok || !expected.Equal(payout.Recipient) {
+					return policy.violation("recipient %s is not allowed to receive rewards of %s", payout.Recipient, payout.Delegator)
+				}
+			}
+		}
+		if !isTezTransfer(payout.TxKind) {
+			continue
+		}
+		if !tx.Destination.Equal(payout.Recipient) || tx.Amount.Int64() != payout.Amount.Int64() {
+			return policy.violation("transaction to %s does not match payout", tx.Destination)
+		}
+		perCycle[payout.Cycle] += tx.Amount.Int64()
+		perRecipient[payout.Recipient.String()] += tx.Amount.Int64()
+	}
+
+	if policy.Limits.MaximumTotalPerCycle > 0 {
+		for cycle, amount := range perCycle {
+			if total := policy.signedPerCycle[cycle] + amount; total > policy.Limits.MaximumTotalPerCycle {
+				return policy.violation("total of cycle %d would be %s which exceeds limit %s", cycle, MutezToTezS(total), MutezToTezS(policy.Limits.MaximumTotalPerCycle))
+			}
+		}
+	}
+	if policy.Limits.MaximumPerRecipient > 0 {
+		for recipient, amount := range perRecipient {
+			if total := policy.signedPerRecipient[recipient] + amount; total > policy.Limits.MaximumPerRecipient {
+				return policy.violation("total for %s would be %s which exceeds limit %s", recipient, MutezToTezS(total), MutezToTezS(policy.Limits.MaximumPerRecipient))
+			}
+		}
+	}
+
+	for cycle, amount := range perCycle {
+		policy.signedPerCycle[cycle] += amount
+	}
+	for recipient, amount := range perRecipient {
+		policy.signedPerRecipient[recipient] += amount
+	}
+	return nil
+}
+
+// Check validates the operation built from the batch against the policy and accounts it towards the limits.
+// Violations are reported to admin.
+func (policy *SigningPolicy) Check(batch RecipeBatch, op *codec.Op) error {
+	if policy == nil {
+		return nil
+	}
+	policy.mtx.Lock()
+	defer policy.mtx.Unlock()
+
+	err := policy.check(batch, op)
+	if err != nil && policy.adminNotify != nil {
+		policy.adminNotify(fmt.Sprintf("Refused to sign payouts: %s", err.Error()))
+	}
+	return err
+}
+
+// Release removes payouts of the batch from the limits, used when signed operation was dropped and will not be applied
+func (policy *SigningPolicy) Release(batch RecipeBatch) {
+	if policy == nil {
+		return
+	}
+	policy.mtx.Lock()
+	defer policy.mtx.Unlock()
+	for _, payout := range batch {
+		if !isTezTransfer(payout.TxKind) {
+			continue
+		}
+		policy.signedPerCycle[payout.Cycle] -= payout.Amount.Int64()
+		policy.signedPerRecipient[payout.Recipient.String()] -= payout.Amount.Int64()
+	}
+}
+
+// CheckFee validates fee of operation which can not be matched with payouts, e.g. multisig call
+func (policy *SigningPolicy) CheckFee(fee int64) error {
+	if policy == nil || policy.Limits.MaximumFeePerOperation <= 0 || fee <= policy.Limits.MaximumFeePerOperation {
+		return nil
+	}
+	err := policy.violation("operation fee %s exceeds limit %s", MutezToTezS(fee), MutezToTezS(policy.Limits.MaximumFeePerOperation))
+	if policy.adminNotify != nil {
+		policy.adminNotify(fmt.Sprintf("Refused to sign payouts: %s", err.Error()))
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var (
+	policyTestSource    = tezos.MustParseAddress("tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU")
+	policyTestDelegator = tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+	policyTestOther     = tezos.MustParseAddress("tz1hZvgjekGo7DmQjWh7XnY5eLQD8wNYPczE")
+)
+
+func policyTestOp(batch RecipeBatch) *codec.Op {
+	op := codec.NewOp().WithSource(policyTestSource).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	for _, p := range batch {
+		InjectTransferContentsWithLimits(op, policyTestSource, &p, tezos.Limits{Fee: p.OpLimits.TransactionFee})
+	}
+	return op
+}
+
+func policyTestPayout(recipient tezos.Address, amount int64, fee int64) PayoutRecipe {
+	return PayoutRecipe{
+		Cycle:     100,
+		Delegator: policyTestDelegator,
+		Recipient: recipient,
+		Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+		TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+		Amount:    tezos.NewZ(amount),
+		OpLimits:  &OpLimits{TransactionFee: fee},
+	}
+}
+
+func TestSigningPolicyLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	notifications := 0
+	policy := NewSigningPolicy(SigningPolicyLimits{
+		MaximumTotalPerCycle:   3000,
+		MaximumPerRecipient:    2000,
+		MaximumFeePerOperation: 1000,
+	}, func(string) { notifications++ })
+	policy.RegisterPastPayouts([]PayoutReport{{Cycle: 100, Recipient: policyTestDelegator, TxKind: enums.PAYOUT_TX_KIND_TEZ, Amount: tezos.NewZ(500)}})
+
+	batch := RecipeBatch{policyTestPayout(policyTestDelegator, 1000, 100)}
+	assert.NoError(policy.Check(batch, policyTestOp(batch)))
+
+	// 500 + 1000 + 1000 > 2000 per recipient
+	assert.ErrorIs(policy.Check(batch, policyTestOp(batch)), constants.ErrSigningPolicyViolation)
+
+	batch = RecipeBatch{policyTestPayout(policyTestOther, 1000, 2000)}
+	assert.ErrorIs(policy.Check(batch, policyTestOp(batch)), constants.ErrSigningPolicyViolation)
+
+	// 500 + 1000 + 1600 > 3000 per cycle
+	batch = RecipeBatch{policyTestPayout(policyTestOther, 1600, 100)}
+	assert.ErrorIs(policy.Check(batch, policyTestOp(batch)), constants.ErrSigningPolicyViolation)
+	assert.Equal(3, notifications)
+
+	batch = RecipeBatch{policyTestPayout(policyTestOther, 1500, 100)}
+	op := policyTestOp(batch)
+	batch[0].Amount = tezos.NewZ(1)
+	assert.ErrorIs(policy.Check(batch, op), constants.ErrSigningPolicyViolation)
+
+	// dropped operation does not count towards limits
+	batch = RecipeBatch{policyTestPayout(policyTestOther, 1400, 100)}
+	assert.NoError(policy.Check(batch, policyTestOp(batch)))
+	policy.Release(batch)
+	assert.NoError(policy.Check(batch, policyTestOp(batch)))
+}
+
+func TestSigningPolicyRecipients(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := NewSigningPolicy(SigningPolicyLimits{}, nil).AllowRecipients(policyTestDelegator)
+	batch := RecipeBatch{policyTestPayout(policyTestDelegator, 1000, 100)}
+	assert.NoError(policy.Check(batch, policyTestOp(batch)))
+
+	batch = RecipeBatch{policyTestPayout(policyTestOther, 1000, 100)}
+	assert.ErrorIs(policy.Check(batch, policyTestOp(batch)), constants.ErrSigningPolicyViolation)
+
+	// allowed recipient redirected from delegator without override
+	policy.AllowRecipients(policyTestOther)
+	assert.ErrorIs(policy.Check(batch, policyTestOp(batch)), constants.ErrSigningPolicyViolation)
+
+	policy.AllowDelegatorRecipient(policyTestDelegator, policyTestOther)
+	assert.NoError(policy.Check(batch, policyTestOp(batch)))
+
+	var nilPolicy *SigningPolicy
+	assert.NoError(nilPolicy.Check(batch, policyTestOp(batch)))
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"time"
+
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// StatementLine is single successful payout in delegator statement, gross amount is net amount increased by the baker fee
+// and collected transaction fee. Gross is nil when it is not known whether the transaction fee was collected.
+type StatementLine struct {
+	Cycle            int64             `json:"cycle" csv:"cycle"`
+	Timestamp        time.Time         `json:"timestamp" csv:"timestamp"`
+	Kind             enums.EPayoutKind `json:"kind" csv:"kind"`
+	Recipient        tezos.Address     `json:"recipient" csv:"recipient"`
+	DelegatedBalance tezos.Z           `json:"delegated_balance" csv:"delegated_balance"`
+	Gross            *tezos.Z          `json:"gross" csv:"gross,omitempty"`
+	Fee              tezos.Z           `json:"fee" csv:"fee"`
+	TransactionFee   tezos.Z           `json:"tx_fee" csv:"tx_fee"`
+	Net              tezos.Z           `json:"net" csv:"net"`
+	OpHash           tezos.OpHash      `json:"op_hash" csv:"op_hash"`
+}
+
+// StatementTotals sums statement lines, lines with unknown gross are not included in the gross total
+type StatementTotals struct {
+	Gross          tezos.Z `json:"gross"`
+	Fee            tezos.Z `json:"fee"`
+	TransactionFee tezos.Z `json:"tx_fee"`
+	Net            tezos.Z `json:"net"`
+}
+
+type DelegatorStatement struct {
+	Baker       tezos.Address   `json:"baker"`
+	Delegator   tezos.Address   `json:"delegator"`
+	FirstCycle  int64           `json:"first_cycle"`
+	LastCycle   int64           `json:"last_cycle"`
+	Lines       []StatementLine `json:"lines"`
+	Totals      StatementTotals `json:"totals"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
@@ -0,0 +1,119 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func formatRatio(ratio float64) string {
+	return fmt.Sprintf("%.2f%%", ratio*100)
+}
+
+type DelegatorStatistics struct {
+	Delegator               tezos.Address `json:"delegator" csv:"delegator"`
+	CyclesPaid              int           `json:"cycles_paid" csv:"cycles_paid"`
+	FirstCycle              int64         `json:"first_cycle" csv:"first_cycle"`
+	LastCycle               int64         `json:"last_cycle" csv:"last_cycle"`
+	TotalReceived           tezos.Z       `json:"total_received" csv:"total_received"`
+	TotalFee                tezos.Z       `json:"total_fee" csv:"total_fee"`
+	AverageDelegatedBalance tezos.Z       `json:"average_delegated_balance" csv:"average_delegated_balance"`
+	// EffectiveYield is received amount relative to delegated balance per paid cycle
+	EffectiveYield float64 `json:"effective_yield" csv:"effective_yield"`
+	EstimatedApy   float64 `json:"estimated_apy" csv:"estimated_apy"`
+}
+
+func (stats *DelegatorStatistics) GetTableHeaders() []string {
+	return []string{"Delegator", "Cycles Paid", "First Cycle", "Last Cycle", "Total Received", "Total Fee", "Avg. Delegated Balance", "Yield / Cycle", "Est. APY"}
+}
+
+func (stats *DelegatorStatistics) ToTableRowData() []string {
+	return []string{
+		stats.Delegator.String(),
+		strconv.Itoa(stats.CyclesPaid),
+		strconv.FormatInt(stats.FirstCycle, 10),
+		strconv.FormatInt(stats.LastCycle, 10),
+		MutezToTezS(stats.TotalReceived.Int64()),
+		MutezToTezS(stats.TotalFee.Int64()),
+		MutezToTezS(stats.AverageDelegatedBalance.Int64()),
+		formatRatio(stats.EffectiveYield),
+		formatRatio(stats.EstimatedApy),
+	}
+}
+
+type CycleStatistics struct {
+	Cycle              int64   `json:"cycle" csv:"cycle"`
+	Delegators         int     `json:"delegators" csv:"delegators"`
+	PaidDelegators     int     `json:"paid_delegators" csv:"paid_delegators"`
+	EarnedRewards      tezos.Z `json:"earned_rewards" csv:"earned_rewards"`
+	EarnedFees         tezos.Z `json:"earned_fees" csv:"earned_fees"`
+	MissedRewards      tezos.Z `json:"missed_rewards" csv:"missed_rewards"`
+	DistributedRewards tezos.Z `json:"distributed_rewards" csv:"distributed_rewards"`
+	IncomeTotal        tezos.Z `json:"income_total" csv:"income_total"`
+}
+
+func (stats *CycleStatistics) GetTableHeaders() []string {
+	return []string{"Cycle", "Delegators", "Paid Delegators", "Earned Rewards", "Earned Fees", "Missed Rewards", "Distributed Rewards", "Income Total"}
+}
+
+func (stats *CycleStatistics) ToTableRowData() []string {
+	return []string{
+		strconv.FormatInt(stats.Cycle, 10),
+		strconv.Itoa(stats.Delegators),
+		strconv.Itoa(stats.PaidDelegators),
+		MutezToTezS(stats.EarnedRewards.Int64()),
+		MutezToTezS(stats.EarnedFees.Int64()),
+		MutezToTezS(stats.MissedRewards.Int64()),
+		MutezToTezS(stats.DistributedRewards.Int64()),
+		MutezToTezS(stats.IncomeTotal.Int64()),
+	}
+}
+
+type ChurnStatistics struct {
+	Cycle            int64           `json:"cycle" csv:"cycle"`
+	Delegators       int             `json:"delegators" csv:"delegators"`
+	Joined           int             `json:"joined" csv:"joined"`
+	Left             int             `json:"left" csv:"left"`
+	JoinedDelegators []tezos.Address `json:"joined_delegators" csv:"-"`
+	LeftDelegators   []tezos.Address `json:"left_delegators" csv:"-"`
+}
+
+func (stats *ChurnStatistics) GetTableHeaders() []string {
+	return []string{"Cycle", "Paid Delegators", "Joined", "Left"}
+}
+
+func (stats *ChurnStatistics) ToTableRowData() []string {
+	return []string{
+		strconv.FormatInt(stats.Cycle, 10),
+		strconv.Itoa(stats.Delegators),
+		strconv.Itoa(stats.Joined),
+		strconv.Itoa(stats.Left),
+	}
+}
+
+type ApyStatistics struct {
+	Cycle            int64   `json:"cycle" csv:"cycle"`
+	DelegatorYield   float64 `json:"delegator_yield" csv:"delegator_yield"`
+	DelegatorApy     float64 `json:"delegator_apy" csv:"delegator_apy"`
+	StakerBonusYield float64 `json:"staker_bonus_yield" csv:"staker_bonus_yield"`
+	StakerBonusApy   float64 `json:"staker_bonus_apy" csv:"staker_bonus_apy"`
+	BakerYield       float64 `json:"baker_yield" csv:"baker_yield"`
+	BakerApy         float64 `json:"baker_apy" csv:"baker_apy"`
+}
+
+func (stats *ApyStatistics) GetTableHeaders() []string {
+	return []string{"Cycle", "Delegator Yield", "Delegator APY", "Staker Bonus Yield", "Staker Bonus APY", "Baker Yield", "Baker APY"}
+}
+
+func (stats *ApyStatistics) ToTableRowData() []string {
+	return []string{
+		strconv.FormatInt(stats.Cycle, 10),
+		formatRatio(stats.DelegatorYield),
+		formatRatio(stats.DelegatorApy),
+		formatRatio(stats.StakerBonusYield),
+		formatRatio(stats.StakerBonusApy),
+		formatRatio(stats.BakerYield),
+		formatRatio(stats.BakerApy),
+	}
+}
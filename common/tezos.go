@@ -27,6 +27,7 @@ type BakersCycleData struct {
 	BlockStakingRewardsEdge       tezos.Z
 	EndorsementStakingRewardsEdge tezos.Z
 	BlockStakingFees              tezos.Z
+	BlockExternalStakingFees      tezos.Z
 	StakersCount                  int32
 
 	FrozenDepositLimit tezos.Z
@@ -56,6 +57,21 @@ func (cycleData *BakersCycleData) GetTotalDelegatedRewards(payoutMode enums.EPay
 	}
 }
 
+// GetMissedDelegatedRewards returns delegated rewards lost compared to ideal rewards, e.g. because of missed blocks or attestations
+func (cycleData *BakersCycleData) GetMissedDelegatedRewards() tezos.Z {
+	missed := cycleData.getIdealDelegatedRewards().Sub(cycleData.getActualDelegatedRewards())
+	if missed.IsNeg() {
+		return tezos.Zero
+	}
+	return missed
+}
+
+// GetStakingEdgeIncome returns the edge the baker earned from stakers rewards
+// together with the fees attributed to the external stakers, which are not shared with them
+func (cycleData *BakersCycleData) GetStakingEdgeIncome() tezos.Z {
+	return cycleData.BlockStakingRewardsEdge.Add(cycleData.EndorsementStakingRewardsEdge).Add(cycleData.BlockExternalStakingFees)
+}
+
 func (cycleData *BakersCycleData) GetBakerDelegatedBalance() tezos.Z {
 	return cycleData.OwnDelegatedBalance
 }
@@ -0,0 +1,21 @@
+package common
+
+import "github.com/trilitech/tzgo/tezos"
+
+type WalletStatus struct {
+	Address    tezos.Address `json:"address"`
+	Balance    tezos.Z       `json:"balance"`
+	IsRevealed bool          `json:"revealed"`
+	Counter    int64         `json:"counter"`
+}
+
+// WalletSweep describes transfer of the whole wallet balance, amount is balance reduced by fee and burns
+type WalletSweep struct {
+	Source       tezos.Address `json:"source"`
+	Destination  tezos.Address `json:"destination"`
+	Amount       int64         `json:"amount"`
+	Fee          int64         `json:"fee"`
+	Burn         int64         `json:"burn"`
+	GasLimit     int64         `json:"gas_limit"`
+	StorageLimit int64         `json:"storage_limit"`
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/trilitech/tzgo/tezos"
 
@@ -92,6 +93,39 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 	if balanceCheckMode == "" {
 		balanceCheckMode = enums.PROTOCOL_BALANCE_CHECK_MODE
 	}
+	broadcastMode := configuration.Network.BroadcastMode
+	if broadcastMode == "" {
+		broadcastMode = enums.BROADCAST_MODE_SINGLE
+	}
+	reporterEngine := configuration.Reports.Engine
+	if reporterEngine == "" {
+		reporterEngine = enums.REPORTER_ENGINE_FS
+	}
+	accountingCurrencies := lo.Map(configuration.Accounting.Currencies, func(currency string, _ int) string { return strings.ToLower(currency) })
+	if len(accountingCurrencies) == 0 {
+		accountingCurrencies = constants.DEFAULT_ACCOUNTING_CURRENCIES
+	}
+	priceSource := configuration.Accounting.PriceSource
+	if priceSource == "" {
+		priceSource = enums.PRICE_SOURCE_COINGECKO
+	}
+	reportSinks := lo.Map(configuration.Reports.Sinks, func(sink tezpay_configuration.ReportSinkV0, _ int) RuntimeReportSink {
+		policy := sink.Policy
+		if policy == "" {
+			policy = enums.REPORT_SINK_POLICY_BEST_EFFORT
+		}
+		retryLimit := constants.DEFAULT_REPORT_SINK_RETRY_LIMIT
+		if sink.RetryLimit != nil {
+			retryLimit = *sink.RetryLimit
+		}
+		return RuntimeReportSink{
+			Engine:     sink.Engine,
+			Url:        sink.Url,
+			Token:      sink.Token,
+			Policy:     policy,
+			RetryLimit: retryLimit,
+		}
+	})
 
 	gasLimitBuffer := int64(constants.DEFAULT_TX_GAS_LIMIT_BUFFER)
 	if configuration.PayoutConfiguration.TxGasLimitBuffer != nil {
@@ -128,6 +162,11 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 		donateFees = *configuration.IncomeRecipients.DonateFees
 	}
 
+	donateStakingEdge := donate
+	if configuration.IncomeRecipients.DonateStakingEdge != nil {
+		donateStakingEdge = *configuration.IncomeRecipients.DonateStakingEdge
+	}
+
 	delegatorBellowMinimumBalanceRewardDestination := enums.REWARD_DESTINATION_NONE
 	if configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination != nil {
 		delegatorBellowMinimumBalanceRewardDestination = *configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination
@@ -148,6 +187,70 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 		simulationBatchSize = *configuration.PayoutConfiguration.SimulationBatchSize
 	}
 
+	var multisig *RuntimeMultisigWallet
+	if configuration.PayoutConfiguration.Multisig != nil {
+		multisig = &RuntimeMultisigWallet{
+			Contract: configuration.PayoutConfiguration.Multisig.Contract,
+			Signers:  configuration.PayoutConfiguration.Multisig.Signers,
+		}
+	}
+
+	feeStrategy := defaultRuntimeFeeStrategy()
+	if strategy := configuration.PayoutConfiguration.FeeStrategy; strategy != nil {
+		if strategy.Kind != "" {
+			feeStrategy.Kind = strategy.Kind
+		}
+		if strategy.Multiplier != nil {
+			feeStrategy.Multiplier = *strategy.Multiplier
+		}
+		if strategy.MempoolPercentile != nil {
+			feeStrategy.MempoolPercentile = *strategy.MempoolPercentile
+		}
+		if strategy.MaximumMultiplier != nil {
+			feeStrategy.MaximumMultiplier = *strategy.MaximumMultiplier
+		}
+	}
+
+	var topUp *RuntimeTopUp
+	if configuration.PayoutConfiguration.TopUp != nil {
+		buffer := constants.DEFAULT_TOP_UP_BUFFER
+		if configuration.PayoutConfiguration.TopUp.Buffer != nil {
+			buffer = *configuration.PayoutConfiguration.TopUp.Buffer
+		}
+		topUp = &RuntimeTopUp{
+			Signer:             configuration.PayoutConfiguration.TopUp.Signer,
+			Buffer:             FloatAmountToMutez(buffer),
+			DailyLimit:         FloatAmountToMutez(configuration.PayoutConfiguration.TopUp.DailyLimit),
+			MaximumPerTransfer: FloatAmountToMutez(configuration.PayoutConfiguration.TopUp.MaximumPerTransfer),
+		}
+	}
+
+	var autoStake *RuntimeAutoStake
+	if configuration.PayoutConfiguration.AutoStake != nil {
+		autoStake = &RuntimeAutoStake{
+			Signer:        configuration.PayoutConfiguration.AutoStake.Signer,
+			BondsShare:    configuration.PayoutConfiguration.AutoStake.BondsShare,
+			FeesShare:     configuration.PayoutConfiguration.AutoStake.FeesShare,
+			LiquidReserve: FloatAmountToMutez(configuration.PayoutConfiguration.AutoStake.LiquidReserve),
+		}
+	}
+
+	var stakerBonus *RuntimeStakerBonus
+	if configuration.StakerBonus != nil {
+		stakerBonus = &RuntimeStakerBonus{
+			Share:                   configuration.StakerBonus.Share,
+			MinimumAmount:           FloatAmountToMutez(configuration.StakerBonus.MinimumAmount),
+			IsPayingTxFee:           configuration.PayoutConfiguration.IsPayingTxFee,
+			IsPayingAllocationTxFee: configuration.PayoutConfiguration.IsPayingAllocationTxFee,
+		}
+		if configuration.StakerBonus.IsBakerPayingTxFee != nil {
+			stakerBonus.IsPayingTxFee = *configuration.StakerBonus.IsBakerPayingTxFee
+		}
+		if configuration.StakerBonus.IsBakerPayingAllocationTxFee != nil {
+			stakerBonus.IsPayingAllocationTxFee = *configuration.StakerBonus.IsBakerPayingAllocationTxFee
+		}
+	}
+
 	rpcPool := make([]string, 0, len(configuration.Network.RpcPool)+1)
 	if configuration.Network.RpcUrl != "" {
 		rpcPool = append(rpcPool, configuration.Network.RpcUrl)
@@ -176,6 +279,10 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 			MinimumDelayBlocks:         minimumPayoutDelayBlocks,
 			MaximumDelayBlocks:         maximumPayoutDelayBlocks,
 			SimulationBatchSize:        simulationBatchSize,
+			Multisig:                   multisig,
+			FeeStrategy:                feeStrategy,
+			TopUp:                      topUp,
+			AutoStake:                  autoStake,
 		},
 		Delegators: RuntimeDelegatorsConfiguration{
 			Requirements: RuntimeDelegatorRequirements{
@@ -187,11 +294,13 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 			Prefilter: configuration.Delegators.Prefilter,
 		},
 		IncomeRecipients: RuntimeIncomeRecipients{
-			Bonds:       configuration.IncomeRecipients.Bonds,
-			Fees:        configuration.IncomeRecipients.Fees,
-			Donations:   preprocessDonationMap(configuration.IncomeRecipients.Donations),
-			DonateFees:  donateFees,
-			DonateBonds: donateBonds,
+			Bonds:             configuration.IncomeRecipients.Bonds,
+			Fees:              configuration.IncomeRecipients.Fees,
+			Donations:         preprocessDonationMap(configuration.IncomeRecipients.Donations),
+			DonateFees:        donateFees,
+			DonateBonds:       donateBonds,
+			StakingEdge:       configuration.IncomeRecipients.StakingEdge,
+			DonateStakingEdge: donateStakingEdge,
 		},
 		Network: RuntimeNetworkConfiguration{
 			RpcPool:                rpcPool,
@@ -200,8 +309,29 @@ func ConfigurationToRuntimeConfiguration(configuration *LatestConfigurationType)
 			Explorer:               configuration.Network.Explorer,
 			DoNotPaySmartContracts: configuration.Network.DoNotPaySmartContracts,
 			IgnoreProtocolChanges:  configuration.Network.IgnoreProtocolChanges,
+			BroadcastMode:          broadcastMode,
+			InjectionEndpoints:     configuration.Network.InjectionEndpoints,
 		},
 		Overdelegation: configuration.Overdelegation,
+		SigningPolicy: RuntimeSigningPolicy{
+			MaximumTotalPerCycle:   FloatAmountToMutez(configuration.SigningPolicy.MaximumTotalPerCycle),
+			MaximumPerRecipient:    FloatAmountToMutez(configuration.SigningPolicy.MaximumPerRecipient),
+			MaximumFeePerOperation: FloatAmountToMutez(configuration.SigningPolicy.MaximumFeePerOperation),
+			RestrictRecipients:     configuration.SigningPolicy.RestrictRecipients,
+		},
+		StakerBonus: stakerBonus,
+		Reports: RuntimeReportsConfiguration{
+			Engine:    reporterEngine,
+			ExportCsv: configuration.Reports.ExportCsv,
+			Sinks:     reportSinks,
+			Ledger:    configuration.Reports.Ledger,
+		},
+		Accounting: RuntimeAccounting{
+			Currencies:  accountingCurrencies,
+			PriceSource: priceSource,
+			PriceFile:   configuration.Accounting.PriceFile,
+			PriceApiKey: configuration.Accounting.PriceApiKey,
+		},
 		NotificationConfigurations: lo.Map(configuration.NotificationConfigurations, func(item json.RawMessage, index int) RuntimeNotificatorConfiguration {
 			var isValid bool
 			var notificatorConfigurationBase tezpay_configuration.NotificatorConfigurationBase
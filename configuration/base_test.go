@@ -6,6 +6,7 @@ import (
 
 	test_assert "github.com/stretchr/testify/assert"
 	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
+	"github.com/tez-capital/tezpay/constants"
 	"github.com/trilitech/tzgo/tezos"
 )
 
@@ -81,3 +82,26 @@ func TestConfigurationToRuntimeConfiguration(t *testing.T) {
 	assert.NotNil(err)
 	assert.True(strings.Contains(err.Error(), "fee must be between 0 and 1"))
 }
+
+func TestDonateStakingEdgeDefaultsToDonate(t *testing.T) {
+	assert := test_assert.New(t)
+
+	runtime, err := ConfigurationToRuntimeConfiguration(&LatestConfigurationType{})
+	assert.Nil(err)
+	assert.Equal(constants.DEFAULT_DONATION_PERCENTAGE, runtime.IncomeRecipients.DonateStakingEdge)
+
+	donate := 0.1
+	runtime, err = ConfigurationToRuntimeConfiguration(&LatestConfigurationType{
+		IncomeRecipients: tezpay_configuration.IncomeRecipientsV0{Donate: &donate},
+	})
+	assert.Nil(err)
+	assert.Equal(donate, runtime.IncomeRecipients.DonateBonds)
+	assert.Equal(donate, runtime.IncomeRecipients.DonateStakingEdge)
+
+	donateStakingEdge := 0.0
+	runtime, err = ConfigurationToRuntimeConfiguration(&LatestConfigurationType{
+		IncomeRecipients: tezpay_configuration.IncomeRecipientsV0{Donate: &donate, DonateStakingEdge: &donateStakingEdge},
+	})
+	assert.Nil(err)
+	assert.Equal(donateStakingEdge, runtime.IncomeRecipients.DonateStakingEdge)
+}
@@ -55,14 +55,92 @@ type RuntimePayoutConfiguration struct {
 	MinimumDelayBlocks         int64                   `json:"minimum_delay_blocks,omitempty"`
 	MaximumDelayBlocks         int64                   `json:"maximum_delay_blocks,omitempty"`
 	SimulationBatchSize        int                     `json:"simulation_batch_size,omitempty"`
+	Multisig                   *RuntimeMultisigWallet  `json:"multisig,omitempty"`
+	FeeStrategy                RuntimeFeeStrategy      `json:"fee_strategy,omitempty"`
+	TopUp                      *RuntimeTopUp           `json:"top_up,omitempty"`
+	AutoStake                  *RuntimeAutoStake       `json:"auto_stake,omitempty"`
+}
+
+type RuntimeAutoStake struct {
+	Signer        string  `json:"signer"`
+	BondsShare    float64 `json:"bonds_share,omitempty"`
+	FeesShare     float64 `json:"fees_share,omitempty"`
+	LiquidReserve tezos.Z `json:"liquid_reserve,omitempty"`
+}
+
+type RuntimeReportSink struct {
+	Engine     enums.EReporterEngine   `json:"engine"`
+	Url        string                  `json:"url,omitempty"`
+	Token      string                  `json:"-"`
+	Policy     enums.EReportSinkPolicy `json:"policy"`
+	RetryLimit int                     `json:"retry_limit"`
+}
+
+type RuntimeReportsConfiguration struct {
+	Engine    enums.EReporterEngine `json:"engine"`
+	ExportCsv bool                  `json:"export_csv,omitempty"`
+	Sinks     []RuntimeReportSink   `json:"sinks,omitempty"`
+	Ledger    bool                  `json:"ledger,omitempty"`
+}
+
+type RuntimeAccounting struct {
+	Currencies  []string           `json:"currencies"`
+	PriceSource enums.EPriceSource `json:"price_source"`
+	PriceFile   string             `json:"price_file,omitempty"`
+	PriceApiKey string             `json:"-"`
+}
+
+type RuntimeStakerBonus struct {
+	Share                   float64 `json:"share"`
+	MinimumAmount           tezos.Z `json:"minimum_amount,omitempty"`
+	IsPayingTxFee           bool    `json:"baker_pays_transaction_fee,omitempty"`
+	IsPayingAllocationTxFee bool    `json:"baker_pays_allocation_fee,omitempty"`
+}
+
+type RuntimeTopUp struct {
+	Signer             string  `json:"signer"`
+	Buffer             tezos.Z `json:"buffer"`
+	DailyLimit         tezos.Z `json:"daily_limit"`
+	MaximumPerTransfer tezos.Z `json:"maximum_per_transfer,omitempty"`
+}
+
+type RuntimeFeeStrategy struct {
+	Kind              enums.EFeeStrategy `json:"kind"`
+	Multiplier        float64            `json:"multiplier,omitempty"`
+	MempoolPercentile float64            `json:"mempool_percentile,omitempty"`
+	MaximumMultiplier float64            `json:"maximum_multiplier,omitempty"`
+}
+
+func defaultRuntimeFeeStrategy() RuntimeFeeStrategy {
+	return RuntimeFeeStrategy{
+		Kind:              enums.FEE_STRATEGY_MINIMAL,
+		Multiplier:        constants.DEFAULT_FEE_STRATEGY_MULTIPLIER,
+		MempoolPercentile: constants.DEFAULT_MEMPOOL_FEE_PERCENTILE,
+		MaximumMultiplier: constants.DEFAULT_MAXIMUM_FEE_MULTIPLIER,
+	}
+}
+
+type RuntimeMultisigWallet struct {
+	Contract tezos.Address `json:"contract"`
+	Signers  []string      `json:"signers,omitempty"`
+}
+
+// GetFundingAddress returns the address holding the payout funds
+func (payoutConfiguration *RuntimePayoutConfiguration) GetFundingAddress(payoutWallet tezos.Address) tezos.Address {
+	if payoutConfiguration.Multisig != nil {
+		return payoutConfiguration.Multisig.Contract
+	}
+	return payoutWallet
 }
 
 type RuntimeIncomeRecipients struct {
-	Bonds       map[string]float64 `json:"bonds,omitempty"`
-	Fees        map[string]float64 `json:"fees,omitempty"`
-	DonateFees  float64            `json:"donate_fees,omitempty"`
-	DonateBonds float64            `json:"donate_bonds,omitempty"`
-	Donations   map[string]float64 `json:"donations,omitempty"`
+	Bonds             map[string]float64 `json:"bonds,omitempty"`
+	Fees              map[string]float64 `json:"fees,omitempty"`
+	DonateFees        float64            `json:"donate_fees,omitempty"`
+	DonateBonds       float64            `json:"donate_bonds,omitempty"`
+	Donations         map[string]float64 `json:"donations,omitempty"`
+	StakingEdge       map[string]float64 `json:"staking_edge,omitempty"`
+	DonateStakingEdge float64            `json:"donate_staking_edge,omitempty"`
 }
 
 type RuntimeNetworkConfiguration struct {
@@ -72,6 +150,16 @@ type RuntimeNetworkConfiguration struct {
 	Explorer               string   `json:"explorer,omitempty" comment:"Url to block explorer"`
 	DoNotPaySmartContracts bool     `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
 	IgnoreProtocolChanges  bool     `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+
+	BroadcastMode      enums.EBroadcastMode `json:"broadcast_mode,omitempty"`
+	InjectionEndpoints []string             `json:"injection_endpoints,omitempty"`
+}
+
+type RuntimeSigningPolicy struct {
+	MaximumTotalPerCycle   tezos.Z `json:"maximum_total_per_cycle,omitempty"`
+	MaximumPerRecipient    tezos.Z `json:"maximum_per_recipient,omitempty"`
+	MaximumFeePerOperation tezos.Z `json:"maximum_fee_per_operation,omitempty"`
+	RestrictRecipients     bool    `json:"restrict_recipients,omitempty"`
 }
 
 type RuntimeConfiguration struct {
@@ -81,6 +169,10 @@ type RuntimeConfiguration struct {
 	IncomeRecipients           RuntimeIncomeRecipients
 	Network                    RuntimeNetworkConfiguration
 	Overdelegation             tezpay_configuration.OverdelegationConfigurationV0
+	SigningPolicy              RuntimeSigningPolicy
+	StakerBonus                *RuntimeStakerBonus
+	Reports                    RuntimeReportsConfiguration
+	Accounting                 RuntimeAccounting
 	NotificationConfigurations []RuntimeNotificatorConfiguration
 	Extensions                 []tezpay_configuration.ExtensionConfigurationV0
 	SourceBytes                []byte `json:"-"`
@@ -106,6 +198,7 @@ func GetDefaultRuntimeConfiguration() RuntimeConfiguration {
 			MinimumDelayBlocks:         constants.DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY,
 			MaximumDelayBlocks:         constants.DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY,
 			SimulationBatchSize:        constants.DEFAULT_SIMULATION_TX_BATCH_SIZE,
+			FeeStrategy:                defaultRuntimeFeeStrategy(),
 		},
 		Delegators: RuntimeDelegatorsConfiguration{
 			Requirements: RuntimeDelegatorRequirements{
@@ -123,13 +216,21 @@ func GetDefaultRuntimeConfiguration() RuntimeConfiguration {
 			Explorer:               constants.DEFAULT_EXPLORER_URL,
 			DoNotPaySmartContracts: false,
 			IgnoreProtocolChanges:  false,
+			BroadcastMode:          enums.BROADCAST_MODE_SINGLE,
 		},
 		Overdelegation: tezpay_configuration.OverdelegationConfigurationV0{
 			IsProtectionEnabled: true,
 		},
 		NotificationConfigurations: make([]RuntimeNotificatorConfiguration, 0),
-		SourceBytes:                []byte{},
-		DisableAnalytics:           false,
+		Reports: RuntimeReportsConfiguration{
+			Engine: enums.REPORTER_ENGINE_FS,
+		},
+		Accounting: RuntimeAccounting{
+			Currencies:  constants.DEFAULT_ACCOUNTING_CURRENCIES,
+			PriceSource: enums.PRICE_SOURCE_COINGECKO,
+		},
+		SourceBytes:      []byte{},
+		DisableAnalytics: false,
 	}
 }
 
@@ -142,5 +243,5 @@ func (configuration *RuntimeConfiguration) IsDonatingToTezCapital() bool {
 		total += v
 	}
 	portion := int64(math.Floor(float64(total) * 10000))
-	return portion < 10000 && (configuration.IncomeRecipients.DonateBonds > 0 || configuration.IncomeRecipients.DonateFees > 0)
+	return portion < 10000 && (configuration.IncomeRecipients.DonateBonds > 0 || configuration.IncomeRecipients.DonateFees > 0 || configuration.IncomeRecipients.DonateStakingEdge > 0)
 }
@@ -11,12 +11,14 @@ import (
 )
 
 type IncomeRecipientsV0 struct {
-	Bonds       map[string]float64 `json:"bonds,omitempty" comment:"list of addresses and their share of the bonds"`
-	Fees        map[string]float64 `json:"fees,omitempty" comment:"list of addresses and their share of the fees"`
-	Donate      *float64           `json:"donate,omitempty" comment:"share of the rewards to donate"`
-	DonateFees  *float64           `json:"donate_fees,omitempty" comment:"share of the fees to donate (if not set, 'donate' is used)"`
-	DonateBonds *float64           `json:"donate_bonds,omitempty" comment:"share of the bonds to donate (if not set, 'donate' is used)"`
-	Donations   map[string]float64 `json:"donations,omitempty" comment:"list of addresses and their share of the donations"`
+	Bonds             map[string]float64 `json:"bonds,omitempty" comment:"list of addresses and their share of the bonds"`
+	Fees              map[string]float64 `json:"fees,omitempty" comment:"list of addresses and their share of the fees"`
+	Donate            *float64           `json:"donate,omitempty" comment:"share of the rewards to donate"`
+	DonateFees        *float64           `json:"donate_fees,omitempty" comment:"share of the fees to donate (if not set, 'donate' is used)"`
+	DonateBonds       *float64           `json:"donate_bonds,omitempty" comment:"share of the bonds to donate (if not set, 'donate' is used)"`
+	Donations         map[string]float64 `json:"donations,omitempty" comment:"list of addresses and their share of the donations"`
+	StakingEdge       map[string]float64 `json:"staking_edge,omitempty" comment:"list of addresses and their share of the staking edge income"`
+	DonateStakingEdge *float64           `json:"donate_staking_edge,omitempty" comment:"share of the staking edge income to donate (if not set, 'donate' is used)"`
 }
 
 type DelegatorRequirementsV0 struct {
@@ -50,6 +52,10 @@ type TezosNetworkConfigurationV0 struct {
 	Explorer               string   `json:"explorer,omitempty" comment:"Url to block explorer"`
 	DoNotPaySmartContracts bool     `json:"ignore_kt,omitempty" comment:"if true, smart contracts will not be paid out (used for testing)"`
 	IgnoreProtocolChanges  bool     `json:"ignore_protocol_changes,omitempty" comment:"if true, protocol changes will be ignored, otherwise the payout will be stopped if the protocol changes"`
+
+	// BroadcastMode controls whether operations are injected through a single node or the whole pool at once.
+	BroadcastMode      enums.EBroadcastMode `json:"broadcast_mode,omitempty" comment:"broadcast mode to use, can be 'single' (first available rpc) or 'fan-out' (all rpcs and injection endpoints at once)"`
+	InjectionEndpoints []string             `json:"injection_endpoints,omitempty" comment:"additional rpc endpoints used only for injection in 'fan-out' broadcast mode"`
 }
 
 type OverdelegationConfigurationV0 struct {
@@ -57,7 +63,7 @@ type OverdelegationConfigurationV0 struct {
 }
 
 type PayoutConfigurationV0 struct {
-	WalletMode                 enums.EWalletMode       `json:"wallet_mode" comment:"wallet mode to use for signing transactions, can be 'local-private-key' or 'remote-signer'"`
+	WalletMode                 enums.EWalletMode       `json:"wallet_mode" comment:"wallet mode to use for signing transactions, can be 'local-private-key', 'remote-signer' or 'transit-signer'"`
 	PayoutMode                 enums.EPayoutMode       `json:"payout_mode" comment:"payout mode to use, can be 'actual' or 'ideal'"`
 	BalanceCheckMode           enums.EBalanceCheckMode `json:"balance_check_mode" comment:"balance check mode to use, can be 'protocol' or 'tzkt'"`
 	Fee                        float64                 `json:"fee,omitempty" comment:"fee to charge delegators for the payout (portion of the reward as decimal, e.g. 0.075 for 7.5%)" validate:"required,min=0,max=1"`
@@ -72,6 +78,72 @@ type PayoutConfigurationV0 struct {
 	MinimumDelayBlocks         *int64                  `json:"minimum_delay_blocks,omitempty" comment:"minimum delay in blocks before the payout is executed"`
 	MaximumDelayBlocks         *int64                  `json:"maximum_delay_blocks,omitempty" comment:"maximum delay in blocks before the payout is executed"`
 	SimulationBatchSize        *int                    `json:"simulation_batch_size,omitempty" comment:"size of the batch for simulation (number of transactions, higher usually means faster simulation but in case of failure, more transactions will be lost and need to be simulated again)"`
+	Multisig                   *MultisigWalletV0       `json:"multisig,omitempty" comment:"pays out from a generic multisig contract, the payout wallet only submits approved proposals"`
+	FeeStrategy                *FeeStrategyV0          `json:"fee_strategy,omitempty" comment:"strategy used to determine transaction fees, extra fee is paid according to 'baker_pays_transaction_fee'"`
+	TopUp                      *TopUpV0                `json:"top_up,omitempty" comment:"funding wallet topping up the payout wallet when its balance is insufficient in continual mode"`
+	AutoStake                  *AutoStakeV0            `json:"auto_stake,omitempty" comment:"stakes share of the baker's income after payouts"`
+}
+
+type AutoStakeV0 struct {
+	Signer        string  `json:"signer" comment:"signer of the wallet holding the income, e.g. 'remote:<pkh>@<url>', 'key:<private key>', 'local-private-key' or 'remote-signer'"`
+	BondsShare    float64 `json:"bonds_share,omitempty" comment:"portion of the bond income to stake (as decimal, e.g. 0.5 for 50%)"`
+	FeesShare     float64 `json:"fees_share,omitempty" comment:"portion of the fee income to stake (as decimal, e.g. 0.5 for 50%)"`
+	LiquidReserve float64 `json:"liquid_reserve,omitempty" comment:"amount of tez kept liquid in the wallet, stake is reduced to keep the reserve"`
+}
+
+type ReportSinkV0 struct {
+	Engine     enums.EReporterEngine   `json:"engine" comment:"sink engine, can be 'fs', 'sqlite' or 'http'"`
+	Url        string                  `json:"url,omitempty" comment:"url the reports are posted to as json, required for 'http' sink"`
+	Token      string                  `json:"token,omitempty" comment:"bearer token sent with requests of 'http' sink"`
+	Policy     enums.EReportSinkPolicy `json:"policy,omitempty" comment:"'must_succeed' fails the report if the sink write fails, 'best_effort' (default) only logs the failure and retries the write later"`
+	RetryLimit *int                    `json:"retry_limit,omitempty" comment:"number of retries of a failed 'best_effort' write before it is dropped"`
+}
+
+type ReportsConfigurationV0 struct {
+	Engine    enums.EReporterEngine `json:"engine,omitempty" comment:"reporter engine to use, can be 'fs' (csv and json files per cycle) or 'sqlite' (embedded database in reports directory)"`
+	ExportCsv bool                  `json:"export_csv,omitempty" comment:"if true, 'sqlite' engine also writes csv and json files per cycle as exports"`
+	Sinks     []ReportSinkV0        `json:"sinks,omitempty" comment:"additional sinks mirroring everything written by the engine, reports are always read from the engine"`
+	Ledger    bool                  `json:"ledger,omitempty" comment:"if true, every report written by the engine is also appended to hash chained ledger in reports directory, use 'verify-ledger' command to detect tampering"`
+}
+
+type AccountingV0 struct {
+	Currencies  []string           `json:"currencies,omitempty" comment:"fiat currencies payouts are valued in, defaults to ['eur', 'usd']"`
+	PriceSource enums.EPriceSource `json:"price_source,omitempty" comment:"'coingecko' (default) fetches daily tez prices and caches them in working directory, 'csv' reads prices from price_file"`
+	PriceFile   string             `json:"price_file,omitempty" comment:"csv file with 'timestamp' column (RFC3339 or YYYY-MM-DD) followed by tez price column per currency, e.g. 'timestamp,eur,usd'"`
+	PriceApiKey string             `json:"price_api_key,omitempty" comment:"optional coingecko demo api key"`
+}
+
+type StakerBonusV0 struct {
+	Share                        float64 `json:"share" comment:"portion of the edge earned on each external staker's stake paid back to the staker as liquid bonus (as decimal, e.g. 0.5 for 50%)"`
+	MinimumAmount                float64 `json:"minimum_amount,omitempty" comment:"bonuses below this amount of tez are not paid"`
+	IsBakerPayingTxFee           *bool   `json:"baker_pays_transaction_fee,omitempty" comment:"if true, baker pays the transaction fee of the bonus (if not set, 'payouts.baker_pays_transaction_fee' is used)"`
+	IsBakerPayingAllocationTxFee *bool   `json:"baker_pays_allocation_fee,omitempty" comment:"if true, baker pays the allocation fee of the bonus (if not set, 'payouts.baker_pays_allocation_fee' is used)"`
+}
+
+type TopUpV0 struct {
+	Signer             string   `json:"signer" comment:"funding wallet signer, e.g. 'remote:<pkh>@<url>', 'key:<private key>' or 'transit-signer'"`
+	Buffer             *float64 `json:"buffer,omitempty" comment:"amount of tez transferred on top of the shortfall"`
+	DailyLimit         float64  `json:"daily_limit" comment:"maximum amount of tez transferred from the funding wallet per day (UTC)"`
+	MaximumPerTransfer float64  `json:"maximum_per_transfer,omitempty" comment:"maximum amount of tez transferred at once, 0 means limited only by the daily limit"`
+}
+
+type FeeStrategyV0 struct {
+	Kind              enums.EFeeStrategy `json:"kind" comment:"fee strategy to use, can be 'minimal', 'multiplier' or 'mempool'"`
+	Multiplier        *float64           `json:"multiplier,omitempty" comment:"multiple of the minimal fee paid with 'multiplier' strategy"`
+	MempoolPercentile *float64           `json:"mempool_percentile,omitempty" comment:"percentile (0-100) of fee rates of operations pending in the node mempool to match with 'mempool' strategy"`
+	MaximumMultiplier *float64           `json:"maximum_multiplier,omitempty" comment:"upper bound of the fee as multiple of the minimal fee with 'mempool' strategy"`
+}
+
+type MultisigWalletV0 struct {
+	Contract tezos.Address `json:"contract" comment:"address of the generic multisig contract holding the payout funds"`
+	Signers  []string      `json:"signers,omitempty" comment:"signers approving proposals, e.g. 'key:<private key>', 'remote:<pkh>@<url>', 'transit-signer' or 'public:<public key>' for signers approving offline"`
+}
+
+type SigningPolicyConfigurationV0 struct {
+	MaximumTotalPerCycle   float64 `json:"maximum_total_per_cycle,omitempty" comment:"maximum amount of tez signed for payouts of a single cycle, 0 means unlimited"`
+	MaximumPerRecipient    float64 `json:"maximum_per_recipient,omitempty" comment:"maximum amount of tez signed for a single recipient, 0 means unlimited"`
+	MaximumFeePerOperation float64 `json:"maximum_fee_per_operation,omitempty" comment:"maximum transaction fee in tez of a single operation (batch), 0 means unlimited"`
+	RestrictRecipients     bool    `json:"restrict_recipients,omitempty" comment:"if true, only delegators, their override recipients and income recipients can receive payouts"`
 }
 
 type ExtensionConfigurationV0 = common.ExtensionDefinition
@@ -84,6 +156,10 @@ type ConfigurationV0 struct {
 	IncomeRecipients           IncomeRecipientsV0            `json:"income_recipients,omitempty" comment:"income recipients configuration"`
 	Network                    TezosNetworkConfigurationV0   `json:"network,omitempty" comment:"tezos network configuration"`
 	Overdelegation             OverdelegationConfigurationV0 `json:"overdelegation,omitempty" comment:"overdelegation protection configuration"`
+	SigningPolicy              SigningPolicyConfigurationV0  `json:"signing_policy,omitempty" comment:"limits enforced before signing payouts"`
+	StakerBonus                *StakerBonusV0                `json:"staker_bonus,omitempty" comment:"liquid bonus paid to external stakers from the baker's edge"`
+	Reports                    ReportsConfigurationV0        `json:"reports,omitempty" comment:"payout reports configuration"`
+	Accounting                 AccountingV0                  `json:"accounting,omitempty" comment:"fiat valuation of payouts for accounting exports"`
 	NotificationConfigurations []json.RawMessage             `json:"notifications,omitempty" comment:"notification configurations"`
 	Extensions                 []ExtensionConfigurationV0    `json:"extensions,omitempty" comment:"extensions (for custom functionality)"`
 	SourceBytes                []byte                        `json:"-"`
@@ -122,6 +198,7 @@ func GetDefaultV0() ConfigurationV0 {
 			Explorer:               constants.DEFAULT_EXPLORER_URL,
 			DoNotPaySmartContracts: false,
 			IgnoreProtocolChanges:  false,
+			BroadcastMode:          enums.BROADCAST_MODE_SINGLE,
 		},
 		Overdelegation: OverdelegationConfigurationV0{
 			IsProtectionEnabled: true,
@@ -3,6 +3,7 @@ package configuration
 import (
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/constants"
@@ -38,6 +39,30 @@ func (configuration *RuntimeConfiguration) Validate() (err error) {
 	_assert(configuration.PayoutConfiguration.MinimumDelayBlocks <= configuration.PayoutConfiguration.MaximumDelayBlocks,
 		"configuration.payouts.minimum_delay_blocks must be less or equal to configuration.payouts.maximum_delay_blocks")
 
+	if configuration.PayoutConfiguration.Multisig != nil {
+		_assert(configuration.PayoutConfiguration.Multisig.Contract.Type() == tezos.AddressTypeContract,
+			fmt.Sprintf("configuration.payouts.multisig.contract - '%s' is not a contract address", configuration.PayoutConfiguration.Multisig.Contract))
+	}
+
+	if topUp := configuration.PayoutConfiguration.TopUp; topUp != nil {
+		_assert(topUp.Signer != "", "configuration.payouts.top_up.signer is required")
+		_assert(!topUp.Buffer.IsNeg(), "configuration.payouts.top_up.buffer must not be negative")
+		_assert(tezos.Zero.IsLess(topUp.DailyLimit), "configuration.payouts.top_up.daily_limit has to be greater than 0")
+		_assert(!topUp.MaximumPerTransfer.IsNeg(), "configuration.payouts.top_up.maximum_per_transfer must not be negative")
+	}
+
+	if autoStake := configuration.PayoutConfiguration.AutoStake; autoStake != nil {
+		_assert(autoStake.Signer != "", "configuration.payouts.auto_stake.signer is required")
+		_assert(utils.IsPortionWithin0n1(autoStake.BondsShare), getPortionRangeError("configuration.payouts.auto_stake.bonds_share", autoStake.BondsShare))
+		_assert(utils.IsPortionWithin0n1(autoStake.FeesShare), getPortionRangeError("configuration.payouts.auto_stake.fees_share", autoStake.FeesShare))
+		_assert(!autoStake.LiquidReserve.IsNeg(), "configuration.payouts.auto_stake.liquid_reserve must not be negative")
+	}
+
+	if stakerBonus := configuration.StakerBonus; stakerBonus != nil {
+		_assert(utils.IsPortionWithin0n1(stakerBonus.Share), getPortionRangeError("configuration.staker_bonus.share", stakerBonus.Share))
+		_assert(!stakerBonus.MinimumAmount.IsNeg(), "configuration.staker_bonus.minimum_amount must not be negative")
+	}
+
 	_assert(lo.Contains(enums.SUPPORTED_DELEGATOR_MINIMUM_BALANCE_REWARD_DESTINATIONS, configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination),
 		fmt.Sprintf("configuration.delegators.requirements.below_minimum_reward_destination - '%s' not supported", configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination))
 
@@ -47,6 +72,12 @@ func (configuration *RuntimeConfiguration) Validate() (err error) {
 		getPortionRangeError("configuration.income_recipients.donate/fees", configuration.IncomeRecipients.DonateFees))
 	_assert(utils.IsPortionWithin0n1(configuration.IncomeRecipients.DonateBonds),
 		getPortionRangeError("configuration.income_recipients.donate/bonds", configuration.IncomeRecipients.DonateBonds))
+	_assert(utils.IsPortionWithin0n1(configuration.IncomeRecipients.DonateStakingEdge),
+		getPortionRangeError("configuration.income_recipients.donate/staking_edge", configuration.IncomeRecipients.DonateStakingEdge))
+
+	_assert(!configuration.SigningPolicy.MaximumTotalPerCycle.IsNeg(), "configuration.signing_policy.maximum_total_per_cycle must not be negative")
+	_assert(!configuration.SigningPolicy.MaximumPerRecipient.IsNeg(), "configuration.signing_policy.maximum_per_recipient must not be negative")
+	_assert(!configuration.SigningPolicy.MaximumFeePerOperation.IsNeg(), "configuration.signing_policy.maximum_fee_per_operation must not be negative")
 
 	bondsPortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.Bonds), func(agg float64, val float64, _ int) float64 {
 		return agg + val
@@ -67,6 +98,16 @@ func (configuration *RuntimeConfiguration) Validate() (err error) {
 		_assert(err == nil, fmt.Sprintf("configuration.income_recipients.fees.%s has to be valid PKH", k))
 	}
 
+	stakingEdgePortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.StakingEdge), func(agg float64, val float64, _ int) float64 {
+		return agg + val
+	}, float64(0))
+	_assert(utils.IsPortionWithin0n1(stakingEdgePortions),
+		getPortionRangeError("configuration.income_recipients.staking_edge sum", stakingEdgePortions))
+	for k := range configuration.IncomeRecipients.StakingEdge {
+		_, err := tezos.ParseAddress(k)
+		_assert(err == nil, fmt.Sprintf("configuration.income_recipients.staking_edge.%s has to be valid PKH", k))
+	}
+
 	donatePortions := lo.Reduce(lo.Values(configuration.IncomeRecipients.Donations), func(agg float64, val float64, _ int) float64 {
 		return agg + val
 	}, float64(0))
@@ -92,6 +133,37 @@ func (configuration *RuntimeConfiguration) Validate() (err error) {
 		_assert(err == nil, fmt.Sprintf("configuration.notifications.%s has invalid configuration - %s", v.Type, err.Error()))
 	}
 
+	feeStrategy := configuration.PayoutConfiguration.FeeStrategy
+	_assert(lo.Contains(enums.SUPPORTED_FEE_STRATEGIES, feeStrategy.Kind),
+		fmt.Sprintf("configuration.payouts.fee_strategy.kind - '%s' not supported", feeStrategy.Kind))
+	_assert(feeStrategy.Multiplier >= 1, "configuration.payouts.fee_strategy.multiplier has to be at least 1")
+	_assert(feeStrategy.MempoolPercentile >= 0 && feeStrategy.MempoolPercentile <= 100, "configuration.payouts.fee_strategy.mempool_percentile has to be between 0 and 100")
+	_assert(feeStrategy.MaximumMultiplier >= 1, "configuration.payouts.fee_strategy.maximum_multiplier has to be at least 1")
+
 	_assert(len(configuration.Network.RpcPool) > 0, "no rpc specified")
+	_assert(lo.Contains(enums.SUPPORTED_BROADCAST_MODES, configuration.Network.BroadcastMode),
+		fmt.Sprintf("configuration.network.broadcast_mode - '%s' not supported", configuration.Network.BroadcastMode))
+
+	_assert(lo.Contains(enums.SUPPORTED_REPORTER_ENGINES, configuration.Reports.Engine),
+		fmt.Sprintf("configuration.reports.engine - '%s' not supported", configuration.Reports.Engine))
+	accounting := configuration.Accounting
+	_assert(lo.Contains(enums.SUPPORTED_PRICE_SOURCES, accounting.PriceSource),
+		fmt.Sprintf("configuration.accounting.price_source - '%s' not supported", accounting.PriceSource))
+	_assert(accounting.PriceSource != enums.PRICE_SOURCE_CSV || accounting.PriceFile != "", "configuration.accounting.price_file is required for 'csv' price source")
+	_assert(!lo.Contains(accounting.Currencies, ""), "configuration.accounting.currencies must not contain empty currency")
+
+	for i, sink := range configuration.Reports.Sinks {
+		_assert(lo.Contains(enums.SUPPORTED_REPORT_SINK_ENGINES, sink.Engine),
+			fmt.Sprintf("configuration.reports.sinks[%d].engine - '%s' not supported", i, sink.Engine))
+		_assert(lo.Contains(enums.SUPPORTED_REPORT_SINK_POLICIES, sink.Policy),
+			fmt.Sprintf("configuration.reports.sinks[%d].policy - '%s' not supported", i, sink.Policy))
+		_assert(sink.RetryLimit >= 0, fmt.Sprintf("configuration.reports.sinks[%d].retry_limit has to be non-negative", i))
+		if sink.Engine == enums.REPORTER_ENGINE_HTTP {
+			sinkUrl, err := url.Parse(sink.Url)
+			_assert(err == nil && sinkUrl.Scheme != "" && sinkUrl.Host != "", fmt.Sprintf("configuration.reports.sinks[%d].url - '%s' is not valid url", i, sink.Url))
+		}
+		_assert(sink.Engine != configuration.Reports.Engine || sink.Engine == enums.REPORTER_ENGINE_HTTP,
+			fmt.Sprintf("configuration.reports.sinks[%d].engine - '%s' is already used as primary engine", i, sink.Engine))
+	}
 	return
 }
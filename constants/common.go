@@ -28,6 +28,9 @@ const (
 	MAX_OPERATION_TTL  = 12   // 12 blocks
 	ALLOCATION_STORAGE = 257
 
+	// offline signed operations have to survive the round trip to the air-gapped machine
+	OFFLINE_SIGNING_OPERATION_TTL = 240 // 240 blocks
+
 	DEFAULT_CYCLE_MONITOR_MAXIMUM_DELAY = int64(1500)
 	DEFAULT_CYCLE_MONITOR_MINIMUM_DELAY = int64(500)
 
@@ -35,8 +38,43 @@ const (
 	PAYOUT_REPORT_FILE_NAME   = "payouts.csv"
 	INVALID_REPORT_FILE_NAME  = "invalid.csv"
 	REPORT_SUMMARY_FILE_NAME  = "summary.json"
+	REPORT_SCHEMA_FILE_NAME   = "schema.json"
 	REPORTS_DIRECTORY         = "reports"
 
+	REPORTS_DATABASE_FILE_NAME = "reports.db"
+	REPORTS_LEDGER_FILE_NAME   = "ledger.jsonl"
+	REPORTS_BACKUP_DIRECTORY   = "backups"
+
+	OFFLINE_SIGNING_BUNDLE_VERSION = 1
+
+	MULTISIG_PROPOSAL_BUNDLE_VERSION = 1
+
+	// tenderbake blocks are final once two blocks are built on top of them
+	OPERATION_FINALITY_DEPTH            = int64(2)
+	OPERATION_CONFIRMATION_POLL_SECONDS = 5
+	MAX_REORG_RETRIES                   = 2
+
+	DEFAULT_FEE_STRATEGY_MULTIPLIER   = float64(1.5)
+	DEFAULT_MEMPOOL_FEE_PERCENTILE    = float64(75)
+	DEFAULT_MAXIMUM_FEE_MULTIPLIER    = float64(5)
+	PROTOCOL_MINIMAL_FEE_MUTEZ        = int64(100)
+	PROTOCOL_MINIMAL_NANOTEZ_PER_GAS  = int64(100)
+	PROTOCOL_MINIMAL_NANOTEZ_PER_BYTE = int64(1000)
+
+	// buffer per multisig call paid by the payout wallet - base fee, signatures and execution of the multisig script
+	MULTISIG_CALL_FEE_BUFFER = int64(5000)
+
+	DEFAULT_TOP_UP_BUFFER = float64(10)
+
+	DEFAULT_REPORT_SINK_RETRY_LIMIT = 10
+
+	DEFAULT_COINGECKO_URL = "https://api.coingecko.com/api/v3"
+
+	// 30720 blocks per cycle with 8 second blocks
+	ESTIMATED_CYCLES_PER_YEAR = float64(365.25*24*60*60) / (30720 * 8)
+
+	AUTO_STAKE_MINIMUM_AMOUNT = int64(1_000_000)
+
 	DEFAULT_DONATION_ADDRESS    = "tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv"
 	DEFAULT_DONATION_PERCENTAGE = 0.05
 
@@ -48,4 +86,5 @@ var (
 		"https://eu.rpc.tez.capital/",
 		"https://us.rpc.tez.capital/",
 	}
+	DEFAULT_ACCOUNTING_CURRENCIES = []string{"eur", "usd"}
 )
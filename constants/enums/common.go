@@ -7,6 +7,8 @@ const (
 	WALLET_MODE_LOCAL_PRIVATE_KEY2 EWalletMode = "local_private_key"
 	WALLET_MODE_REMOTE_SIGNER      EWalletMode = "remote-signer"
 	WALLET_MODE_REMOTE_SIGNER2     EWalletMode = "remote_signer"
+	WALLET_MODE_TRANSIT_SIGNER     EWalletMode = "transit-signer"
+	WALLET_MODE_TRANSIT_SIGNER2    EWalletMode = "transit_signer"
 )
 
 var (
@@ -15,6 +17,8 @@ var (
 		WALLET_MODE_LOCAL_PRIVATE_KEY2,
 		WALLET_MODE_REMOTE_SIGNER,
 		WALLET_MODE_REMOTE_SIGNER2,
+		WALLET_MODE_TRANSIT_SIGNER,
+		WALLET_MODE_TRANSIT_SIGNER2,
 	}
 )
 
@@ -59,18 +63,21 @@ const (
 	PAYOUT_KIND_FEE_INCOME       EPayoutKind = "fee income"
 	PAYOUT_KIND_ACCUMULATED      EPayoutKind = "accumulated"
 	PAYOUT_KIND_INVALID          EPayoutKind = "invalid"
+
+	PAYOUT_KIND_STAKING_EDGE_INCOME EPayoutKind = "staking edge income"
+	PAYOUT_KIND_STAKER_BONUS        EPayoutKind = "staker bonus"
 )
 
 func (kind EPayoutKind) ToPriority() int {
 	// for odering
 	switch kind {
-	case PAYOUT_KIND_DELEGATOR_REWARD:
+	case PAYOUT_KIND_DELEGATOR_REWARD, PAYOUT_KIND_STAKER_BONUS:
 		return 10
 	case PAYOUT_KIND_BAKER_REWARD:
 		return 9
 	case PAYOUT_KIND_DONATION:
 		return 8
-	case PAYOUT_KIND_FEE_INCOME:
+	case PAYOUT_KIND_FEE_INCOME, PAYOUT_KIND_STAKING_EDGE_INCOME:
 		return 7
 	case PAYOUT_KIND_ACCUMULATED:
 		return 6
@@ -116,3 +123,160 @@ var (
 	PROTOCOL_BALANCE_CHECK_MODE = EBalanceCheckMode("protocol")
 	TZKT_BALANCE_CHECK_MODE     = EBalanceCheckMode("tzkt")
 )
+
+type EFeeStrategy string
+
+const (
+	FEE_STRATEGY_MINIMAL    EFeeStrategy = "minimal"
+	FEE_STRATEGY_MULTIPLIER EFeeStrategy = "multiplier"
+	FEE_STRATEGY_MEMPOOL    EFeeStrategy = "mempool"
+)
+
+var (
+	SUPPORTED_FEE_STRATEGIES = []EFeeStrategy{
+		FEE_STRATEGY_MINIMAL,
+		FEE_STRATEGY_MULTIPLIER,
+		FEE_STRATEGY_MEMPOOL,
+	}
+)
+
+type EReporterEngine string
+
+const (
+	REPORTER_ENGINE_FS     EReporterEngine = "fs"
+	REPORTER_ENGINE_SQLITE EReporterEngine = "sqlite"
+)
+
+const (
+	REPORTER_ENGINE_HTTP EReporterEngine = "http"
+)
+
+var (
+	SUPPORTED_REPORTER_ENGINES = []EReporterEngine{
+		REPORTER_ENGINE_FS,
+		REPORTER_ENGINE_SQLITE,
+	}
+	SUPPORTED_REPORT_SINK_ENGINES = []EReporterEngine{
+		REPORTER_ENGINE_FS,
+		REPORTER_ENGINE_SQLITE,
+		REPORTER_ENGINE_HTTP,
+	}
+)
+
+type EStatementFormat string
+
+const (
+	STATEMENT_FORMAT_CSV  EStatementFormat = "csv"
+	STATEMENT_FORMAT_JSON EStatementFormat = "json"
+	STATEMENT_FORMAT_HTML EStatementFormat = "html"
+)
+
+var (
+	SUPPORTED_STATEMENT_FORMATS = []EStatementFormat{
+		STATEMENT_FORMAT_CSV,
+		STATEMENT_FORMAT_JSON,
+		STATEMENT_FORMAT_HTML,
+	}
+)
+
+type EStatisticsView string
+
+const (
+	STATISTICS_VIEW_SUMMARY    EStatisticsView = "summary"
+	STATISTICS_VIEW_DELEGATORS EStatisticsView = "delegators"
+	STATISTICS_VIEW_CYCLES     EStatisticsView = "cycles"
+	STATISTICS_VIEW_CHURN      EStatisticsView = "churn"
+	STATISTICS_VIEW_APY        EStatisticsView = "apy"
+)
+
+var (
+	SUPPORTED_STATISTICS_VIEWS = []EStatisticsView{
+		STATISTICS_VIEW_SUMMARY,
+		STATISTICS_VIEW_DELEGATORS,
+		STATISTICS_VIEW_CYCLES,
+		STATISTICS_VIEW_CHURN,
+		STATISTICS_VIEW_APY,
+	}
+)
+
+type EStatisticsFormat string
+
+const (
+	STATISTICS_FORMAT_TABLE EStatisticsFormat = "table"
+	STATISTICS_FORMAT_CSV   EStatisticsFormat = "csv"
+	STATISTICS_FORMAT_JSON  EStatisticsFormat = "json"
+)
+
+var (
+	SUPPORTED_STATISTICS_FORMATS = []EStatisticsFormat{
+		STATISTICS_FORMAT_TABLE,
+		STATISTICS_FORMAT_CSV,
+		STATISTICS_FORMAT_JSON,
+	}
+)
+
+type EPriceSource string
+
+const (
+	PRICE_SOURCE_COINGECKO EPriceSource = "coingecko"
+	PRICE_SOURCE_CSV       EPriceSource = "csv"
+)
+
+var (
+	SUPPORTED_PRICE_SOURCES = []EPriceSource{
+		PRICE_SOURCE_COINGECKO,
+		PRICE_SOURCE_CSV,
+	}
+)
+
+type EAccountingCategory string
+
+const (
+	ACCOUNTING_CATEGORY_BAKER_INCOME      EAccountingCategory = "baker_income"
+	ACCOUNTING_CATEGORY_DONATION          EAccountingCategory = "donation"
+	ACCOUNTING_CATEGORY_DELEGATOR_PAYMENT EAccountingCategory = "delegator_payment"
+)
+
+type EAccountingExportFormat string
+
+const (
+	ACCOUNTING_EXPORT_FORMAT_GENERIC      EAccountingExportFormat = "generic"
+	ACCOUNTING_EXPORT_FORMAT_KOINLY       EAccountingExportFormat = "koinly"
+	ACCOUNTING_EXPORT_FORMAT_COINTRACKING EAccountingExportFormat = "cointracking"
+)
+
+var (
+	SUPPORTED_ACCOUNTING_EXPORT_FORMATS = []EAccountingExportFormat{
+		ACCOUNTING_EXPORT_FORMAT_GENERIC,
+		ACCOUNTING_EXPORT_FORMAT_KOINLY,
+		ACCOUNTING_EXPORT_FORMAT_COINTRACKING,
+	}
+)
+
+type EReportSinkPolicy string
+
+const (
+	REPORT_SINK_POLICY_MUST_SUCCEED EReportSinkPolicy = "must_succeed"
+	REPORT_SINK_POLICY_BEST_EFFORT  EReportSinkPolicy = "best_effort"
+)
+
+var (
+	SUPPORTED_REPORT_SINK_POLICIES = []EReportSinkPolicy{
+		REPORT_SINK_POLICY_MUST_SUCCEED,
+		REPORT_SINK_POLICY_BEST_EFFORT,
+	}
+)
+
+type EBroadcastMode string
+
+const (
+	BROADCAST_MODE_SINGLE  EBroadcastMode = "single"
+	BROADCAST_MODE_FAN_OUT EBroadcastMode = "fan-out"
+)
+
+var (
+	SUPPORTED_BROADCAST_MODES = []EBroadcastMode{
+		BROADCAST_MODE_SINGLE,
+		BROADCAST_MODE_FAN_OUT,
+	}
+)
@@ -13,6 +13,7 @@ var (
 	ErrConfigurationLoadFailed            = errors.New("failed to load configuration")
 	ErrConfigurationValidationFailed      = errors.New("failed to validate configuration")
 	ErrSignerLoadFailed                   = errors.New("failed to load signer engine")
+	ErrSignerPassphraseUnavailable        = errors.New("private key passphrase not available")
 	ErrTransactorLoadFailed               = errors.New("failed to load transactor engine")
 	ErrCollectorLoadFailed                = errors.New("failed to load collector engine")
 	ErrExtensionStoreInitializationFailed = errors.New("failed to initialize extension store")
@@ -69,6 +70,8 @@ var (
 
 	ErrExecutePayoutsUserTerminated = errors.New("user terminated execution")
 	ErrGetChainLimitsFailed         = errors.New("failed to get chain limits")
+	ErrSigningPolicyViolation       = errors.New("signing policy violation")
+	ErrSigningPolicyLoadFailed      = errors.New("failed to load signing policy")
 
 	// notifications
 
@@ -85,6 +88,106 @@ var (
 	ErrOperationInvalidContractAddress = errors.New("invalid contract address")
 	ErrOperationInvalidLimits          = errors.New("invalid limits")
 	ErrOperationFailed                 = errors.New("operation failed")
+	ErrOperationDroppedByReorg         = errors.New("operation was dropped from the chain by reorganization")
+
+	// offline signing
+
+	ErrSignerCannotSign                = errors.New("signer is not able to sign operations")
+	ErrOfflineBundleLoadFailed         = errors.New("failed to load offline signing bundle")
+	ErrOfflineBundleSaveFailed         = errors.New("failed to save offline signing bundle")
+	ErrOfflineBundleUnsupportedVersion = errors.New("unsupported offline signing bundle version")
+	ErrOfflineBundleSourceMismatch     = errors.New("offline signing bundle source does not match signer")
+	ErrOfflineBundleContentsMismatch   = errors.New("forged operation does not match payouts")
+	ErrOfflineBundleInvalidOperation   = errors.New("invalid forged operation")
+	ErrOfflineBundleNotSigned          = errors.New("offline signing bundle is not signed")
+	ErrOfflineBundleInvalidSignature   = errors.New("invalid signature")
+
+	// private key encryption
+
+	ErrPrivateKeyAlreadyEncrypted   = errors.New("private key is already encrypted")
+	ErrPrivateKeyEncryptionFailed   = errors.New("failed to encrypt private key")
+	ErrPrivateKeyPassphraseMismatch = errors.New("passphrases do not match")
+
+	// multisig
+
+	ErrMultisigStorageLoadFailed        = errors.New("failed to load multisig contract storage")
+	ErrMultisigInvalidStorage           = errors.New("multisig contract storage is not compatible with generic multisig")
+	ErrMultisigProposalCreationFailed   = errors.New("failed to create multisig proposal")
+	ErrMultisigSignerNotAuthorized      = errors.New("signer is not one of the multisig keys")
+	ErrMultisigSignerCannotSign         = errors.New("signer is not able to sign multisig proposals")
+	ErrMultisigInvalidSignature         = errors.New("invalid multisig proposal signature")
+	ErrMultisigThresholdNotReached      = errors.New("multisig proposal does not have enough signatures")
+	ErrMultisigCounterMismatch          = errors.New("multisig proposal counter does not match contract counter")
+	ErrMultisigBundleLoadFailed         = errors.New("failed to load multisig proposal bundle")
+	ErrMultisigBundleSaveFailed         = errors.New("failed to save multisig proposal bundle")
+	ErrMultisigBundleUnsupportedVersion = errors.New("unsupported multisig proposal bundle version")
+	ErrMultisigBundleContractMismatch   = errors.New("multisig proposal bundle contract does not match configuration")
+
+	// wallet
+
+	ErrWalletStatusLoadFailed    = errors.New("failed to load payout wallet status")
+	ErrWalletAlreadyRevealed     = errors.New("payout wallet is already revealed")
+	ErrWalletRevealFailed        = errors.New("failed to reveal payout wallet")
+	ErrWalletRotationUnsupported = errors.New("key rotation is supported only for local private key wallet mode")
+	ErrWalletRotationFailed      = errors.New("failed to rotate payout wallet key")
+	ErrWalletRotationSameKey     = errors.New("new key is the same as the current payout wallet key")
+	ErrWalletSweepFailed         = errors.New("failed to transfer remaining balance")
+	ErrWalletInsufficientBalance = errors.New("remaining balance does not cover transfer costs")
+
+	// top up
+
+	ErrTopUpFailed         = errors.New("failed to top up payout wallet")
+	ErrTopUpLimitExceeded  = errors.New("top up would exceed configured limits")
+	ErrTopUpLogLoadFailed  = errors.New("failed to load top up log")
+	ErrTopUpLogWriteFailed = errors.New("failed to write top up log")
+
+	// auto stake
+
+	ErrAutoStakeFailed = errors.New("failed to stake income")
+
+	// reporter
+
+	ErrReporterLoadFailed             = errors.New("failed to load reporter engine")
+	ErrUnsupportedReporterEngine      = errors.New("unsupported reporter engine")
+	ErrReportsDatabaseMigrationFailed = errors.New("failed to migrate reports database")
+	ErrReportsImportFailed            = errors.New("failed to import file system reports")
+	ErrReportsWriteFailed             = errors.New("failed to write reports")
+	ErrReportSinkWriteFailed          = errors.New("failed to write reports to sink")
+	ErrReporterReadNotSupported       = errors.New("reporter engine does not support reading reports")
+	ErrReportsSchemaMigrationFailed   = errors.New("failed to migrate report file schema")
+
+	// ledger
+
+	ErrLedgerLoadFailed  = errors.New("failed to load payout ledger")
+	ErrLedgerWriteFailed = errors.New("failed to append to payout ledger")
+
+	// reports check
+
+	ErrReportsCheckFailed  = errors.New("failed to check reports")
+	ErrReportsRepairFailed = errors.New("failed to repair reports")
+
+	// statements
+
+	ErrStatementWriteFailed       = errors.New("failed to write statement")
+	ErrUnsupportedStatementFormat = errors.New("unsupported statement format")
+
+	// accounting
+
+	ErrPriceEngineLoadFailed       = errors.New("failed to load price engine")
+	ErrPriceNotAvailable           = errors.New("price not available")
+	ErrAccountingExportFailed      = errors.New("failed to export accounting records")
+	ErrUnsupportedAccountingFormat = errors.New("unsupported accounting export format")
+
+	// statistics
+
+	ErrStatisticsExportFailed      = errors.New("failed to export statistics")
+	ErrUnsupportedStatisticsFormat = errors.New("unsupported statistics format")
+
+	// api
+
+	ErrApiUnauthorized         = errors.New("unauthorized")
+	ErrInvalidApiRequest       = errors.New("invalid request")
+	ErrPayoutAlreadyInProgress = errors.New("payout processing is already in progress")
 
 	// extensions
 
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// fee income payouts only forward fees withheld from delegator payments which are recorded as baker income already
+func getAccountingCategory(kind enums.EPayoutKind) (enums.EAccountingCategory, bool) {
+	switch kind {
+	case enums.PAYOUT_KIND_BAKER_REWARD, enums.PAYOUT_KIND_STAKING_EDGE_INCOME:
+		return enums.ACCOUNTING_CATEGORY_BAKER_INCOME, true
+	case enums.PAYOUT_KIND_DONATION:
+		return enums.ACCOUNTING_CATEGORY_DONATION, true
+	case enums.PAYOUT_KIND_DELEGATOR_REWARD, enums.PAYOUT_KIND_STAKER_BONUS, enums.PAYOUT_KIND_ACCUMULATED:
+		return enums.ACCOUNTING_CATEGORY_DELEGATOR_PAYMENT, true
+	default:
+		return "", false
+	}
+}
+
+// BuildAccountingRecords values successful tez payouts from reports in currencies at the time of payment.
+// Baker fee withheld from delegator payment is recorded as separate baker income.
+func BuildAccountingRecords(reports []common.PayoutReport, priceEngine common.PriceEngine, currencies []string) ([]common.AccountingRecord, error) {
+	records := make([]common.AccountingRecord, 0, len(reports))
+	for _, report := range reports {
+		if !report.IsSuccess || report.TxKind != enums.PAYOUT_TX_KIND_TEZ {
+			continue
+		}
+		category, ok := getAccountingCategory(report.Kind)
+		if !ok {
+			continue
+		}
+
+		prices := make(map[string]float64, len(currencies))
+		for _, currency := range currencies {
+			price, err := priceEngine.GetPrice(currency, report.Timestamp)
+			if err != nil {
+				return nil, errors.Join(constants.ErrAccountingExportFailed, fmt.Errorf("cycle %d, op %s", report.Cycle, report.OpHash), err)
+			}
+			prices[currency] = price
+		}
+		records = append(records, common.AccountingRecord{
+			Timestamp:      report.Timestamp,
+			Cycle:          report.Cycle,
+			Category:       category,
+			Kind:           report.Kind,
+			Delegator:      report.Delegator,
+			Recipient:      report.Recipient,
+			Amount:         report.Amount,
+			Fee:            report.Fee,
+			TransactionFee: tezos.NewZ(report.TransactionFee),
+			OpHash:         report.OpHash,
+			Prices:         prices,
+		})
+		if category == enums.ACCOUNTING_CATEGORY_DELEGATOR_PAYMENT && tezos.Zero.IsLess(report.Fee) {
+			records = append(records, common.AccountingRecord{
+				Timestamp:      report.Timestamp,
+				Cycle:          report.Cycle,
+				Category:       enums.ACCOUNTING_CATEGORY_BAKER_INCOME,
+				Kind:           enums.PAYOUT_KIND_FEE_INCOME,
+				Delegator:      report.Delegator,
+				Recipient:      report.Baker,
+				Amount:         report.Fee,
+				Fee:            tezos.Zero,
+				TransactionFee: tezos.Zero,
+				OpHash:         report.OpHash,
+				Prices:         prices,
+			})
+		}
+	}
+	slices.SortStableFunc(records, func(a, b common.AccountingRecord) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return records, nil
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	accountingAsset = "XTZ"
+)
+
+func formatTez(amount tezos.Z) string {
+	return strconv.FormatFloat(float64(amount.Int64())/constants.MUTEZ_FACTOR, 'f', 6, 64)
+}
+
+func formatFiat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func getAccountingDescription(record *common.AccountingRecord) string {
+	description := fmt.Sprintf("%s, cycle %d", record.Kind, record.Cycle)
+	if record.Category == enums.ACCOUNTING_CATEGORY_DELEGATOR_PAYMENT || record.Kind == enums.PAYOUT_KIND_FEE_INCOME {
+		description = fmt.Sprintf("%s, delegator %s", description, record.Delegator)
+	}
+	return description
+}
+
+// generic export keeps all the report data with price and fiat values for each currency
+func marshalGenericAccountingRecords(records []common.AccountingRecord, currencies []string) [][]string {
+	header := []string{"timestamp", "cycle", "category", "kind", "delegator", "recipient", "amount", "fee", "tx_fee", "op_hash"}
+	for _, currency := range currencies {
+		header = append(header, "price_"+currency, "amount_"+currency, "fee_"+currency, "tx_fee_"+currency)
+	}
+	rows := [][]string{header}
+	for _, record := range records {
+		delegator := ""
+		if record.Delegator.IsValid() {
+			delegator = record.Delegator.String()
+		}
+		row := []string{
+			record.Timestamp.UTC().Format("2006-01-02T15:04:05Z"),
+			strconv.FormatInt(record.Cycle, 10),
+			string(record.Category),
+			string(record.Kind),
+			delegator,
+			record.Recipient.String(),
+			formatTez(record.Amount),
+			formatTez(record.Fee),
+			formatTez(record.TransactionFee),
+			record.OpHash.String(),
+		}
+		for _, currency := range currencies {
+			row = append(row,
+				strconv.FormatFloat(record.Prices[currency], 'f', -1, 64),
+				formatFiat(record.GetFiatValue(record.Amount, currency)),
+				formatFiat(record.GetFiatValue(record.Fee, currency)),
+				formatFiat(record.GetFiatValue(record.TransactionFee, currency)),
+			)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// koinly universal format, baker income is received as reward, everything else is sent
+func marshalKoinlyAccountingRecords(records []common.AccountingRecord, currencies []string) [][]string {
+	rows := [][]string{{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"}}
+	for _, record := range records {
+		row := []string{record.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC"), "", "", "", "", formatTez(record.TransactionFee), accountingAsset, "", "", "", getAccountingDescription(&record), record.OpHash.String()}
+		switch record.Category {
+		case enums.ACCOUNTING_CATEGORY_BAKER_INCOME:
+			row[3], row[4], row[9] = formatTez(record.Amount), accountingAsset, "reward"
+		case enums.ACCOUNTING_CATEGORY_DONATION:
+			row[1], row[2], row[9] = formatTez(record.Amount), accountingAsset, "donation"
+		default:
+			row[1], row[2] = formatTez(record.Amount), accountingAsset
+		}
+		if len(currencies) > 0 {
+			row[7], row[8] = formatFiat(record.GetFiatValue(record.Amount, currencies[0])), strings.ToUpper(currencies[0])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// cointracking csv import format, baker income is staking income, delegator payments are withdrawals
+func marshalCoinTrackingAccountingRecords(records []common.AccountingRecord) [][]string {
+	rows := [][]string{{"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency", "Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date", "Tx-ID"}}
+	for _, record := range records {
+		row := []string{"", "", "", "", "", formatTez(record.TransactionFee), accountingAsset, "Tezos", string(record.Category), getAccountingDescription(&record), record.Timestamp.UTC().Format("2006-01-02 15:04:05"), record.OpHash.String()}
+		switch record.Category {
+		case enums.ACCOUNTING_CATEGORY_BAKER_INCOME:
+			row[0], row[1], row[2] = "Staking", formatTez(record.Amount), accountingAsset
+		case enums.ACCOUNTING_CATEGORY_DONATION:
+			row[0], row[3], row[4] = "Donation", formatTez(record.Amount), accountingAsset
+		default:
+			row[0], row[3], row[4] = "Withdrawal", formatTez(record.Amount), accountingAsset
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// MarshalAccountingRecords writes records as csv in requested format
+func MarshalAccountingRecords(records []common.AccountingRecord, currencies []string, format enums.EAccountingExportFormat) ([]byte, error) {
+	var rows [][]string
+	switch format {
+	case enums.ACCOUNTING_EXPORT_FORMAT_GENERIC:
+		rows = marshalGenericAccountingRecords(records, currencies)
+	case enums.ACCOUNTING_EXPORT_FORMAT_KOINLY:
+		rows = marshalKoinlyAccountingRecords(records, currencies)
+	case enums.ACCOUNTING_EXPORT_FORMAT_COINTRACKING:
+		rows = marshalCoinTrackingAccountingRecords(records)
+	default:
+		return nil, errors.Join(constants.ErrUnsupportedAccountingFormat, fmt.Errorf("format - %s", format))
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, errors.Join(constants.ErrAccountingExportFailed, err)
+	}
+	return buffer.Bytes(), nil
+}
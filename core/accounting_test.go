@@ -0,0 +1,80 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type fixedPriceEngine map[string]float64
+
+func (engine fixedPriceEngine) GetId() string {
+	return "fixedPriceEngine"
+}
+
+func (engine fixedPriceEngine) GetPrice(currency string, at time.Time) (float64, error) {
+	return engine[currency], nil
+}
+
+func TestAccountingExport(t *testing.T) {
+	assert := assert.New(t)
+
+	report := func(kind enums.EPayoutKind, amount int64, minute int) common.PayoutReport {
+		report := mock.NewPayoutReport(100, amount)
+		report.Timestamp = mock.PayoutReportTimestamp.Add(time.Duration(minute) * time.Minute)
+		report.Kind, report.Fee, report.TransactionFee = kind, tezos.NewZ(amount/10), 1_000
+		return report
+	}
+	failed := report(enums.PAYOUT_KIND_DELEGATOR_REWARD, 9_000_000, 0)
+	failed.IsSuccess = false
+	reports := []common.PayoutReport{
+		report(enums.PAYOUT_KIND_DONATION, 500_000, 3),
+		report(enums.PAYOUT_KIND_DELEGATOR_REWARD, 10_000_000, 1),
+		report(enums.PAYOUT_KIND_FEE_INCOME, 2_000_000, 2),
+		failed,
+	}
+
+	currencies := []string{"eur", "usd"}
+	records, err := BuildAccountingRecords(reports, fixedPriceEngine{"eur": 0.8, "usd": 1.0}, currencies)
+	assert.Nil(err)
+	assert.Len(records, 3)
+	assert.Equal(enums.ACCOUNTING_CATEGORY_DELEGATOR_PAYMENT, records[0].Category)
+	t.Log("baker fee withheld from delegator payment is baker income, fee income payouts only forward it")
+	assert.Equal(enums.ACCOUNTING_CATEGORY_BAKER_INCOME, records[1].Category)
+	assert.Equal(enums.PAYOUT_KIND_FEE_INCOME, records[1].Kind)
+	assert.Equal(int64(1_000_000), records[1].Amount.Int64())
+	assert.Equal(records[0].OpHash, records[1].OpHash)
+	assert.Equal(enums.ACCOUNTING_CATEGORY_DONATION, records[2].Category)
+	assert.InDelta(8.0, records[0].GetFiatValue(records[0].Amount, "eur"), 0.000001)
+
+	data, err := MarshalAccountingRecords(records, currencies, enums.ACCOUNTING_EXPORT_FORMAT_GENERIC)
+	assert.Nil(err)
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.Nil(err)
+	assert.Len(rows, 4)
+	assert.Len(rows[0], 18)
+	assert.Equal("10.000000", rows[1][6])
+	assert.Equal("8.00", rows[1][11])
+
+	data, err = MarshalAccountingRecords(records, currencies, enums.ACCOUNTING_EXPORT_FORMAT_KOINLY)
+	assert.Nil(err)
+	rows, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.Nil(err)
+	assert.Equal([]string{"10.000000", "XTZ", "", ""}, rows[1][1:5])
+	assert.Equal([]string{"", "", "1.000000", "XTZ"}, rows[2][1:5])
+	assert.Equal("reward", rows[2][9])
+	assert.Equal("donation", rows[3][9])
+
+	data, err = MarshalAccountingRecords(records, currencies, enums.ACCOUNTING_EXPORT_FORMAT_COINTRACKING)
+	assert.Nil(err)
+	rows, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.Nil(err)
+	assert.Equal([]string{"Withdrawal", "Staking", "Donation"}, []string{rows[1][0], rows[2][0], rows[3][0]})
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// GetAutoStakeAmount returns configured share of the income limited to the balance above the liquid reserve
+func GetAutoStakeAmount(config *configuration.RuntimeAutoStake, summary *common.CyclePayoutSummary, balance tezos.Z) tezos.Z {
+	amount := summary.BondIncome.Mul64(int64(config.BondsShare * 1000000)).Div64(1000000).
+		Add(summary.FeeIncome.Mul64(int64(config.FeesShare * 1000000)).Div64(1000000))
+	available := balance.Sub(config.LiquidReserve)
+	if available.IsLess(amount) {
+		amount = available
+	}
+	if amount.IsNeg() {
+		return tezos.Zero
+	}
+	return amount
+}
+
+// AutoStakeIncome stakes share of the cycle income from the signer wallet, returns nil summary if there is nothing to stake
+func AutoStakeIncome(config *configuration.RuntimeAutoStake, summary *common.CyclePayoutSummary, collector common.CollectorEngine, signer common.SignerEngine, transactor common.TransactorEngine, confirmations int64) (*common.AutoStakeSummary, error) {
+	source := signer.GetPKH()
+	balance, err := collector.GetBalance(source)
+	if err != nil {
+		return nil, errors.Join(constants.ErrAutoStakeFailed, fmt.Errorf("failed to get balance of %s", source), err)
+	}
+
+	amount := GetAutoStakeAmount(config, summary, balance)
+	if amount.Int64() < constants.AUTO_STAKE_MINIMUM_AMOUNT {
+		slog.Info("nothing to stake", "cycle", summary.Cycle, "source", source.String(), "amount", common.MutezToTezS(amount.Int64()), "balance", common.MutezToTezS(balance.Int64()), "phase", "auto_stake")
+		return nil, nil
+	}
+
+	slog.Info("staking income", "cycle", summary.Cycle, "source", source.String(), "amount", common.MutezToTezS(amount.Int64()), "phase", "auto_stake")
+	op := codec.NewOp().WithSource(source)
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	op.WithStake(amount.Int64())
+
+	opts := rpc.DefaultOptions
+	opts.Confirmations = confirmations
+	opts.Signer = signer.GetSigner()
+	rcpt, err := transactor.Send(op, &opts)
+	if err != nil {
+		return nil, errors.Join(constants.ErrAutoStakeFailed, err)
+	}
+	if !rcpt.IsSuccess() {
+		return nil, errors.Join(constants.ErrAutoStakeFailed, rcpt.Error())
+	}
+	return &common.AutoStakeSummary{
+		Source: source,
+		Amount: amount,
+		OpHash: rcpt.Op.Hash,
+	}, nil
+}
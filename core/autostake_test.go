@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestGetAutoStakeAmount(t *testing.T) {
+	assert := assert.New(t)
+	summary := &common.CyclePayoutSummary{
+		BondIncome: tezos.NewZ(100_000_000),
+		FeeIncome:  tezos.NewZ(20_000_000),
+	}
+	config := &configuration.RuntimeAutoStake{
+		BondsShare:    0.5,
+		FeesShare:     0.25,
+		LiquidReserve: tezos.NewZ(10_000_000),
+	}
+
+	assert.Equal(int64(55_000_000), GetAutoStakeAmount(config, summary, tezos.NewZ(1_000_000_000)).Int64())
+	// limited by liquid reserve
+	assert.Equal(int64(30_000_000), GetAutoStakeAmount(config, summary, tezos.NewZ(40_000_000)).Int64())
+	assert.Equal(int64(0), GetAutoStakeAmount(config, summary, tezos.NewZ(5_000_000)).Int64())
+
+	config.BondsShare, config.FeesShare = 0.123456, 0
+	assert.Equal(int64(12_345_600), GetAutoStakeAmount(config, summary, tezos.NewZ(1_000_000_000)).Int64())
+}
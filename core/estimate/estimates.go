@@ -10,6 +10,7 @@ import (
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/codec"
 	"github.com/trilitech/tzgo/rpc"
@@ -21,6 +22,12 @@ type EstimationContext struct {
 	Collector                            common.CollectorEngine
 	Configuration                        *configuration.RuntimeConfiguration
 	BatchMetadataDeserializationGasLimit int64
+	// FeeStrategy defaults to the strategy from configuration
+	FeeStrategy FeeStrategy
+}
+
+func (ctx *EstimationContext) isMultisig() bool {
+	return ctx.Configuration != nil && ctx.Configuration.PayoutConfiguration.Multisig != nil
 }
 
 func splitIntoBatches[T any](candidates []T, capacity int) [][]T {
@@ -35,15 +42,30 @@ func splitIntoBatches[T any](candidates []T, capacity int) [][]T {
 	return batches
 }
 
-func buildOpForEstimation[T common.TransferArgs](payoutKey tezos.Key, batch []T, injectBurnTransactions bool) (*codec.Op, error) {
+// multisigEstimationTransferArgs keeps the amount minimal because funds are held by the multisig contract
+// and payout wallet is not expected to hold them
+type multisigEstimationTransferArgs struct {
+	common.TransferArgs
+}
+
+func (args multisigEstimationTransferArgs) GetAmount() tezos.Z {
+	return tezos.NewZ(1)
+}
+
+func buildOpForEstimation[T common.TransferArgs](ctx *EstimationContext, batch []T, injectBurnTransactions bool) (*codec.Op, error) {
 	var err error
+	payoutKey := ctx.PayoutKey
 	op := codec.NewOp().WithSource(payoutKey.Address())
 	op.WithTTL(constants.MAX_OPERATION_TTL)
 	if injectBurnTransactions {
 		op.WithTransfer(tezos.BurnAddress, 1)
 	}
 	for _, p := range batch {
-		if err = common.InjectTransferContents(op, payoutKey.Address(), p); err != nil {
+		var args common.TransferArgs = p
+		if ctx.isMultisig() {
+			args = multisigEstimationTransferArgs{p}
+		}
+		if err = common.InjectTransferContents(op, payoutKey.Address(), args); err != nil {
 			break
 		}
 	}
@@ -53,17 +75,30 @@ func buildOpForEstimation[T common.TransferArgs](payoutKey tezos.Key, batch []T,
 	return op, err
 }
 
+// estimateMultisigTransferFee estimates fee of the payout executed by the multisig call. The payout does not pay
+// base fee and size of its own operation but size of its transfer in the call lambda. Fee of the call itself is paid by the payout wallet.
+func estimateMultisigTransferFee(ctx *EstimationContext, p common.TransferArgs, gasUsed int64) (int64, error) {
+	size, err := common.GetMultisigTransferSize(ctx.Configuration.PayoutConfiguration.Multisig.Contract, p)
+	if err != nil {
+		return 0, err
+	}
+	fee := size*constants.PROTOCOL_MINIMAL_NANOTEZ_PER_BYTE + gasUsed*constants.PROTOCOL_MINIMAL_NANOTEZ_PER_GAS
+	return (fee + 999) / 1000, nil // nano -> micro, round up
+}
+
 func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext) ([]*common.OpLimits, error) {
 	var (
 		err     error
 		receipt *rpc.Receipt
 	)
-	op, err := buildOpForEstimation(ctx.PayoutKey, batch, true)
+	op, err := buildOpForEstimation(ctx, batch, true)
 
 	if err != nil {
 		return nil, err
 	}
+	observeSimulation := metrics.ObserveDuration(metrics.SimulationDuration)
 	receipt, err = ctx.Collector.Simulate(op, ctx.PayoutKey)
+	observeSimulation()
 	if err != nil || (receipt != nil && !receipt.IsSuccess()) {
 		if receipt != nil && receipt.Error() != nil && (err == nil || receipt.Error().Error() != err.Error()) {
 			return nil, errors.Join(receipt.Error(), err)
@@ -100,7 +135,7 @@ func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext)
 			return nil, err
 		}
 		// rebuild op for estimates
-		op, err := buildOpForEstimation(ctx.PayoutKey, []T{batch[i]}, false)
+		op, err := buildOpForEstimation(ctx, []T{batch[i]}, false)
 		if err != nil {
 			return nil, err
 		}
@@ -122,10 +157,17 @@ func estimateBatchFees[T common.TransferArgs](batch []T, ctx *EstimationContext)
 			ctx.BatchMetadataDeserializationGasLimit + // potential gas used for deserialization if only one tx in batch
 			ctx.Configuration.PayoutConfiguration.TxDeserializationGasBuffer // buffer for deserialization gas limit
 
+		minimalFee := utils.EstimateTransactionFee(op, []int64{totalTxGasUsed}, 0)
+		if ctx.isMultisig() {
+			if minimalFee, err = estimateMultisigTransferFee(ctx, batch[i], totalTxGasUsed); err != nil {
+				return nil, err
+			}
+		}
+
 		result = append(result, &common.OpLimits{
 			GasLimit:                p.GasUsed + ctx.Configuration.PayoutConfiguration.TxGasLimitBuffer,
 			StorageLimit:            utils.CalculateStorageLimit(p),
-			TransactionFee:          utils.EstimateTransactionFee(op, []int64{totalTxGasUsed}, feeBuffer),
+			TransactionFee:          ctx.FeeStrategy.GetFee(minimalFee, totalTxGasUsed) + feeBuffer,
 			DeserializationGasLimit: txSerializationGas + ctx.Configuration.PayoutConfiguration.TxDeserializationGasBuffer,
 			AllocationBurn:          p.AllocationBurn,
 			StorageBurn:             p.StorageBurn,
@@ -142,6 +184,10 @@ type EstimateResult[T common.TransferArgs] struct {
 }
 
 func EstimateTransactionFees[T common.TransferArgs](transactions []T, ctx *EstimationContext) []EstimateResult[T] {
+	if ctx.FeeStrategy == nil {
+		ctx.FeeStrategy = NewFeeStrategy(&ctx.Configuration.PayoutConfiguration.FeeStrategy, ctx.Collector)
+	}
+
 	standardTxs := make([]T, 0, len(transactions))
 	faTxs := make([]T, 0, len(transactions))
 	otherTxs := make([]T, 0, len(transactions))
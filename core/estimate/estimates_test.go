@@ -0,0 +1,53 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestEstimateMultisigTransactionFees(t *testing.T) {
+	assert := assert.New(t)
+
+	payoutKey, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	recipe := &common.PayoutRecipe{
+		Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+		TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+		Delegator: mock.GetRandomAddress(),
+		Recipient: mock.GetRandomAddress(),
+		Amount:    tezos.NewZ(1_000_000),
+	}
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	ctx := &EstimationContext{
+		PayoutKey:     payoutKey.Public(),
+		Collector:     mock.InitSimpleColletor(),
+		Configuration: &config,
+	}
+	direct := EstimateTransactionFees([]*common.PayoutRecipe{recipe}, ctx)
+	assert.Len(direct, 1)
+	assert.Nil(direct[0].Error)
+
+	multisigConfig := configuration.GetDefaultRuntimeConfiguration()
+	multisigConfig.PayoutConfiguration.Multisig = &configuration.RuntimeMultisigWallet{
+		Contract: tezos.MustParseAddress("KT1VqarPDicMFn1ejmQqqshUkUXTCTXwmkCN"),
+	}
+	ctx = &EstimationContext{
+		PayoutKey:     payoutKey.Public(),
+		Collector:     mock.InitSimpleColletor(),
+		Configuration: &multisigConfig,
+	}
+	multisig := EstimateTransactionFees([]*common.PayoutRecipe{recipe}, ctx)
+	assert.Len(multisig, 1)
+	assert.Nil(multisig[0].Error)
+
+	t.Log("payout in multisig call does not pay base fee of its own operation")
+	assert.Equal(direct[0].Result.GasLimit, multisig[0].Result.GasLimit)
+	assert.Less(multisig[0].Result.TransactionFee, direct[0].Result.TransactionFee)
+	assert.Less(direct[0].Result.TransactionFee-multisig[0].Result.TransactionFee, int64(200))
+}
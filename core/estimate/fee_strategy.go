@@ -0,0 +1,87 @@
+package estimate
+
+import (
+	"log/slog"
+	"math"
+	"sync"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+// FeeStrategy determines fee of a transaction based on the minimal fee required by the protocol
+type FeeStrategy interface {
+	GetFee(minimalFee int64, gasLimit int64) int64
+}
+
+type MinimalFeeStrategy struct{}
+
+func (strategy *MinimalFeeStrategy) GetFee(minimalFee int64, gasLimit int64) int64 {
+	return minimalFee
+}
+
+type MultiplierFeeStrategy struct {
+	Multiplier float64
+}
+
+func (strategy *MultiplierFeeStrategy) GetFee(minimalFee int64, gasLimit int64) int64 {
+	return int64(math.Ceil(float64(minimalFee) * max(strategy.Multiplier, 1)))
+}
+
+// MempoolFeeStrategy matches fee levels required by the node mempool filter and fees paid by pending operations
+type MempoolFeeStrategy struct {
+	Percentile        float64
+	MaximumMultiplier float64
+
+	collector common.CollectorEngine
+	levels    *common.MempoolFeeLevels
+	once      sync.Once
+}
+
+func (strategy *MempoolFeeStrategy) getLevels() *common.MempoolFeeLevels {
+	strategy.once.Do(func() {
+		levels, err := strategy.collector.GetMempoolFeeLevels()
+		if err != nil {
+			slog.Warn("failed to read mempool fee levels, falling back to minimal fees", "error", err.Error())
+			return
+		}
+		strategy.levels = levels
+	})
+	return strategy.levels
+}
+
+func (strategy *MempoolFeeStrategy) GetFee(minimalFee int64, gasLimit int64) int64 {
+	levels := strategy.getLevels()
+	if levels == nil || gasLimit <= 0 {
+		return minimalFee
+	}
+
+	// node may require more than protocol minimum
+	filterMultiplier := max(1,
+		float64(levels.MinimalFees)/float64(constants.PROTOCOL_MINIMAL_FEE_MUTEZ),
+		levels.MinimalNanotezPerGasUnit/float64(constants.PROTOCOL_MINIMAL_NANOTEZ_PER_GAS),
+		levels.MinimalNanotezPerByte/1000,
+	)
+	fee := float64(minimalFee) * filterMultiplier
+	// pending operation fee rates are total fee per gas unit so we compare them with the same rate of ours
+	fee = max(fee, levels.GetPendingFeeRate(strategy.Percentile)*float64(gasLimit)/1000)
+	fee = min(fee, float64(minimalFee)*max(strategy.MaximumMultiplier, 1))
+	return max(minimalFee, int64(math.Ceil(fee)))
+}
+
+func NewFeeStrategy(config *configuration.RuntimeFeeStrategy, collector common.CollectorEngine) FeeStrategy {
+	switch config.Kind {
+	case enums.FEE_STRATEGY_MULTIPLIER:
+		return &MultiplierFeeStrategy{Multiplier: config.Multiplier}
+	case enums.FEE_STRATEGY_MEMPOOL:
+		return &MempoolFeeStrategy{
+			Percentile:        config.MempoolPercentile,
+			MaximumMultiplier: config.MaximumMultiplier,
+			collector:         collector,
+		}
+	default:
+		return &MinimalFeeStrategy{}
+	}
+}
@@ -0,0 +1,48 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+)
+
+func TestFeeStrategies(t *testing.T) {
+	assert := assert.New(t)
+	collector := mock.InitSimpleColletor()
+
+	config := configuration.GetDefaultRuntimeConfiguration().PayoutConfiguration.FeeStrategy
+	assert.Equal(int64(400), NewFeeStrategy(&config, collector).GetFee(400, 2000))
+
+	config.Kind = enums.FEE_STRATEGY_MULTIPLIER
+	config.Multiplier = 1.5
+	assert.Equal(int64(600), NewFeeStrategy(&config, collector).GetFee(400, 2000))
+
+	config.Kind = enums.FEE_STRATEGY_MEMPOOL
+	config.MempoolPercentile = 50
+	config.MaximumMultiplier = 3
+	// idle mempool
+	assert.Equal(int64(400), NewFeeStrategy(&config, collector).GetFee(400, 2000))
+
+	// congested mempool, median pending operation pays 500 nanotez per gas unit
+	collector.SetOpts(&mock.SimpleCollectorOpts{MempoolFeeLevels: &common.MempoolFeeLevels{
+		MinimalFees:              100,
+		MinimalNanotezPerGasUnit: 100,
+		MinimalNanotezPerByte:    1000,
+		PendingFeeRates:          []float64{200, 500, 900, 300, 700},
+	}})
+	assert.Equal(int64(1000), NewFeeStrategy(&config, collector).GetFee(400, 2000))
+	// capped by maximum multiplier
+	assert.Equal(int64(1200), NewFeeStrategy(&config, collector).GetFee(400, 10000))
+
+	// node filter requires double of protocol minimum
+	collector.SetOpts(&mock.SimpleCollectorOpts{MempoolFeeLevels: &common.MempoolFeeLevels{
+		MinimalFees:              100,
+		MinimalNanotezPerGasUnit: 200,
+		MinimalNanotezPerByte:    1000,
+	}})
+	assert.Equal(int64(800), NewFeeStrategy(&config, collector).GetFee(400, 2000))
+}
@@ -1,6 +1,10 @@
 package core
 
 import (
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants"
@@ -29,3 +33,112 @@ func ExecutePayouts(preparationResult *common.PreparePayoutsResult, config *conf
 		PaidDelegators: ctx.StageData.PaidDelegators,
 	}, nil
 }
+
+func ForgePayoutsForOfflineSigning(preparationResult *common.PreparePayoutsResult, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.OfflineSigningBundle, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+
+	ctx, err := execute.NewPayoutExecutionContext(preparationResult, config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.SplitIntoBatches,
+		execute.ForgeUnsignedBatches).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := lo.Uniq(lo.Map(preparationResult.ValidPayouts, func(p common.PayoutRecipe, _ int) int64 { return p.Cycle }))
+	return &common.OfflineSigningBundle{
+		Version:           constants.OFFLINE_SIGNING_BUNDLE_VERSION,
+		CreatedAt:         time.Now(),
+		Source:            engineContext.GetSigner().GetPKH(),
+		PublicKey:         engineContext.GetSigner().GetKey(),
+		TTL:               constants.OFFLINE_SIGNING_OPERATION_TTL,
+		Cycles:            cycles,
+		PreparationResult: preparationResult,
+		Batches:           ctx.StageData.OfflineSigningBatches,
+	}, nil
+}
+
+func ExecuteOfflineSignedPayouts(bundle *common.OfflineSigningBundle, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.ExecutePayoutsResult, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+
+	ctx, err := execute.NewPayoutExecutionContextFromOfflineSigningBundle(bundle, config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.ExecutePayouts).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ExecutePayoutsResult{
+		BatchResults:   ctx.StageData.BatchResults,
+		PaidDelegators: ctx.StageData.PaidDelegators,
+	}, nil
+}
+
+func ProposeMultisigPayouts(preparationResult *common.PreparePayoutsResult, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.MultisigProposalBundle, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+	if config.PayoutConfiguration.Multisig == nil {
+		return nil, errors.Join(constants.ErrMultisigProposalCreationFailed, errors.New("multisig is not configured"))
+	}
+
+	ctx, err := execute.NewPayoutExecutionContext(preparationResult, config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.SplitIntoBatches,
+		execute.CreateMultisigProposals).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := lo.Uniq(lo.Map(preparationResult.ValidPayouts, func(p common.PayoutRecipe, _ int) int64 { return p.Cycle }))
+	bundle := &common.MultisigProposalBundle{
+		Version:           constants.MULTISIG_PROPOSAL_BUNDLE_VERSION,
+		CreatedAt:         time.Now(),
+		Contract:          config.PayoutConfiguration.Multisig.Contract,
+		Cycles:            cycles,
+		PreparationResult: preparationResult,
+		Proposals:         ctx.StageData.MultisigProposals,
+	}
+	if len(bundle.Proposals) > 0 {
+		bundle.ChainId = bundle.Proposals[0].ChainId
+	}
+	return bundle, nil
+}
+
+func ExecuteMultisigProposals(bundle *common.MultisigProposalBundle, config *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*common.ExecutePayoutsResult, error) {
+	if config == nil {
+		return nil, constants.ErrMissingConfiguration
+	}
+
+	ctx, err := execute.NewPayoutExecutionContextFromMultisigProposalBundle(bundle, config, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = WrapContext[*execute.PayoutExecutionContext, *common.ExecutePayoutsOptions](ctx).ExecuteStages(options,
+		execute.ExecutePayouts).Unwrap()
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ExecutePayoutsResult{
+		BatchResults:   ctx.StageData.BatchResults,
+		PaidDelegators: ctx.StageData.PaidDelegators,
+	}, nil
+}
@@ -9,22 +9,28 @@ import (
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/tez-capital/tezpay/state"
 	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/rpc"
 	"github.com/trilitech/tzgo/tezos"
 )
 
-func druRunExecutePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, batch common.RecipeBatch) *common.BatchResult {
+func druRunExecutePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, index int, batch common.RecipeBatch) *common.BatchResult {
 	logger = logger.With("batch_id", batchId)
 	if state.Global.GetWantsOutputJson() {
 		logger.Info("creating batch", "recipes", batch, "phase", "executing_batch")
 	} else {
 		logger.Info("creating batch", "tx_count", len(batch), "phase", "executing_batch")
 	}
-	opExecCtx, err := batch.ToOpExecutionContext(ctx.GetSigner(), ctx.GetTransactor())
+	opExecCtx, err := ctx.getOpExecutionContext(index, batch)
 	if err != nil {
 		logger.Warn("failed to create operation execution context", "id", batchId, "error", err.Error(), "phase", "batch_execution_finished")
-		return common.NewFailedBatchResultWithOpHash(batch, opExecCtx.GetOpHash(), errors.Join(constants.ErrOperationContextCreationFailed, err))
+		opHash := tezos.ZeroOpHash
+		if opExecCtx != nil {
+			opHash = opExecCtx.GetOpHash()
+		}
+		return common.NewFailedBatchResultWithOpHash(batch, opHash, errors.Join(constants.ErrOperationContextCreationFailed, err))
 	}
 	logger.Info("broadcasting batch")
 	time.Sleep(2 * time.Second)
@@ -34,14 +40,42 @@ func druRunExecutePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger,
 	return common.NewSuccessBatchResult(batch, tezos.ZeroOpHash)
 }
 
-func executePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, batch common.RecipeBatch) *common.BatchResult {
+func getRequiredConfirmations(options *common.ExecutePayoutsOptions) int64 {
+	if options.Confirmations > 0 {
+		return options.Confirmations
+	}
+	return constants.DEFAULT_REQUIRED_CONFIRMATIONS
+}
+
+func executePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchId string, index int, batch common.RecipeBatch, options *common.ExecutePayoutsOptions) *common.BatchResult {
 	logger = logger.With("batch_id", batchId)
 	if state.Global.GetWantsOutputJson() {
 		logger.Info("creating batch", "recipes", batch, "phase", "executing_batch")
 	} else {
 		logger.Info("creating batch", "tx_count", len(batch), "phase", "executing_batch")
 	}
-	opExecCtx, err := batch.ToOpExecutionContext(ctx.GetSigner(), ctx.GetTransactor())
+
+	var result *common.BatchResult
+	for attempt := 0; attempt <= constants.MAX_REORG_RETRIES; attempt++ {
+		if attempt > 0 {
+			logger.Warn("operation was dropped by reorganization, retrying batch", "attempt", attempt, "phase", "batch_retry")
+		}
+		result = executePayoutBatchAttempt(ctx, logger, index, batch, options)
+		if result.IsSuccess || !errors.Is(result.Err, constants.ErrOperationDroppedByReorg) {
+			break
+		}
+		ctx.releaseSigningPolicySpend(index, batch)
+	}
+	if result.IsSuccess {
+		metrics.Batches.Inc(metrics.BATCH_RESULT_SUCCESS)
+	} else {
+		metrics.Batches.Inc(metrics.BATCH_RESULT_FAILURE)
+	}
+	return result
+}
+
+func executePayoutBatchAttempt(ctx *PayoutExecutionContext, logger *slog.Logger, index int, batch common.RecipeBatch, options *common.ExecutePayoutsOptions) *common.BatchResult {
+	opExecCtx, err := ctx.getOpExecutionContext(index, batch)
 	if err != nil {
 		logger.Warn("failed to create operation execution context", "error", err.Error(), "phase", "batch_execution_finished")
 		opHash := tezos.ZeroOpHash
@@ -52,7 +86,11 @@ func executePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchI
 	}
 
 	logger.Info("broadcasting batch")
-	err = opExecCtx.Dispatch(nil)
+	dispatchOptions := rpc.NewCallOptions()
+	dispatchOptions.Confirmations = 1 // inclusion, confirmations are tracked by WaitForFinality
+	observeDispatch := metrics.ObserveDuration(metrics.DispatchDuration)
+	err = opExecCtx.Dispatch(dispatchOptions)
+	observeDispatch()
 	if err != nil {
 		logger.Warn("failed to broadcast batch", "error", err.Error(), "phase", "batch_execution_finished")
 		return common.NewFailedBatchResultWithOpHash(batch, opExecCtx.GetOpHash(), errors.Join(constants.ErrOperationBroadcastFailed, err))
@@ -61,14 +99,27 @@ func executePayoutBatch(ctx *PayoutExecutionContext, logger *slog.Logger, batchI
 	logger.Info("waiting for confirmation", "op_reference", utils.GetOpReference(opExecCtx.GetOpHash(), ctx.GetConfiguration().Network.Explorer), "op_hash", opExecCtx.GetOpHash(), "phase", "batch_waiting_for_confirmation")
 	ctx.protectedSection.Pause() // pause protected section to allow confirmation canceling
 	err = opExecCtx.WaitForApply()
+	if err == nil {
+		err = opExecCtx.WaitForFinality(getRequiredConfirmations(options), func(state common.OperationConfirmationState, level int64) {
+			logger.Info("batch confirmation state changed", "state", state, "level", level, "op_hash", opExecCtx.GetOpHash(), "phase", "batch_confirmation_state")
+		})
+	}
 	ctx.protectedSection.Resume() // resume protected section
+	if errors.Is(err, constants.ErrOperationDroppedByReorg) {
+		logger.Warn("batch was dropped by reorganization", "error", err.Error(), "phase", "batch_execution_finished")
+		result := common.NewFailedBatchResultWithOpHash(batch, opExecCtx.GetOpHash(), errors.Join(constants.ErrOperationConfirmationFailed, err))
+		result.ConfirmationState = common.OPERATION_CONFIRMATION_STATE_RETRY
+		return result
+	}
 	if err != nil {
 		logger.Warn("failed to apply batch", "error", err.Error(), "phase", "batch_execution_finished")
 		return common.NewFailedBatchResultWithOpHash(batch, opExecCtx.GetOpHash(), errors.Join(constants.ErrOperationConfirmationFailed, err))
 	}
 
 	logger.Info("batch successful", "phase", "batch_execution_finished")
-	return common.NewSuccessBatchResult(batch, opExecCtx.GetOpHash())
+	result := common.NewSuccessBatchResult(batch, opExecCtx.GetOpHash())
+	result.ConfirmationState = common.OPERATION_CONFIRMATION_STATE_FINALIZED
+	return result
 }
 
 func executePayouts(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) *PayoutExecutionContext {
@@ -92,9 +143,9 @@ func executePayouts(ctx *PayoutExecutionContext, options *common.ExecutePayoutsO
 
 		batchId := fmt.Sprintf("%d/%d", i+1, batchCount)
 		if options.DryRun {
-			batchesResults = append(batchesResults, *druRunExecutePayoutBatch(ctx, logger, batchId, batch))
+			batchesResults = append(batchesResults, *druRunExecutePayoutBatch(ctx, logger, batchId, i, batch))
 		} else {
-			batchesResults = append(batchesResults, *executePayoutBatch(ctx, logger, batchId, batch))
+			batchesResults = append(batchesResults, *executePayoutBatch(ctx, logger, batchId, i, batch, options))
 		}
 	}
 
@@ -131,7 +182,12 @@ func executePayouts(ctx *PayoutExecutionContext, options *common.ExecutePayoutsO
 		failureDetected = true
 	}
 	for _, blueprint := range ctx.PayoutBlueprints {
-		if err := reporter.ReportCycleSummary(blueprint.Summary); err != nil {
+		summary := blueprint.Summary
+		// keep stake recorded by earlier run of the cycle
+		if existing, err := reporter.GetExistingCycleSummary(summary.Cycle); err == nil && existing.AutoStake != nil && summary.AutoStake == nil {
+			summary.AutoStake = existing.AutoStake
+		}
+		if err := reporter.ReportCycleSummary(summary); err != nil {
 			logger.Warn("failed to report cycle summary", "error", err.Error())
 			failureDetected = true
 		}
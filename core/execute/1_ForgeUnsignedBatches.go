@@ -0,0 +1,41 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// ForgeUnsignedBatches forges batches for offline signing. Counters are assigned sequentially
+// across batches because none of them is applied before all of them are signed.
+func ForgeUnsignedBatches(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	logger := ctx.logger.With("phase", "forge_unsigned_batches")
+	signer := ctx.GetSigner()
+	batchCount := len(ctx.StageData.Batches)
+	logger.Info("forging unsigned batches", "batches_count", batchCount)
+
+	counter := int64(0)
+	offlineBatches := make([]common.OfflineSigningBatch, 0, batchCount)
+	for i, batch := range ctx.StageData.Batches {
+		batchId := fmt.Sprintf("%d/%d", i+1, batchCount)
+		op, err := batch.ToUnsignedOp(signer.GetPKH(), signer.GetKey(), ctx.GetTransactor(), constants.OFFLINE_SIGNING_OPERATION_TTL, counter)
+		if err != nil {
+			return nil, errors.Join(constants.ErrOperationContextCreationFailed, fmt.Errorf("batch %s", batchId), err)
+		}
+		if err := ctx.signingPolicy.Check(batch, op); err != nil {
+			return nil, err
+		}
+		if op.Contents[0].Kind() == tezos.OpTypeReveal {
+			return nil, errors.Join(constants.ErrNotRevealed, fmt.Errorf("payout wallet %s has to be revealed before offline signing", signer.GetPKH()))
+		}
+		counter = op.Contents[len(op.Contents)-1].GetCounter() + 1
+		logger.Debug("forged batch", "batch_id", batchId, "branch", op.Branch, "tx_count", len(batch))
+		offlineBatches = append(offlineBatches, common.NewOfflineSigningBatch(batchId, batch, op))
+	}
+
+	ctx.StageData.OfflineSigningBatches = offlineBatches
+	return ctx, nil
+}
@@ -1,11 +1,16 @@
 package execute
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
 )
 
 type StageData struct {
@@ -14,6 +19,13 @@ type StageData struct {
 	Batches                       []common.RecipeBatch
 	BatchResults                  common.BatchResults
 	PaidDelegators                int
+
+	// signed operations matching Batches, available when executing offline signed payouts
+	SignedOps             []*codec.Op
+	OfflineSigningBatches []common.OfflineSigningBatch
+
+	// multisig proposals matching Batches, available when payouts are paid from multisig
+	MultisigProposals []common.MultisigProposal
 }
 
 type PayoutExecutionContext struct {
@@ -21,6 +33,7 @@ type PayoutExecutionContext struct {
 	configuration *configuration.RuntimeConfiguration
 
 	protectedSection *utils.ProtectedSection
+	signingPolicy    *common.SigningPolicy
 	StageData        *StageData
 
 	ValidPayouts       []common.PayoutRecipe
@@ -35,16 +48,41 @@ func (ctx *PayoutExecutionContext) GetConfiguration() *configuration.RuntimeConf
 	return ctx.configuration
 }
 
+func (ctx *PayoutExecutionContext) getOpExecutionContext(index int, batch common.RecipeBatch) (*common.OpExecutionContext, error) {
+	if index < len(ctx.StageData.SignedOps) {
+		return common.InitOpExecutionContext(ctx.StageData.SignedOps[index], ctx.GetTransactor()), nil
+	}
+	if ctx.configuration.PayoutConfiguration.Multisig != nil {
+		return ctx.getMultisigOpExecutionContext(index, batch)
+	}
+	return batch.ToOpExecutionContext(ctx.GetSigner(), ctx.GetTransactor(), ctx.signingPolicy)
+}
+
+// releaseSigningPolicySpend returns spend of dropped batch to the signing policy, presigned operations are not accounted
+func (ctx *PayoutExecutionContext) releaseSigningPolicySpend(index int, batch common.RecipeBatch) {
+	if index < len(ctx.StageData.SignedOps) {
+		return
+	}
+	ctx.signingPolicy.Release(batch)
+}
+
 func NewPayoutExecutionContext(preparationResult *common.PreparePayoutsResult, configuration *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
 	if err := engineContext.Validate(); err != nil {
 		return nil, err
 	}
 
+	cycles := lo.Map(preparationResult.Blueprints, func(blueprint *common.CyclePayoutBlueprint, _ int) int64 { return blueprint.Cycle })
+	signingPolicy, err := NewSigningPolicy(configuration, engineContext.GetCollector(), cycles, preparationResult.ReportsOfPastSuccesfulPayouts, engineContext.AdminNotify)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PayoutExecutionContext{
 		ExecutePayoutsEngineContext: *engineContext,
 		configuration:               configuration,
 
 		protectedSection: utils.NewProtectedSection("executing payouts, job will be terminated after next batch"),
+		signingPolicy:    signingPolicy,
 		StageData: &StageData{
 			ReportsOfPastSuccesfulPayouts: preparationResult.ReportsOfPastSuccesfulPayouts,
 		},
@@ -57,3 +95,54 @@ func NewPayoutExecutionContext(preparationResult *common.PreparePayoutsResult, c
 		logger: slog.Default().With("stage", "execute"),
 	}, nil
 }
+
+func NewPayoutExecutionContextFromOfflineSigningBundle(bundle *common.OfflineSigningBundle, configuration *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	if err := bundle.Verify(); err != nil {
+		return nil, err
+	}
+
+	ctx, err := NewPayoutExecutionContext(bundle.PreparationResult, configuration, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+	if !ctx.GetSigner().GetPKH().Equal(bundle.Source) {
+		return nil, errors.Join(constants.ErrOfflineBundleSourceMismatch, fmt.Errorf("bundle source %s, payout wallet %s", bundle.Source, ctx.GetSigner().GetPKH()))
+	}
+
+	ctx.StageData.Batches = make([]common.RecipeBatch, 0, len(bundle.Batches))
+	ctx.StageData.SignedOps = make([]*codec.Op, 0, len(bundle.Batches))
+	for _, batch := range bundle.Batches {
+		op, err := batch.GetSignedOp(bundle.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		ctx.StageData.Batches = append(ctx.StageData.Batches, batch.Payouts)
+		ctx.StageData.SignedOps = append(ctx.StageData.SignedOps, op)
+	}
+	ctx.StageData.OfflineSigningBatches = bundle.Batches
+	return ctx, nil
+}
+
+func NewPayoutExecutionContextFromMultisigProposalBundle(bundle *common.MultisigProposalBundle, configuration *configuration.RuntimeConfiguration, engineContext *common.ExecutePayoutsEngineContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	if configuration.PayoutConfiguration.Multisig == nil || !configuration.PayoutConfiguration.Multisig.Contract.Equal(bundle.Contract) {
+		return nil, errors.Join(constants.ErrMultisigBundleContractMismatch, fmt.Errorf("bundle contract %s", bundle.Contract))
+	}
+
+	ctx, err := NewPayoutExecutionContext(bundle.PreparationResult, configuration, engineContext, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.StageData.Batches = make([]common.RecipeBatch, 0, len(bundle.Proposals))
+	for _, proposal := range bundle.Proposals {
+		ctx.StageData.Batches = append(ctx.StageData.Batches, proposal.Payouts)
+	}
+	ctx.StageData.MultisigProposals = bundle.Proposals
+	return ctx, nil
+}
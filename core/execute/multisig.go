@@ -0,0 +1,136 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+)
+
+func (ctx *PayoutExecutionContext) getMultisigStorage() (*common.MultisigStorage, error) {
+	contract := ctx.configuration.PayoutConfiguration.Multisig.Contract
+	storage, err := ctx.GetTransactor().GetContractStorage(contract)
+	if err != nil {
+		return nil, errors.Join(constants.ErrMultisigStorageLoadFailed, fmt.Errorf("contract %s", contract), err)
+	}
+	return common.ParseMultisigStorage(storage)
+}
+
+// collectMultisigApprovals signs the proposal with configured signers until threshold is reached.
+// Signers without access to private key (public:) are expected to approve through proposal bundle.
+func (ctx *PayoutExecutionContext) collectMultisigApprovals(proposal *common.MultisigProposal, storage *common.MultisigStorage) error {
+	for _, signer := range ctx.GetMultisigSigners() {
+		count, err := proposal.CountValidSignatures(storage)
+		if err != nil {
+			return err
+		}
+		if count >= storage.Threshold {
+			return nil
+		}
+		if proposal.IsSignedBy(signer.GetKey()) {
+			continue
+		}
+		if !storage.IsAuthorized(signer.GetKey()) {
+			ctx.logger.Warn("multisig signer is not one of the contract keys, skipping", "signer", signer.GetPKH(), "error", constants.ErrMultisigSignerNotAuthorized.Error())
+			continue
+		}
+		if _, ok := signer.(common.PayloadSignerEngine); !ok {
+			continue
+		}
+		if err := proposal.Sign(signer); err != nil {
+			ctx.logger.Warn("failed to collect multisig approval", "signer", signer.GetPKH(), "proposal", proposal.Id, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+func (ctx *PayoutExecutionContext) getMultisigProposal(index int, batch common.RecipeBatch, storage *common.MultisigStorage) (*common.MultisigProposal, error) {
+	if index < len(ctx.StageData.MultisigProposals) {
+		return &ctx.StageData.MultisigProposals[index], nil
+	}
+	chainId, err := ctx.GetTransactor().GetChainId()
+	if err != nil {
+		return nil, errors.Join(constants.ErrMultisigProposalCreationFailed, err)
+	}
+	return common.NewMultisigProposal(fmt.Sprintf("%d", index+1), ctx.configuration.PayoutConfiguration.Multisig.Contract, chainId, storage.Counter, batch), nil
+}
+
+// getMultisigOpExecutionContext wraps the batch into approved multisig call submitted by the payout wallet
+func (ctx *PayoutExecutionContext) getMultisigOpExecutionContext(index int, batch common.RecipeBatch) (*common.OpExecutionContext, error) {
+	storage, err := ctx.getMultisigStorage()
+	if err != nil {
+		return nil, err
+	}
+	proposal, err := ctx.getMultisigProposal(index, batch, storage)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.signingPolicy.Check(proposal.Payouts, proposal.ToTransferOp(0)); err != nil {
+		return nil, err
+	}
+	if err := ctx.collectMultisigApprovals(proposal, storage); err != nil {
+		return nil, err
+	}
+	parameters, err := proposal.GetParameters(storage)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := ctx.GetSigner()
+	transactor := ctx.GetTransactor()
+	op := codec.NewOp().WithSource(signer.GetPKH())
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	op.WithCall(proposal.Contract, *parameters)
+	receipt, err := transactor.Simulate(op, signer.GetKey())
+	if err != nil || (receipt != nil && !receipt.IsSuccess()) {
+		if receipt != nil && receipt.Error() != nil {
+			return nil, errors.Join(receipt.Error(), err)
+		}
+		return nil, err
+	}
+	op.WithLimits(receipt.MinLimits(), ctx.configuration.PayoutConfiguration.TxGasLimitBuffer)
+	fee := int64(0)
+	for _, content := range op.Contents {
+		fee += content.Limits().Fee
+	}
+	if err := ctx.signingPolicy.CheckFee(fee); err != nil {
+		return nil, err
+	}
+
+	if err := signer.Sign(op); err != nil {
+		return nil, err
+	}
+	return common.InitOpExecutionContext(op, transactor), nil
+}
+
+// CreateMultisigProposals wraps batches into multisig proposals with sequential counters
+// and collects approvals of the signers available online
+func CreateMultisigProposals(ctx *PayoutExecutionContext, options *common.ExecutePayoutsOptions) (*PayoutExecutionContext, error) {
+	logger := ctx.logger.With("phase", "create_multisig_proposals")
+	logger.Info("creating multisig proposals")
+
+	storage, err := ctx.getMultisigStorage()
+	if err != nil {
+		return nil, err
+	}
+	chainId, err := ctx.GetTransactor().GetChainId()
+	if err != nil {
+		return nil, errors.Join(constants.ErrMultisigProposalCreationFailed, err)
+	}
+
+	proposals := make([]common.MultisigProposal, 0, len(ctx.StageData.Batches))
+	for i, batch := range ctx.StageData.Batches {
+		proposal := common.NewMultisigProposal(fmt.Sprintf("%d/%d", i+1, len(ctx.StageData.Batches)), ctx.configuration.PayoutConfiguration.Multisig.Contract, chainId, storage.Counter+int64(i), batch)
+		if err := ctx.signingPolicy.Check(proposal.Payouts, proposal.ToTransferOp(0)); err != nil {
+			return nil, err
+		}
+		if err := ctx.collectMultisigApprovals(proposal, storage); err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, *proposal)
+	}
+	ctx.StageData.MultisigProposals = proposals
+	return ctx, nil
+}
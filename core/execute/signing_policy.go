@@ -0,0 +1,62 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// NewSigningPolicy creates signing policy from configuration. If restricted, only delegators of the cycles
+// fetched through collector, their override recipients and income recipients are allowed. Allowed recipients
+// are never taken from payouts being checked, those are produced by the pipeline the policy guards.
+func NewSigningPolicy(config *configuration.RuntimeConfiguration, collector common.CollectorEngine, cycles []int64, pastReports []common.PayoutReport, adminNotify func(msg string)) (*common.SigningPolicy, error) {
+	policyConfiguration := config.SigningPolicy
+	policy := common.NewSigningPolicy(common.SigningPolicyLimits{
+		MaximumTotalPerCycle:   policyConfiguration.MaximumTotalPerCycle.Int64(),
+		MaximumPerRecipient:    policyConfiguration.MaximumPerRecipient.Int64(),
+		MaximumFeePerOperation: policyConfiguration.MaximumFeePerOperation.Int64(),
+	}, adminNotify)
+	policy.RegisterPastPayouts(pastReports)
+
+	if !policyConfiguration.RestrictRecipients {
+		return policy, nil
+	}
+
+	policy.AllowRecipients(config.BakerPKH)
+	if collector == nil {
+		return nil, errors.Join(constants.ErrSigningPolicyLoadFailed, errors.New("collector is required to restrict recipients"))
+	}
+	for _, cycle := range cycles {
+		cycleData, err := collector.GetCycleStakingData(config.BakerPKH, cycle)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSigningPolicyLoadFailed, fmt.Errorf("failed to get delegators of cycle %d", cycle), err)
+		}
+		for _, delegator := range cycleData.Delegators {
+			policy.AllowRecipients(delegator.Address)
+		}
+	}
+	for delegator, override := range config.Delegators.Overrides {
+		delegatorAddress, err := tezos.ParseAddress(delegator)
+		if err != nil || !override.Recipient.IsValid() {
+			continue
+		}
+		policy.AllowDelegatorRecipient(delegatorAddress, override.Recipient)
+	}
+	incomeRecipients := config.IncomeRecipients
+	for _, recipients := range []map[string]float64{incomeRecipients.Bonds, incomeRecipients.Fees, incomeRecipients.StakingEdge, incomeRecipients.Donations} {
+		for recipient := range recipients {
+			if address, err := tezos.ParseAddress(recipient); err == nil {
+				policy.AllowRecipients(address)
+			}
+		}
+	}
+	// donations without configured destination go to the default one
+	if len(incomeRecipients.Donations) == 0 && incomeRecipients.DonateBonds+incomeRecipients.DonateFees+incomeRecipients.DonateStakingEdge > 0 {
+		policy.AllowRecipients(tezos.MustParseAddress(constants.DEFAULT_DONATION_ADDRESS))
+	}
+	return policy, nil
+}
@@ -0,0 +1,48 @@
+package execute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	tezpay_configuration "github.com/tez-capital/tezpay/configuration/v"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type delegatorsCollector struct {
+	*mock.SimpleColletor
+	delegators []common.Delegator
+}
+
+func (collector *delegatorsCollector) GetCycleStakingData(baker tezos.Address, cycle int64) (*common.BakersCycleData, error) {
+	return &common.BakersCycleData{Delegators: collector.delegators}, nil
+}
+
+func TestNewSigningPolicyAllowedRecipients(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := configuration.ConfigurationToRuntimeConfiguration(&configuration.LatestConfigurationType{
+		SigningPolicy: tezpay_configuration.SigningPolicyConfigurationV0{RestrictRecipients: true},
+	})
+	assert.Nil(err)
+	delegator := mock.GetRandomAddress()
+	collector := &delegatorsCollector{SimpleColletor: mock.InitSimpleColletor(), delegators: []common.Delegator{{Address: delegator}}}
+
+	_, err = NewSigningPolicy(config, nil, []int64{100}, nil, nil)
+	assert.ErrorIs(err, constants.ErrSigningPolicyLoadFailed)
+
+	policy, err := NewSigningPolicy(config, collector, []int64{100}, nil, nil)
+	assert.Nil(err)
+	assert.True(policy.AllowedRecipients[delegator.String()])
+	assert.False(policy.AllowedRecipients[mock.GetRandomAddress().String()])
+	t.Log("default config donates to default destination")
+	assert.True(policy.AllowedRecipients[constants.DEFAULT_DONATION_ADDRESS])
+
+	config.IncomeRecipients.Donations = map[string]float64{delegator.String(): 100}
+	policy, err = NewSigningPolicy(config, collector, []int64{100}, nil, nil)
+	assert.Nil(err)
+	assert.False(policy.AllowedRecipients[constants.DEFAULT_DONATION_ADDRESS])
+}
@@ -46,7 +46,7 @@ func CheckConditionsAndPrepare(ctx *PayoutGenerationContext, options *common.Gen
 		return ctx, errors.Join(constants.ErrRevealCheckFailed, fmt.Errorf("address - %s", payoutAddress), err)
 	}
 	if !revealed {
-		return ctx, errors.Join(constants.ErrNotRevealed, fmt.Errorf("address - %s", payoutAddress))
+		return ctx, errors.Join(constants.ErrNotRevealed, fmt.Errorf("address - %s, reveal it with 'tezpay wallet reveal'", payoutAddress))
 	}
 
 	logger.Debug("estimating serialization gas limit")
@@ -58,6 +58,8 @@ func DistributeBonds(ctx *PayoutGenerationContext, options *common.GeneratePayou
 
 	bakerBonds := getBakerBondsAmount(ctx.StageData.CycleData, totalDelegatorsDelegatedBalance, configuration)
 	availableRewards := ctx.StageData.CycleData.GetTotalDelegatedRewards(configuration.PayoutConfiguration.PayoutMode).Sub(bakerBonds)
+	ctx.StageData.DelegatorsRewards = availableRewards
+	ctx.StageData.DelegatorsDelegatedBalance = totalDelegatorsDelegatedBalance
 
 	ctx.StageData.PayoutCandidatesWithBondAmount = lo.Map(candidates, func(candidate PayoutCandidate, _ int) PayoutCandidateWithBondAmount {
 		if candidate.IsInvalid {
@@ -77,6 +79,14 @@ func DistributeBonds(ctx *PayoutGenerationContext, options *common.GeneratePayou
 	ctx.StageData.BakerBondsAmount = bakerBonds.Sub(bondsDonate)
 	ctx.StageData.DonateBondsAmount = bondsDonate
 
+	// staker bonuses are funded from the edge before donation, bonuses invalidated during estimation stay with the baker
+	ctx.StageData.StakerBonuses = getStakerBonuses(ctx.StageData.CycleData, configuration)
+	ctx.StageData.StakerBonusPayouts = getStakerBonusPayouts(logger, ctx, options)
+	stakingEdge := ctx.StageData.CycleData.GetStakingEdgeIncome().Sub(sumValidStakerBonuses(ctx.StageData.StakerBonuses))
+	stakingEdgeDonate := utils.GetZPortion(stakingEdge, configuration.IncomeRecipients.DonateStakingEdge)
+	ctx.StageData.BakerStakingEdgeAmount = stakingEdge.Sub(stakingEdgeDonate)
+	ctx.StageData.DonateStakingEdgeAmount = stakingEdgeDonate
+
 	hookData := &AfterBondsDistributedHookData{
 		Cycle:      options.Cycle,
 		Candidates: ctx.StageData.PayoutCandidatesWithBondAmount,
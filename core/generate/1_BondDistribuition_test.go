@@ -1,6 +1,7 @@
 package generate
 
 import (
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +51,29 @@ func TestGetBakerBondsAmount(t *testing.T) {
 	bakerBondsAmount = getBakerBondsAmount(&cycleData, tezos.NewZ(9_000_000), &configWithOverdelegationProtectionDisabled)
 	assert.Equal(bakerBondsAmount.Int64(), tezos.NewZ(468).Int64())
 }
+
+func TestDistributeBondsStakingEdge(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.IncomeRecipients.DonateStakingEdge = 0.05
+	ctx := &PayoutGenerationContext{
+		configuration: &config,
+		StageData: &StageData{
+			CycleData: &common.BakersCycleData{
+				OwnStakedBalance:              tezos.NewZ(1_000_000),
+				OwnDelegatedBalance:           tezos.NewZ(1_000_000),
+				BlockDelegatedRewards:         tezos.NewZ(1000),
+				BlockStakingRewardsEdge:       tezos.NewZ(600_000),
+				EndorsementStakingRewardsEdge: tezos.NewZ(300_000),
+				BlockExternalStakingFees:      tezos.NewZ(100_000),
+			},
+		},
+		logger: slog.Default(),
+	}
+
+	result, err := DistributeBonds(ctx, &common.GeneratePayoutsOptions{})
+	assert.Nil(err)
+	assert.Equal(int64(950_000), result.StageData.BakerStakingEdgeAmount.Int64())
+	assert.Equal(int64(50_000), result.StageData.DonateStakingEdgeAmount.Int64())
+}
@@ -21,6 +21,8 @@ type CheckBalanceHookData struct {
 	IsSufficient bool                                  `json:"is_sufficient"`
 	Message      string                                `json:"message"`
 	Payouts      []PayoutCandidateWithBondAmountAndFee `json:"payouts"`
+	// Shortfall is set only by the collector check, top up is not triggered by hooks
+	Shortfall tezos.Z `json:"-"`
 }
 
 func checkBalanceWithHook(data *CheckBalanceHookData) error {
@@ -35,23 +37,26 @@ func checkBalanceWithCollector(data *CheckBalanceHookData, ctx *PayoutGeneration
 	if data.SkipTezCheck { // skip tez check for cases when pervious hook already checked it
 		return nil
 	}
-	payableBalance, err := ctx.GetCollector().GetBalance(ctx.PayoutKey.Address())
+	configuration := ctx.GetConfiguration()
+
+	payableBalance, err := ctx.GetCollector().GetBalance(configuration.PayoutConfiguration.GetFundingAddress(ctx.PayoutKey.Address()))
 	if err != nil {
 		return err
 	}
 
-	configuration := ctx.GetConfiguration()
-
 	totalPayouts := len(lo.Filter(data.Payouts, func(candidate PayoutCandidateWithBondAmountAndFee, _ int) bool {
 		return !candidate.IsInvalid
+	})) + len(lo.Filter(ctx.StageData.StakerBonuses, func(bonus StakerBonus, _ int) bool {
+		return !bonus.IsInvalid
 	}))
 
 	// calculate bonds and fees portion
 	bondsPortionToBeForwarded := lo.Sum(lo.Values(configuration.IncomeRecipients.Bonds))
 	feesPortionToBeForwarded := lo.Sum(lo.Values(configuration.IncomeRecipients.Fees))
+	stakingEdgePortionToBeForwarded := lo.Sum(lo.Values(configuration.IncomeRecipients.StakingEdge))
 
-	// add all bonds, fees and donations destinations
-	totalPayouts = totalPayouts + len(configuration.IncomeRecipients.Bonds) + len(configuration.IncomeRecipients.Fees) + utils.Max(len(configuration.IncomeRecipients.Donations), 1)
+	// add all bonds, fees, staking edge and donations destinations
+	totalPayouts = totalPayouts + len(configuration.IncomeRecipients.Bonds) + len(configuration.IncomeRecipients.Fees) + len(configuration.IncomeRecipients.StakingEdge) + utils.Max(len(configuration.IncomeRecipients.Donations), 1)
 
 	requiredbalance := lo.Reduce(data.Payouts, func(agg tezos.Z, candidate PayoutCandidateWithBondAmountAndFee, _ int) tezos.Z {
 		if candidate.TxKind == enums.PAYOUT_TX_KIND_TEZ {
@@ -63,25 +68,82 @@ func checkBalanceWithCollector(data *CheckBalanceHookData, ctx *PayoutGeneration
 	bondsToBeForwarded := ctx.StageData.BakerBondsAmount.Mul64(int64(bondsPortionToBeForwarded * 1000000)).Div64(1000000)
 	// fees * feesPortionToBeForwarded
 	feesToBeForwarded := ctx.StageData.BakerFeesAmount.Mul64(int64(feesPortionToBeForwarded * 1000000)).Div64(1000000)
-
-	// add bonds,fees and donations to required balance
-	requiredbalance = requiredbalance.Add(bondsToBeForwarded).Add(feesToBeForwarded).Add(ctx.StageData.DonateBondsAmount)
-	requiredbalance = requiredbalance.Add(tezos.NewZ(constants.PAYOUT_FEE_BUFFER).Mul64(int64(totalPayouts)))
+	// staking edge * stakingEdgePortionToBeForwarded
+	stakingEdgeToBeForwarded := ctx.StageData.BakerStakingEdgeAmount.Mul64(int64(stakingEdgePortionToBeForwarded * 1000000)).Div64(1000000)
+
+	// add bonds, fees, staking edge, staker bonuses and donations to required balance
+	requiredbalance = requiredbalance.Add(bondsToBeForwarded).Add(feesToBeForwarded).Add(stakingEdgeToBeForwarded).Add(sumValidStakerBonuses(ctx.StageData.StakerBonuses))
+	requiredbalance = requiredbalance.Add(ctx.StageData.DonateBondsAmount).Add(ctx.StageData.DonateStakingEdgeAmount)
+	requiredFees := tezos.NewZ(constants.PAYOUT_FEE_BUFFER).Mul64(int64(totalPayouts))
+	if configuration.PayoutConfiguration.Multisig == nil {
+		requiredbalance = requiredbalance.Add(requiredFees)
+	} else if err := checkMultisigSubmitterBalance(data, ctx, requiredFees, totalPayouts); err != nil {
+		return err
+	}
 
 	diff := payableBalance.Sub(requiredbalance)
 	if diff.IsNeg() || diff.IsZero() {
+		message := fmt.Sprintf("required: %s, available: %s", requiredbalance, payableBalance)
+		if !data.IsSufficient {
+			message = fmt.Sprintf("%s; %s", data.Message, message)
+		}
+		data.IsSufficient = false
+		data.Message = message
+		// balance has to be strictly greater than required
+		data.Shortfall = diff.Neg().Add64(1)
+	}
+	return nil
+}
+
+// checkMultisigSubmitterBalance checks the payout wallet can pay fees of multisig calls, payouts are funded by the multisig contract.
+// Payouts are expected to be split into calls by simulation batch size. Top up does not cover the payout wallet.
+func checkMultisigSubmitterBalance(data *CheckBalanceHookData, ctx *PayoutGenerationContext, payoutFees tezos.Z, totalPayouts int) error {
+	batchSize := ctx.GetConfiguration().PayoutConfiguration.SimulationBatchSize
+	calls := (totalPayouts + batchSize - 1) / batchSize
+	requiredFees := payoutFees.Add(tezos.NewZ(constants.MULTISIG_CALL_FEE_BUFFER).Mul64(int64(calls)))
+
+	submitterBalance, err := ctx.GetCollector().GetBalance(ctx.PayoutKey.Address())
+	if err != nil {
+		return err
+	}
+	if !requiredFees.IsLess(submitterBalance) {
 		data.IsSufficient = false
-		data.Message = fmt.Sprintf("required: %s, available: %s", requiredbalance, payableBalance)
+		data.Message = fmt.Sprintf("payout wallet required for multisig fees: %s, available: %s", requiredFees, submitterBalance)
 	}
 	return nil
 }
 
+// topUpPayoutWallet transfers the shortfall from the funding wallet if configured, returns true if the balance should be checked again.
+// Admin is notified only about the first failure of consecutive failed attempts tracked through failureNotified.
+func topUpPayoutWallet(ctx *PayoutGenerationContext, logger *slog.Logger, data *CheckBalanceHookData, failureNotified *bool) bool {
+	topUp := ctx.GetTopUp()
+	if topUp == nil || !tezos.Zero.IsLess(data.Shortfall) {
+		return false
+	}
+	destination := ctx.GetConfiguration().PayoutConfiguration.GetFundingAddress(ctx.PayoutKey.Address())
+	amount, err := topUp.TopUp(destination, data.Shortfall)
+	if err != nil {
+		logger.Warn("failed to top up payout wallet", "shortfall", common.MutezToTezS(data.Shortfall.Int64()), "error", err.Error(), "phase", "top_up")
+		if !*failureNotified {
+			ctx.AdminNotify(fmt.Sprintf("failed to top up payout wallet - %s", err.Error()))
+			*failureNotified = true
+		}
+		return false
+	}
+	*failureNotified = false
+	logger.Info("payout wallet topped up", "destination", destination.String(), "amount", common.MutezToTezS(amount.Int64()), "phase", "top_up")
+	ctx.AdminNotify(fmt.Sprintf("payout wallet %s topped up with %s", destination, common.MutezToTezS(amount.Int64())))
+	return true
+}
+
 func runBalanceCheck(ctx *PayoutGenerationContext, logger *slog.Logger, check func(*CheckBalanceHookData) error, data *CheckBalanceHookData, options *common.GeneratePayoutsOptions) error {
 	notificatorTrigger := 0
+	topUpFailureNotified := false
 	for {
 		// we reset values before each check so we get relevant data for this check only
 		data.IsSufficient = true
 		data.Message = ""
+		data.Shortfall = tezos.Zero
 
 		if err := check(data); err != nil {
 			if options.WaitForSufficientBalance {
@@ -93,6 +155,9 @@ func runBalanceCheck(ctx *PayoutGenerationContext, logger *slog.Logger, check fu
 		}
 
 		if !data.IsSufficient {
+			if topUpPayoutWallet(ctx, logger, data, &topUpFailureNotified) {
+				continue
+			}
 			if options.WaitForSufficientBalance {
 				logger.Warn("insufficient balance, retrying in 5 minutes...", "message", data.Message, "phase", "wait_for_sufficient_balance")
 				if notificatorTrigger%12 == 0 { // every hour
@@ -0,0 +1,104 @@
+package generate
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type failingTopUp struct {
+	err error
+}
+
+func (topUp *failingTopUp) GetId() string { return "failingTopUp" }
+func (topUp *failingTopUp) TopUp(destination tezos.Address, shortfall tezos.Z) (tezos.Z, error) {
+	if topUp.err != nil {
+		return tezos.Zero, topUp.err
+	}
+	return shortfall, nil
+}
+
+func TestTopUpPayoutWalletNotifiesOncePerFailureStreak(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	notifications := 0
+	topUp := &failingTopUp{err: errors.New("funding wallet is empty")}
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(mock.InitSimpleColletor(), nil, func(msg string) { notifications++ }).WithTopUp(topUp),
+		configuration:                &config,
+	}
+	data := &CheckBalanceHookData{Shortfall: tezos.NewZ(100)}
+	failureNotified := false
+
+	assert.False(topUpPayoutWallet(ctx, slog.Default(), data, &failureNotified))
+	assert.False(topUpPayoutWallet(ctx, slog.Default(), data, &failureNotified))
+	assert.Equal(1, notifications)
+
+	topUp.err = nil
+	assert.True(topUpPayoutWallet(ctx, slog.Default(), data, &failureNotified))
+	assert.Equal(2, notifications)
+	topUp.err = errors.New("funding wallet is empty")
+	assert.False(topUpPayoutWallet(ctx, slog.Default(), data, &failureNotified))
+	assert.Equal(3, notifications)
+}
+
+type balancesCollector struct {
+	*mock.SimpleColletor
+	balances map[string]tezos.Z
+}
+
+func (collector *balancesCollector) GetBalance(addr tezos.Address) (tezos.Z, error) {
+	return collector.balances[addr.String()], nil
+}
+
+func TestCheckBalanceWithCollectorMultisig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	contract := tezos.MustParseAddress("KT1VqarPDicMFn1ejmQqqshUkUXTCTXwmkCN")
+	config.PayoutConfiguration.Multisig = &configuration.RuntimeMultisigWallet{Contract: contract}
+	payoutKey, _ := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	collector := &balancesCollector{
+		SimpleColletor: mock.InitSimpleColletor(),
+		balances: map[string]tezos.Z{
+			contract.String(): tezos.NewZ(1_000_000),
+		},
+	}
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(collector, nil, func(string) {}),
+		PayoutKey:                    payoutKey.Public(),
+		StageData:                    &StageData{},
+		configuration:                &config,
+	}
+	payouts := []PayoutCandidateWithBondAmountAndFee{
+		{PayoutCandidateWithBondAmount: PayoutCandidateWithBondAmount{TxKind: enums.PAYOUT_TX_KIND_TEZ, BondsAmount: tezos.NewZ(500_000)}},
+	}
+
+	t.Log("payout wallet pays fees of multisig calls")
+	data := &CheckBalanceHookData{IsSufficient: true, Shortfall: tezos.Zero, Payouts: payouts}
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.False(data.IsSufficient)
+	assert.Contains(data.Message, "multisig fees")
+	assert.True(data.Shortfall.IsZero())
+
+	collector.balances[payoutKey.Address().String()] = tezos.NewZ(1_000_000)
+	data = &CheckBalanceHookData{IsSufficient: true, Shortfall: tezos.Zero, Payouts: payouts}
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.True(data.IsSufficient)
+
+	t.Log("payouts are funded by the multisig contract")
+	collector.balances[contract.String()] = tezos.NewZ(100_000)
+	data = &CheckBalanceHookData{IsSufficient: true, Shortfall: tezos.Zero, Payouts: payouts}
+	assert.Nil(checkBalanceWithCollector(data, ctx))
+	assert.False(data.IsSufficient)
+	assert.NotContains(data.Message, "multisig fees")
+	assert.False(data.Shortfall.IsZero())
+}
@@ -80,7 +80,7 @@ func getDistributionPayouts(logger *slog.Logger, kind enums.EPayoutKind, distrib
 	return all, nil
 }
 
-// injects bonds, fee and donation payments and finalizes Payouts
+// injects staker bonus, bonds, fee, staking edge and donation payments and finalizes Payouts
 func FinalizePayouts(ctx *PayoutGenerationContext, options *common.GeneratePayoutsOptions) (result *PayoutGenerationContext, err error) {
 	configuration := ctx.GetConfiguration()
 	logger := ctx.logger.With("phase", "finalize_payouts")
@@ -103,23 +103,31 @@ func FinalizePayouts(ctx *PayoutGenerationContext, options *common.GeneratePayou
 		return ctx, fmt.Errorf("invalid fees distribution - %s", err.Error())
 	}
 
+	// staking edge
+	stakingEdgePayouts, err := getDistributionPayouts(logger, enums.PAYOUT_KIND_STAKING_EDGE_INCOME, configuration.IncomeRecipients.StakingEdge, ctx.StageData.BakerStakingEdgeAmount, ctx, options)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid staking edge distribution - %s", err.Error())
+	}
+
 	// donations
 	donationDistributionDefinition := configuration.IncomeRecipients.Donations
-	if len(donationDistributionDefinition) == 0 && configuration.IncomeRecipients.DonateBonds+configuration.IncomeRecipients.DonateFees > 0 { // inject default destination
+	if len(donationDistributionDefinition) == 0 && configuration.IncomeRecipients.DonateBonds+configuration.IncomeRecipients.DonateFees+configuration.IncomeRecipients.DonateStakingEdge > 0 { // inject default destination
 		logger.Debug("no donation destination found, donating to tez.capital")
 		donationDistributionDefinition = map[string]float64{
 			constants.DEFAULT_DONATION_ADDRESS: 100,
 		}
 	}
-	donationPayouts, err := getDistributionPayouts(logger, enums.PAYOUT_KIND_DONATION, donationDistributionDefinition, ctx.StageData.DonateBondsAmount.Add(ctx.StageData.DonateFeesAmount), ctx, options)
+	donationPayouts, err := getDistributionPayouts(logger, enums.PAYOUT_KIND_DONATION, donationDistributionDefinition, ctx.StageData.DonateBondsAmount.Add(ctx.StageData.DonateFeesAmount).Add(ctx.StageData.DonateStakingEdgeAmount), ctx, options)
 	if err != nil {
 		return ctx, fmt.Errorf("invalid donation distribution - %s", err.Error())
 	}
 
 	payouts := make([]common.PayoutRecipe, 0)
 	payouts = append(payouts, delegatorPayouts...)
+	payouts = append(payouts, ctx.StageData.StakerBonusPayouts...)
 	payouts = append(payouts, bondsPayouts...)
 	payouts = append(payouts, feesPayouts...)
+	payouts = append(payouts, stakingEdgePayouts...)
 	payouts = append(payouts, donationPayouts...)
 
 	ctx.StageData.Payouts = payouts
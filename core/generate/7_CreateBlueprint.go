@@ -31,6 +31,10 @@ func CreateBlueprint(ctx *PayoutGenerationContext, options *common.GeneratePayou
 	logger := ctx.logger.With("phase", "create_blueprint")
 	logger.Info("creating payout blueprint")
 
+	stakerBonusPayouts := lo.Filter(stageData.Payouts, func(payout common.PayoutRecipe, _ int) bool {
+		return payout.Kind == enums.PAYOUT_KIND_STAKER_BONUS
+	})
+
 	blueprint := common.CyclePayoutBlueprint{
 		Cycle:   options.Cycle,
 		Payouts: stageData.Payouts,
@@ -44,14 +48,20 @@ func CreateBlueprint(ctx *PayoutGenerationContext, options *common.GeneratePayou
 			ExternalDelegatedBalance: stageData.CycleData.ExternalDelegatedBalance,
 			EarnedFees:               stageData.CycleData.BlockDelegatedFees,
 			EarnedRewards:            stageData.CycleData.GetTotalDelegatedRewards(ctx.configuration.PayoutConfiguration.PayoutMode),
+			EarnedStakingEdge:        stageData.CycleData.GetStakingEdgeIncome(),
+			MissedRewards:            stageData.CycleData.GetMissedDelegatedRewards(),
 			DistributedRewards:       sumValidPayoutsAmount(stageData.Payouts),
+			StakerBonuses:            sumValidPayoutsAmount(stakerBonusPayouts),
 			BondIncome:               stageData.BakerBondsAmount,
 			FeeIncome:                stageData.BakerFeesAmount,
-			IncomeTotal:              stageData.BakerBondsAmount.Add(stageData.BakerFeesAmount),
+			StakingEdgeIncome:        stageData.BakerStakingEdgeAmount,
+			IncomeTotal:              stageData.BakerBondsAmount.Add(stageData.BakerFeesAmount).Add(stageData.BakerStakingEdgeAmount),
 			DonatedBonds:             stageData.DonateBondsAmount,
 			DonatedFees:              stageData.DonateFeesAmount,
-			DonatedTotal:             stageData.DonateFeesAmount.Add(stageData.DonateBondsAmount),
+			DonatedStakingEdge:       stageData.DonateStakingEdgeAmount,
+			DonatedTotal:             stageData.DonateFeesAmount.Add(stageData.DonateBondsAmount).Add(stageData.DonateStakingEdgeAmount),
 			Timestamp:                time.Now(),
+			InvalidPayouts:           getInvalidPayoutsBreakdowns(stageData, ctx.configuration),
 		},
 		BatchMetadataDeserializationGasLimit: stageData.BatchMetadataDeserializationGasLimit,
 	}
@@ -23,6 +23,15 @@ type StageData struct {
 	DonateFeesAmount  tezos.Z
 	PaidDelegators    int
 
+	BakerStakingEdgeAmount  tezos.Z
+	DonateStakingEdgeAmount tezos.Z
+	StakerBonuses           []StakerBonus
+	StakerBonusPayouts      []common.PayoutRecipe
+
+	// rewards available to delegators and delegated balance they were split by
+	DelegatorsRewards          tezos.Z
+	DelegatorsDelegatedBalance tezos.Z
+
 	// protocol, signature etc.
 	BatchMetadataDeserializationGasLimit int64
 }
@@ -0,0 +1,69 @@
+package generate
+
+import (
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// isRedistributedInvalidReason reports whether delegators invalid for the reason are excluded from bond distribution
+func isRedistributedInvalidReason(reason enums.EPayoutInvalidReason, configuration *configuration.RuntimeConfiguration) bool {
+	if reason == enums.INVALID_DELEGATOR_IGNORED {
+		return true
+	}
+	return reason == enums.INVALID_DELEGATOR_LOW_BAlANCE && configuration.Delegators.Requirements.BellowMinimumBalanceRewardDestination == enums.REWARD_DESTINATION_EVERYONE
+}
+
+// getInvalidPayoutAmount returns share of rewards the invalid recipe would have received before fees
+func getInvalidPayoutAmount(recipe *common.PayoutRecipe, rewards tezos.Z, delegatedBalance tezos.Z) tezos.Z {
+	if recipe.Kind != enums.PAYOUT_KIND_INVALID {
+		return recipe.Amount
+	}
+	amount := recipe.Amount.Add(recipe.Fee)
+	if recipe.OpLimits != nil {
+		if recipe.TxFeeCollected {
+			amount = amount.Add64(recipe.OpLimits.GetOperationFeesWithoutAllocation())
+		}
+		if recipe.AllocationFeeCollected {
+			amount = amount.Add64(recipe.OpLimits.GetAllocationFee())
+		}
+	}
+	if !amount.IsZero() || delegatedBalance.IsZero() {
+		return amount
+	}
+	// invalidated before bonds were distributed
+	return rewards.Mul(recipe.DelegatedBalance).Div(delegatedBalance)
+}
+
+func getInvalidPayoutsBreakdowns(stageData *StageData, configuration *configuration.RuntimeConfiguration) []common.InvalidPayoutsBreakdown {
+	invalid := lo.Filter(stageData.Payouts, func(recipe common.PayoutRecipe, _ int) bool {
+		return !recipe.IsValid
+	})
+	redistributedBalance := lo.Reduce(invalid, func(total tezos.Z, recipe common.PayoutRecipe, _ int) tezos.Z {
+		if recipe.Kind != enums.PAYOUT_KIND_INVALID || !isRedistributedInvalidReason(enums.EPayoutInvalidReason(recipe.Note), configuration) {
+			return total
+		}
+		return total.Add(recipe.DelegatedBalance)
+	}, tezos.Zero)
+
+	breakdowns := lo.Map(invalid, func(recipe common.PayoutRecipe, _ int) common.InvalidPayoutsBreakdown {
+		reason := enums.EPayoutInvalidReason(recipe.Note)
+		breakdown := common.InvalidPayoutsBreakdown{
+			Reason:           reason,
+			Count:            1,
+			DelegatedBalance: recipe.DelegatedBalance,
+		}
+		if isRedistributedInvalidReason(reason, configuration) {
+			// share the delegator would have had if included in bond distribution
+			breakdown.Amount = getInvalidPayoutAmount(&recipe, stageData.DelegatorsRewards, stageData.DelegatorsDelegatedBalance.Add(redistributedBalance))
+			breakdown.Redistributed = breakdown.Amount
+		} else {
+			breakdown.Amount = getInvalidPayoutAmount(&recipe, stageData.DelegatorsRewards, stageData.DelegatorsDelegatedBalance)
+			breakdown.KeptByBaker = breakdown.Amount
+		}
+		return breakdown
+	})
+	return common.CombineInvalidPayoutsBreakdowns(breakdowns)
+}
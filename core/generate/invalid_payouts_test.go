@@ -0,0 +1,69 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestGetInvalidPayoutsBreakdowns(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	invalid := func(kind enums.EPayoutKind, reason enums.EPayoutInvalidReason, balance int64, amount int64) common.PayoutRecipe {
+		return common.PayoutRecipe{
+			Delegator:        mock.GetRandomAddress(),
+			Recipient:        mock.GetRandomAddress(),
+			Kind:             kind,
+			DelegatedBalance: tezos.NewZ(balance),
+			Amount:           tezos.NewZ(amount),
+			Note:             string(reason),
+		}
+	}
+	belowMinimum := invalid(enums.PAYOUT_KIND_INVALID, enums.INVALID_PAYOUT_BELLOW_MINIMUM, 100, 3)
+	belowMinimum.Fee = tezos.NewZ(1)
+	belowMinimum.OpLimits = &common.OpLimits{TransactionFee: 2, AllocationBurn: 5}
+	belowMinimum.TxFeeCollected = true
+	stageData := &StageData{
+		DelegatorsRewards:          tezos.NewZ(1000),
+		DelegatorsDelegatedBalance: tezos.NewZ(10000),
+		Payouts: []common.PayoutRecipe{
+			{Kind: enums.PAYOUT_KIND_DELEGATOR_REWARD, DelegatedBalance: tezos.NewZ(7500), Amount: tezos.NewZ(750), IsValid: true},
+			invalid(enums.PAYOUT_KIND_INVALID, enums.INVALID_DELEGATOR_IGNORED, 2000, 0),
+			invalid(enums.PAYOUT_KIND_INVALID, enums.INVALID_DELEGATOR_LOW_BAlANCE, 500, 0),
+			invalid(enums.PAYOUT_KIND_INVALID, enums.INVALID_DELEGATOR_LOW_BAlANCE, 500, 0),
+			belowMinimum,
+			invalid(enums.PAYOUT_KIND_DONATION, enums.INVALID_INVALID_ADDRESS, 0, 7),
+		},
+	}
+
+	breakdowns := getInvalidPayoutsBreakdowns(stageData, &config)
+	assert.Len(breakdowns, 4)
+	ignored, lowBalance, belowMinimumBreakdown, invalidAddress := breakdowns[0], breakdowns[1], breakdowns[2], breakdowns[3]
+	assert.Equal(enums.INVALID_DELEGATOR_IGNORED, ignored.Reason)
+	assert.Equal(int64(166), ignored.Amount.Int64())
+	assert.Equal(int64(166), ignored.Redistributed.Int64())
+	assert.True(ignored.KeptByBaker.IsZero())
+	assert.Equal(2, lowBalance.Count)
+	assert.Equal(int64(1000), lowBalance.DelegatedBalance.Int64())
+	assert.Equal(int64(100), lowBalance.KeptByBaker.Int64())
+	assert.Equal(int64(6), belowMinimumBreakdown.KeptByBaker.Int64())
+	assert.Equal(int64(7), invalidAddress.KeptByBaker.Int64())
+
+	t.Log("low balance rewards are redistributed if configured")
+	config.Delegators.Requirements.BellowMinimumBalanceRewardDestination = enums.REWARD_DESTINATION_EVERYONE
+	stageData.DelegatorsDelegatedBalance = tezos.NewZ(9000)
+	lowBalance = getInvalidPayoutsBreakdowns(stageData, &config)[1]
+	assert.Equal(int64(82), lowBalance.Redistributed.Int64())
+	assert.True(lowBalance.KeptByBaker.IsZero())
+
+	summary := common.CyclePayoutSummary{InvalidPayouts: breakdowns}
+	data := summary.GetInvalidPayoutsTemplateData()
+	assert.Equal("5", data["InvalidPayoutsCount"])
+	assert.Equal("2", data["InvalidPayouts_DELEGATOR_LOW_BALANCE_Count"])
+}
@@ -0,0 +1,141 @@
+package generate
+
+import (
+	"log/slog"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/core/estimate"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type StakerBonus struct {
+	Staker         tezos.Address              `json:"staker"`
+	Recipient      tezos.Address              `json:"recipient"`
+	StakedBalance  tezos.Z                    `json:"staked_balance"`
+	Amount         tezos.Z                    `json:"amount"`
+	IsInvalid      bool                       `json:"is_invalid,omitempty"`
+	InvalidBecause enums.EPayoutInvalidReason `json:"invalid_because,omitempty"`
+}
+
+// getStakerBonuses splits configured share of the rewards edge between external stakers by their staked balance
+func getStakerBonuses(cycleData *common.BakersCycleData, config *configuration.RuntimeConfiguration) []StakerBonus {
+	if config.StakerBonus == nil || !tezos.Zero.IsLess(cycleData.ExternalStakedBalance) {
+		return []StakerBonus{}
+	}
+	edge := cycleData.BlockStakingRewardsEdge.Add(cycleData.EndorsementStakingRewardsEdge)
+
+	bonuses := make([]StakerBonus, 0)
+	for _, delegator := range cycleData.Delegators {
+		if !tezos.Zero.IsLess(delegator.StakedBalance) || delegator.Address.Equal(config.BakerPKH) {
+			continue
+		}
+		if lo.ContainsBy(config.Delegators.Ignore, func(address tezos.Address) bool { return address.Equal(delegator.Address) }) {
+			continue
+		}
+
+		bonus := StakerBonus{
+			Staker:        delegator.Address,
+			Recipient:     delegator.Address,
+			StakedBalance: delegator.StakedBalance,
+			Amount:        utils.GetZPortion(edge.Mul(delegator.StakedBalance).Div(cycleData.ExternalStakedBalance), config.StakerBonus.Share),
+		}
+		if override, ok := config.Delegators.Overrides[delegator.Address.String()]; ok && !override.Recipient.Equal(tezos.InvalidAddress) {
+			bonus.Recipient = override.Recipient
+		}
+		switch {
+		case bonus.Amount.IsZero() || bonus.Amount.IsNeg():
+			bonus.IsInvalid = true
+			bonus.InvalidBecause = enums.INVALID_PAYOUT_ZERO
+		case bonus.Amount.IsLess(config.StakerBonus.MinimumAmount):
+			bonus.IsInvalid = true
+			bonus.InvalidBecause = enums.INVALID_PAYOUT_BELLOW_MINIMUM
+		}
+		bonuses = append(bonuses, bonus)
+	}
+	return bonuses
+}
+
+func sumValidStakerBonuses(bonuses []StakerBonus) tezos.Z {
+	return lo.Reduce(bonuses, func(agg tezos.Z, bonus StakerBonus, _ int) tezos.Z {
+		if bonus.IsInvalid {
+			return agg
+		}
+		return agg.Add(bonus.Amount)
+	}, tezos.Zero)
+}
+
+// getStakerBonusPayouts estimates payouts of staker bonuses, bonuses of payouts invalidated by estimation are marked invalid
+func getStakerBonusPayouts(logger *slog.Logger, ctx *PayoutGenerationContext, options *common.GeneratePayoutsOptions) []common.PayoutRecipe {
+	if len(ctx.StageData.StakerBonuses) == 0 {
+		return []common.PayoutRecipe{}
+	}
+	stakerBonus := ctx.GetConfiguration().StakerBonus
+	valid := make([]common.PayoutRecipe, 0, len(ctx.StageData.StakerBonuses))
+	invalid := make([]common.PayoutRecipe, 0)
+	for _, bonus := range ctx.StageData.StakerBonuses {
+		recipe := common.PayoutRecipe{
+			Baker:         ctx.GetConfiguration().BakerPKH,
+			Delegator:     bonus.Staker,
+			Cycle:         options.Cycle,
+			Recipient:     bonus.Recipient,
+			Kind:          enums.PAYOUT_KIND_STAKER_BONUS,
+			TxKind:        enums.PAYOUT_TX_KIND_TEZ,
+			StakedBalance: bonus.StakedBalance,
+			Amount:        bonus.Amount,
+			IsValid:       !bonus.IsInvalid,
+			Note:          string(bonus.InvalidBecause),
+		}
+		if bonus.IsInvalid {
+			invalid = append(invalid, recipe)
+			continue
+		}
+		valid = append(valid, recipe)
+	}
+
+	estimateContext := &estimate.EstimationContext{
+		PayoutKey:                            ctx.PayoutKey,
+		Collector:                            ctx.GetCollector(),
+		Configuration:                        ctx.GetConfiguration(),
+		BatchMetadataDeserializationGasLimit: ctx.StageData.BatchMetadataDeserializationGasLimit,
+	}
+
+	all := lo.Map(estimate.EstimateTransactionFees(utils.MapToPointers(valid), estimateContext), func(result estimate.EstimateResult[*common.PayoutRecipe], _ int) common.PayoutRecipe {
+		recipe := result.Transaction
+		if result.Error != nil {
+			logger.Warn("failed to estimate tx costs", "recipient", recipe.Recipient, "staker", recipe.Delegator, "amount", recipe.Amount.Int64(), "kind", recipe.TxKind, "error", result.Error)
+			recipe.IsValid = false
+			recipe.Note = string(enums.INVALID_FAILED_TO_ESTIMATE_TX_COSTS)
+			return *recipe
+		}
+		recipe.OpLimits = result.Result
+		if !stakerBonus.IsPayingTxFee {
+			recipe.Amount = recipe.Amount.Sub64(result.Result.GetOperationFeesWithoutAllocation())
+			recipe.TxFeeCollected = true
+		}
+		if !stakerBonus.IsPayingAllocationTxFee {
+			recipe.Amount = recipe.Amount.Sub64(result.Result.GetAllocationFee())
+			recipe.AllocationFeeCollected = true
+		}
+		if recipe.Amount.IsZero() || recipe.Amount.IsNeg() {
+			recipe.IsValid = false
+			recipe.Note = string(enums.INVALID_PAYOUT_BELLOW_MINIMUM)
+		}
+		return *recipe
+	})
+	for _, recipe := range all {
+		if recipe.IsValid {
+			continue
+		}
+		for i := range ctx.StageData.StakerBonuses {
+			if bonus := &ctx.StageData.StakerBonuses[i]; bonus.Staker.Equal(recipe.Delegator) {
+				bonus.IsInvalid = true
+				bonus.InvalidBecause = enums.EPayoutInvalidReason(recipe.Note)
+			}
+		}
+	}
+	return append(all, invalid...)
+}
@@ -0,0 +1,84 @@
+package generate
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestGetStakerBonuses(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.BakerPKH = mock.GetRandomAddress()
+	stakers := []tezos.Address{mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress(), mock.GetRandomAddress()}
+	config.Delegators.Ignore = []tezos.Address{stakers[3]}
+	cycleData := &common.BakersCycleData{
+		ExternalStakedBalance:         tezos.NewZ(4_000_000_000),
+		BlockStakingRewardsEdge:       tezos.NewZ(3_000_000),
+		EndorsementStakingRewardsEdge: tezos.NewZ(1_000_000),
+		BlockExternalStakingFees:      tezos.NewZ(500_000),
+		Delegators: []common.Delegator{
+			{Address: config.BakerPKH, StakedBalance: tezos.NewZ(10_000_000_000)},
+			{Address: stakers[0], StakedBalance: tezos.NewZ(3_000_000_000)},
+			{Address: stakers[1], StakedBalance: tezos.NewZ(10_000_000)},
+			{Address: stakers[2], DelegatedBalance: tezos.NewZ(1_000_000_000)},
+			{Address: stakers[3], StakedBalance: tezos.NewZ(990_000_000)},
+		},
+	}
+
+	t.Log("disabled")
+	assert.Empty(getStakerBonuses(cycleData, &config))
+
+	t.Log("enabled")
+	config.StakerBonus = &configuration.RuntimeStakerBonus{Share: 0.5, MinimumAmount: tezos.NewZ(10_000)}
+	bonuses := getStakerBonuses(cycleData, &config)
+	assert.Len(bonuses, 2)
+	assert.True(bonuses[0].Staker.Equal(stakers[0]))
+	assert.False(bonuses[0].IsInvalid)
+	assert.Equal(int64(1_500_000), bonuses[0].Amount.Int64())
+	assert.True(bonuses[1].Staker.Equal(stakers[1]))
+	assert.True(bonuses[1].IsInvalid)
+	assert.Equal(enums.INVALID_PAYOUT_BELLOW_MINIMUM, bonuses[1].InvalidBecause)
+	assert.Equal(int64(1_500_000), sumValidStakerBonuses(bonuses).Int64())
+}
+
+func TestDistributeBondsInvalidatedStakerBonus(t *testing.T) {
+	assert := assert.New(t)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.StakerBonus = &configuration.RuntimeStakerBonus{Share: 0.5}
+	staker := mock.GetRandomAddress()
+	failingCollector := mock.InitSimpleColletor()
+	failingCollector.SetOpts(&mock.SimpleCollectorOpts{FailWithError: errors.New("failed to simulate")})
+	ctx := &PayoutGenerationContext{
+		GeneratePayoutsEngineContext: *common.NewGeneratePayoutsEngines(failingCollector, nil, nil),
+		configuration:                &config,
+		StageData: &StageData{
+			CycleData: &common.BakersCycleData{
+				OwnStakedBalance:              tezos.NewZ(1_000_000),
+				ExternalStakedBalance:         tezos.NewZ(1_000_000),
+				BlockStakingRewardsEdge:       tezos.NewZ(600_000),
+				EndorsementStakingRewardsEdge: tezos.NewZ(400_000),
+				Delegators:                    []common.Delegator{{Address: staker, StakedBalance: tezos.NewZ(1_000_000)}},
+			},
+		},
+		logger: slog.Default(),
+	}
+
+	result, err := DistributeBonds(ctx, &common.GeneratePayoutsOptions{})
+	assert.Nil(err)
+	assert.Len(result.StageData.StakerBonusPayouts, 1)
+	assert.False(result.StageData.StakerBonusPayouts[0].IsValid)
+	assert.True(result.StageData.StakerBonuses[0].IsInvalid)
+	assert.Equal(enums.INVALID_FAILED_TO_ESTIMATE_TX_COSTS, result.StageData.StakerBonuses[0].InvalidBecause)
+	// invalidated bonus is not paid, edge stays with the baker
+	assert.Equal(int64(1_000_000), result.StageData.BakerStakingEdgeAmount.Int64())
+}
@@ -43,9 +43,8 @@ func PreparePayouts(ctx *PayoutPrepareContext, options *common.PreparePayoutsOpt
 		if err != nil && !os.IsNotExist(err) {
 			return nil, errors.Join(constants.ErrPayoutsFromFileLoadFailed, fmt.Errorf("cycle: %d", blueprint.Cycle), err)
 		}
-		reportResidues := utils.FilterReportsByBaker(reports, ctx.configuration.BakerPKH)
 		// we match already paid even against invalid set of payouts in case they were paid under different conditions
-		bluePrintPayouts, blueprintReportsOfPastSuccesfulPayouts := utils.FilterRecipesByReports(blueprint.Payouts, reportResidues, ctx.GetCollector())
+		bluePrintPayouts, blueprintReportsOfPastSuccesfulPayouts := utils.FilterRecipesByBakerReports(blueprint.Payouts, reports, ctx.configuration.BakerPKH, ctx.GetCollector())
 
 		payouts = append(payouts, bluePrintPayouts...)
 		reportsOfPastSuccesfulPayouts = append(reportsOfPastSuccesfulPayouts, blueprintReportsOfPastSuccesfulPayouts...)
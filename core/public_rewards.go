@@ -0,0 +1,106 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func getPublicRewardStatus(report *common.PayoutReport) common.EPublicRewardStatus {
+	switch {
+	case report.Kind == enums.PAYOUT_KIND_ACCUMULATED:
+		return common.PUBLIC_REWARD_STATUS_ACCUMULATED
+	case report.IsSuccess:
+		return common.PUBLIC_REWARD_STATUS_PAID
+	default:
+		return common.PUBLIC_REWARD_STATUS_FAILED
+	}
+}
+
+// getAccumulatedIntoCycle returns cycle of the combined payout the accumulated report was paid with
+func getAccumulatedIntoCycle(report *common.PayoutReport) int64 {
+	_, cycle, found := strings.Cut(report.Note, "#")
+	if !found {
+		return 0
+	}
+	result, err := strconv.ParseInt(cycle, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return result
+}
+
+// isRetriedSuccessfully checks whether failed report was paid by later successful payout of the same cycle
+func isRetriedSuccessfully(report *common.PayoutReport, reports []common.PayoutReport) bool {
+	identifier := report.GetIdentifier()
+	return lo.ContainsBy(reports, func(other common.PayoutReport) bool {
+		return other.IsSuccess && other.Cycle == report.Cycle && !other.Timestamp.Before(report.Timestamp) && other.GetIdentifier() == identifier
+	})
+}
+
+// BuildPublicDelegatorRewards collects tez rewards of the delegator from reports of the cycles between first and last cycle.
+// Only delegator payout kinds are included, income and donation reports never leave the baker.
+// Accumulated rewards are listed as paid with the cycle of the combined payout and are not summed again.
+func BuildPublicDelegatorRewards(reports []common.PayoutReport, delegator tezos.Address, firstCycle int64, lastCycle int64, explorer string) *common.PublicDelegatorRewards {
+	result := &common.PublicDelegatorRewards{
+		Delegator:    delegator,
+		Paid:         []common.PublicRewardLine{},
+		Pending:      []common.PublicRewardLine{},
+		TotalPaid:    tezos.Zero,
+		TotalPending: tezos.Zero,
+		FirstCycle:   firstCycle,
+		LastCycle:    lastCycle,
+	}
+
+	fees, gross := tezos.Zero, tezos.Zero
+	for _, report := range reports {
+		if !report.Delegator.Equal(delegator) || report.TxKind != enums.PAYOUT_TX_KIND_TEZ ||
+			(!lo.Contains(statementPayoutKinds, report.Kind) && report.Kind != enums.PAYOUT_KIND_ACCUMULATED) {
+			continue
+		}
+		line := common.PublicRewardLine{
+			Cycle:            report.Cycle,
+			Timestamp:        report.Timestamp,
+			Kind:             report.Kind,
+			Status:           getPublicRewardStatus(&report),
+			DelegatedBalance: report.DelegatedBalance,
+			Amount:           report.Amount,
+			Fee:              report.Fee,
+			FeeRate:          report.FeeRate,
+		}
+		switch line.Status {
+		case common.PUBLIC_REWARD_STATUS_ACCUMULATED:
+			line.PaidWithCycle = getAccumulatedIntoCycle(&report)
+			result.Paid = append(result.Paid, line)
+			continue
+		case common.PUBLIC_REWARD_STATUS_FAILED:
+			if !isRetriedSuccessfully(&report, reports) {
+				result.Pending = append(result.Pending, line)
+				result.TotalPending = result.TotalPending.Add(report.Amount)
+			}
+			continue
+		}
+		if !report.OpHash.Equal(tezos.ZeroOpHash) {
+			line.OpHash = report.OpHash.String()
+			if explorer != "" {
+				line.ExplorerUrl = utils.GetOpReference(report.OpHash, explorer)
+			}
+		}
+		result.Paid = append(result.Paid, line)
+		result.TotalPaid = result.TotalPaid.Add(report.Amount)
+		fees = fees.Add(report.Fee)
+		gross = gross.Add(report.Amount).Add(report.Fee)
+	}
+	result.EffectiveFee = getRatio(fees, gross)
+
+	compareLines := func(a, b common.PublicRewardLine) int { return int(b.Cycle - a.Cycle) }
+	slices.SortStableFunc(result.Paid, compareLines)
+	slices.SortStableFunc(result.Pending, compareLines)
+	return result
+}
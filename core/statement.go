@@ -0,0 +1,90 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var statementPayoutKinds = []enums.EPayoutKind{
+	enums.PAYOUT_KIND_DELEGATOR_REWARD,
+	enums.PAYOUT_KIND_STAKER_BONUS,
+}
+
+func isStatementReport(report *common.PayoutReport) bool {
+	return report.IsSuccess &&
+		report.TxKind == enums.PAYOUT_TX_KIND_TEZ &&
+		report.Delegator.IsValid() &&
+		lo.Contains(statementPayoutKinds, report.Kind)
+}
+
+// BuildDelegatorStatements aggregates successful tez payouts from reports into statement per delegator.
+// Statement is built only for the delegator if it is valid address, otherwise for all delegators.
+func BuildDelegatorStatements(baker tezos.Address, reports []common.PayoutReport, delegator tezos.Address) []common.DelegatorStatement {
+	generatedAt := time.Now().UTC()
+	relevant := lo.Filter(reports, func(report common.PayoutReport, _ int) bool {
+		return isStatementReport(&report) && (!delegator.IsValid() || report.Delegator.Equal(delegator))
+	})
+	grouped := lo.GroupBy(relevant, func(report common.PayoutReport) string { return report.Delegator.String() })
+
+	statements := make([]common.DelegatorStatement, 0, len(grouped))
+	for _, delegatorReports := range grouped {
+		lines := lo.Map(delegatorReports, func(report common.PayoutReport, _ int) common.StatementLine {
+			var gross *tezos.Z
+			transactionFee := tezos.Zero
+			if report.TxFeeCollected != nil {
+				if *report.TxFeeCollected {
+					transactionFee = tezos.NewZ(report.TransactionFee)
+				}
+				gross = lo.ToPtr(report.Amount.Add(report.Fee).Add(transactionFee))
+			}
+			return common.StatementLine{
+				Cycle:            report.Cycle,
+				Timestamp:        report.Timestamp,
+				Kind:             report.Kind,
+				Recipient:        report.Recipient,
+				DelegatedBalance: report.DelegatedBalance,
+				Gross:            gross,
+				Fee:              report.Fee,
+				TransactionFee:   transactionFee,
+				Net:              report.Amount,
+				OpHash:           report.OpHash,
+			}
+		})
+		slices.SortStableFunc(lines, func(a, b common.StatementLine) int {
+			if a.Cycle != b.Cycle {
+				return int(a.Cycle - b.Cycle)
+			}
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+
+		totals := common.StatementTotals{Gross: tezos.Zero, Fee: tezos.Zero, TransactionFee: tezos.Zero, Net: tezos.Zero}
+		for _, line := range lines {
+			if line.Gross != nil {
+				totals.Gross = totals.Gross.Add(*line.Gross)
+			}
+			totals.Fee = totals.Fee.Add(line.Fee)
+			totals.TransactionFee = totals.TransactionFee.Add(line.TransactionFee)
+			totals.Net = totals.Net.Add(line.Net)
+		}
+
+		statements = append(statements, common.DelegatorStatement{
+			Baker:       baker,
+			Delegator:   delegatorReports[0].Delegator,
+			FirstCycle:  lines[0].Cycle,
+			LastCycle:   lines[len(lines)-1].Cycle,
+			Lines:       lines,
+			Totals:      totals,
+			GeneratedAt: generatedAt,
+		})
+	}
+	slices.SortFunc(statements, func(a, b common.DelegatorStatement) int {
+		return strings.Compare(a.Delegator.String(), b.Delegator.String())
+	})
+	return statements
+}
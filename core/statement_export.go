@@ -0,0 +1,124 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+
+	"github.com/gocarina/gocsv"
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var statementHtmlTemplate = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"tez": func(amount tezos.Z) string {
+		return fmt.Sprintf("%.6f", float64(amount.Int64())/constants.MUTEZ_FACTOR)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Reward statement {{.Delegator}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 0.2em 1em; }
+dt { font-weight: bold; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.4em 0.6em; text-align: left; }
+td.amount, th.amount { text-align: right; font-variant-numeric: tabular-nums; }
+tfoot td { font-weight: bold; border-top: 2px solid #222; }
+.hash { font-family: monospace; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>Reward statement</h1>
+<dl>
+<dt>Baker</dt><dd class="hash">{{.Baker}}</dd>
+<dt>Delegator</dt><dd class="hash">{{.Delegator}}</dd>
+<dt>Cycles</dt><dd>{{.FirstCycle}} - {{.LastCycle}}</dd>
+<dt>Generated</dt><dd>{{.GeneratedAt.Format "2006-01-02"}}</dd>
+</dl>
+<table>
+<thead>
+<tr><th>Cycle</th><th>Kind</th><th class="amount">Delegated balance (TEZ)</th><th class="amount">Gross (TEZ)</th><th class="amount">Fee (TEZ)</th><th class="amount">Tx fee (TEZ)</th><th class="amount">Net (TEZ)</th><th>Operation</th></tr>
+</thead>
+<tbody>
+{{- range .Lines}}
+<tr><td>{{.Cycle}}</td><td>{{.Kind}}</td><td class="amount">{{tez .DelegatedBalance}}</td><td class="amount">{{with .Gross}}{{tez .}}{{else}}unknown{{end}}</td><td class="amount">{{tez .Fee}}</td><td class="amount">{{tez .TransactionFee}}</td><td class="amount">{{tez .Net}}</td><td class="hash">{{.OpHash}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td colspan="3">Total</td><td class="amount">{{tez .Totals.Gross}}</td><td class="amount">{{tez .Totals.Fee}}</td><td class="amount">{{tez .Totals.TransactionFee}}</td><td class="amount">{{tez .Totals.Net}}</td><td></td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// marshalStatementCsv writes statement lines followed by totals row
+func marshalStatementCsv(statement *common.DelegatorStatement) ([]byte, error) {
+	data, err := gocsv.MarshalBytes(statement.Lines)
+	if err != nil {
+		return nil, err
+	}
+	header, err := csv.NewReader(bytes.NewReader(data)).Read()
+	if err != nil {
+		return nil, err
+	}
+	totals := map[string]string{
+		"cycle":  "total",
+		"gross":  statement.Totals.Gross.String(),
+		"fee":    statement.Totals.Fee.String(),
+		"tx_fee": statement.Totals.TransactionFee.String(),
+		"net":    statement.Totals.Net.String(),
+	}
+	buffer := bytes.NewBuffer(data)
+	writer := csv.NewWriter(buffer)
+	writer.Write(lo.Map(header, func(column string, _ int) string { return totals[column] }))
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+func marshalStatement(statement *common.DelegatorStatement, format enums.EStatementFormat) ([]byte, error) {
+	switch format {
+	case enums.STATEMENT_FORMAT_CSV:
+		return marshalStatementCsv(statement)
+	case enums.STATEMENT_FORMAT_JSON:
+		return json.MarshalIndent(statement, "", "\t")
+	case enums.STATEMENT_FORMAT_HTML:
+		var buffer bytes.Buffer
+		err := statementHtmlTemplate.Execute(&buffer, statement)
+		return buffer.Bytes(), err
+	default:
+		return nil, errors.Join(constants.ErrUnsupportedStatementFormat, fmt.Errorf("format - %s", format))
+	}
+}
+
+// WriteDelegatorStatement writes statement into directory as '<delegator>.<format>' file for each format
+func WriteDelegatorStatement(statement *common.DelegatorStatement, directory string, formats []enums.EStatementFormat) ([]string, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, errors.Join(constants.ErrStatementWriteFailed, err)
+	}
+	files := make([]string, 0, len(formats))
+	for _, format := range formats {
+		data, err := marshalStatement(statement, format)
+		if err != nil {
+			return files, errors.Join(constants.ErrStatementWriteFailed, fmt.Errorf("delegator - %s", statement.Delegator), err)
+		}
+		file := path.Join(directory, fmt.Sprintf("%s.%s", statement.Delegator, format))
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			return files, errors.Join(constants.ErrStatementWriteFailed, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestBuildDelegatorStatements(t *testing.T) {
+	assert := assert.New(t)
+
+	baker := mock.GetRandomAddress()
+	alice := mock.GetRandomAddress()
+	bob := mock.GetRandomAddress()
+	report := func(delegator tezos.Address, cycle int64, kind enums.EPayoutKind, amount int64, success bool) common.PayoutReport {
+		report := mock.NewPayoutReport(cycle, amount)
+		report.Baker, report.Kind, report.Delegator, report.Recipient = baker, kind, delegator, delegator
+		report.Fee, report.TransactionFee, report.IsSuccess = tezos.NewZ(amount/10), 500, success
+		return report
+	}
+	reports := []common.PayoutReport{
+		report(alice, 101, enums.PAYOUT_KIND_DELEGATOR_REWARD, 2_000_000, true),
+		report(alice, 100, enums.PAYOUT_KIND_DELEGATOR_REWARD, 1_000_000, true),
+		report(alice, 101, enums.PAYOUT_KIND_STAKER_BONUS, 100_000, true),
+		report(alice, 102, enums.PAYOUT_KIND_DELEGATOR_REWARD, 3_000_000, false),
+		report(alice, 102, enums.PAYOUT_KIND_ACCUMULATED, 4_000_000, true),
+		report(bob, 100, enums.PAYOUT_KIND_DELEGATOR_REWARD, 500_000, true),
+		report(tezos.InvalidAddress, 100, enums.PAYOUT_KIND_FEE_INCOME, 700_000, true),
+	}
+
+	reports[1].TxFeeCollected = lo.ToPtr(true)
+	reports[2].TxFeeCollected = lo.ToPtr(false)
+
+	statements := BuildDelegatorStatements(baker, reports, tezos.InvalidAddress)
+	assert.Len(statements, 2)
+
+	statements = BuildDelegatorStatements(baker, reports, alice)
+	assert.Len(statements, 1)
+	statement := statements[0]
+	assert.Equal(alice, statement.Delegator)
+	assert.Equal(int64(100), statement.FirstCycle)
+	assert.Equal(int64(101), statement.LastCycle)
+	assert.Len(statement.Lines, 3)
+	assert.Equal(int64(100), statement.Lines[0].Cycle)
+	assert.Equal(int64(3_100_000), statement.Totals.Net.Int64())
+	assert.Equal(int64(310_000), statement.Totals.Fee.Int64())
+	t.Log("gross total skips lines where it is unknown whether transaction fee was collected")
+	assert.Nil(statement.Lines[1].Gross)
+	assert.Equal(int64(1_210_500), statement.Totals.Gross.Int64())
+	assert.Equal(int64(500), statement.Totals.TransactionFee.Int64())
+
+	directory := t.TempDir()
+	files, err := WriteDelegatorStatement(&statement, directory, enums.SUPPORTED_STATEMENT_FORMATS)
+	assert.Nil(err)
+	assert.Len(files, 3)
+	html, err := os.ReadFile(path.Join(directory, alice.String()+".html"))
+	assert.Nil(err)
+	assert.Contains(string(html), "1.210500")
+	csv, err := os.ReadFile(path.Join(directory, alice.String()+".csv"))
+	assert.Nil(err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Len(lines, 5)
+	assert.True(strings.HasPrefix(lines[4], "total,"))
+	assert.Contains(lines[4], ",1210500,310000,500,3100000,")
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// annualize compounds yield per cycle over estimated number of cycles per year
+func annualize(yieldPerCycle float64) float64 {
+	return math.Pow(1+yieldPerCycle, constants.ESTIMATED_CYCLES_PER_YEAR) - 1
+}
+
+func getRatio(amount tezos.Z, base tezos.Z) float64 {
+	if !tezos.Zero.IsLess(base) {
+		return 0
+	}
+	return float64(amount.Int64()) / float64(base.Int64())
+}
+
+func isDelegatorRewardReport(report *common.PayoutReport) bool {
+	return report.IsSuccess && report.Kind == enums.PAYOUT_KIND_DELEGATOR_REWARD && report.Delegator.IsValid()
+}
+
+// BuildDelegatorStatistics aggregates successful payouts per delegator, yield is computed from delegator rewards
+// relative to the delegated balance of the cycles they were paid for
+func BuildDelegatorStatistics(reports []common.PayoutReport) []common.DelegatorStatistics {
+	grouped := lo.GroupBy(lo.Filter(reports, func(report common.PayoutReport, _ int) bool {
+		return isStatementReport(&report)
+	}), func(report common.PayoutReport) string { return report.Delegator.String() })
+
+	result := make([]common.DelegatorStatistics, 0, len(grouped))
+	for _, delegatorReports := range grouped {
+		stats := common.DelegatorStatistics{
+			Delegator:     delegatorReports[0].Delegator,
+			FirstCycle:    delegatorReports[0].Cycle,
+			LastCycle:     delegatorReports[0].Cycle,
+			TotalReceived: tezos.Zero,
+			TotalFee:      tezos.Zero,
+		}
+		rewarded, balances := tezos.Zero, tezos.Zero
+		cycles := make(map[int64]bool)
+		for _, report := range delegatorReports {
+			stats.TotalReceived = stats.TotalReceived.Add(report.Amount)
+			stats.TotalFee = stats.TotalFee.Add(report.Fee)
+			stats.FirstCycle = min(stats.FirstCycle, report.Cycle)
+			stats.LastCycle = max(stats.LastCycle, report.Cycle)
+			cycles[report.Cycle] = true
+			if isDelegatorRewardReport(&report) {
+				rewarded = rewarded.Add(report.Amount)
+				balances = balances.Add(report.DelegatedBalance)
+			}
+		}
+		stats.CyclesPaid = len(cycles)
+		stats.AverageDelegatedBalance = balances.Div64(int64(stats.CyclesPaid))
+		stats.EffectiveYield = getRatio(rewarded, balances)
+		stats.EstimatedApy = annualize(stats.EffectiveYield)
+		result = append(result, stats)
+	}
+	slices.SortFunc(result, func(a, b common.DelegatorStatistics) int {
+		if c := b.TotalReceived.Cmp(a.TotalReceived); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Delegator.String(), b.Delegator.String())
+	})
+	return result
+}
+
+func BuildCycleStatistics(summaries []common.CyclePayoutSummary) []common.CycleStatistics {
+	result := lo.Map(summaries, func(summary common.CyclePayoutSummary, _ int) common.CycleStatistics {
+		return common.CycleStatistics{
+			Cycle:              summary.Cycle,
+			Delegators:         summary.Delegators,
+			PaidDelegators:     summary.PaidDelegators,
+			EarnedRewards:      summary.EarnedRewards,
+			EarnedFees:         summary.EarnedFees,
+			MissedRewards:      summary.MissedRewards,
+			DistributedRewards: summary.DistributedRewards,
+			IncomeTotal:        summary.IncomeTotal,
+		}
+	})
+	slices.SortFunc(result, func(a, b common.CycleStatistics) int { return int(a.Cycle - b.Cycle) })
+	return result
+}
+
+// BuildChurnStatistics compares delegators paid in consecutive reported cycles, the first cycle is the baseline
+func BuildChurnStatistics(reports []common.PayoutReport) []common.ChurnStatistics {
+	delegatorsByCycle := make(map[int64]map[string]tezos.Address)
+	for _, report := range reports {
+		if !isDelegatorRewardReport(&report) {
+			continue
+		}
+		if _, ok := delegatorsByCycle[report.Cycle]; !ok {
+			delegatorsByCycle[report.Cycle] = make(map[string]tezos.Address)
+		}
+		delegatorsByCycle[report.Cycle][report.Delegator.String()] = report.Delegator
+	}
+	cycles := lo.Keys(delegatorsByCycle)
+	slices.Sort(cycles)
+
+	result := make([]common.ChurnStatistics, 0, len(cycles))
+	for i, cycle := range cycles {
+		current := delegatorsByCycle[cycle]
+		stats := common.ChurnStatistics{
+			Cycle:            cycle,
+			Delegators:       len(current),
+			JoinedDelegators: []tezos.Address{},
+			LeftDelegators:   []tezos.Address{},
+		}
+		if i > 0 {
+			previous := delegatorsByCycle[cycles[i-1]]
+			for key, address := range current {
+				if _, ok := previous[key]; !ok {
+					stats.JoinedDelegators = append(stats.JoinedDelegators, address)
+				}
+			}
+			for key, address := range previous {
+				if _, ok := current[key]; !ok {
+					stats.LeftDelegators = append(stats.LeftDelegators, address)
+				}
+			}
+		}
+		compareAddresses := func(a, b tezos.Address) int { return strings.Compare(a.String(), b.String()) }
+		slices.SortFunc(stats.JoinedDelegators, compareAddresses)
+		slices.SortFunc(stats.LeftDelegators, compareAddresses)
+		stats.Joined, stats.Left = len(stats.JoinedDelegators), len(stats.LeftDelegators)
+		result = append(result, stats)
+	}
+	return result
+}
+
+// BuildApyStatistics estimates yields per cycle, delegator yield is based on paid delegator rewards
+// relative to external delegated balance, baker yield on income relative to baker's own balance
+func BuildApyStatistics(summaries []common.CyclePayoutSummary, reports []common.PayoutReport) []common.ApyStatistics {
+	rewardsByCycle := make(map[int64]tezos.Z)
+	for _, report := range reports {
+		if isDelegatorRewardReport(&report) {
+			rewardsByCycle[report.Cycle] = rewardsByCycle[report.Cycle].Add(report.Amount)
+		}
+	}
+
+	result := lo.Map(summaries, func(summary common.CyclePayoutSummary, _ int) common.ApyStatistics {
+		stats := common.ApyStatistics{
+			Cycle:            summary.Cycle,
+			DelegatorYield:   getRatio(rewardsByCycle[summary.Cycle], summary.ExternalDelegatedBalance),
+			StakerBonusYield: getRatio(summary.StakerBonuses, summary.ExternalStakedBalance),
+			BakerYield:       getRatio(summary.IncomeTotal, summary.OwnStakedBalance.Add(summary.OwnDelegatedBalance)),
+		}
+		stats.DelegatorApy = annualize(stats.DelegatorYield)
+		stats.StakerBonusApy = annualize(stats.StakerBonusYield)
+		stats.BakerApy = annualize(stats.BakerYield)
+		return stats
+	})
+	slices.SortFunc(result, func(a, b common.ApyStatistics) int { return int(a.Cycle - b.Cycle) })
+	return result
+}
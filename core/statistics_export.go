@@ -0,0 +1,62 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// summary is a single record, so csv is written as metric,value rows of its numeric fields
+func marshalCycleSummaryCsv(summary *common.CyclePayoutSummary) ([]byte, error) {
+	rows := [][]string{{"metric", "value"}}
+	value := reflect.ValueOf(*summary)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch fieldValue := value.Field(i).Interface().(type) {
+		case tezos.Z:
+			rows = append(rows, []string{name, fieldValue.String()})
+		case int, int64:
+			rows = append(rows, []string{name, fmt.Sprint(fieldValue)})
+		}
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// MarshalStatistics writes statistics as csv or json, data is either cycle summary or slice of statistics
+func MarshalStatistics(data any, format enums.EStatisticsFormat) ([]byte, error) {
+	var result []byte
+	var err error
+	switch format {
+	case enums.STATISTICS_FORMAT_JSON:
+		result, err = json.MarshalIndent(data, "", "\t")
+	case enums.STATISTICS_FORMAT_CSV:
+		if summary, ok := data.(*common.CyclePayoutSummary); ok {
+			result, err = marshalCycleSummaryCsv(summary)
+		} else {
+			result, err = gocsv.MarshalBytes(data)
+		}
+	default:
+		return nil, errors.Join(constants.ErrUnsupportedStatisticsFormat, fmt.Errorf("format - %s", format))
+	}
+	if err != nil {
+		return nil, errors.Join(constants.ErrStatisticsExportFailed, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestBuildStatistics(t *testing.T) {
+	assert := assert.New(t)
+
+	alice := mock.GetRandomAddress()
+	bob := mock.GetRandomAddress()
+	carol := mock.GetRandomAddress()
+	report := func(delegator tezos.Address, cycle int64, amount int64, success bool) common.PayoutReport {
+		report := mock.NewPayoutReport(cycle, amount)
+		report.Delegator, report.Recipient, report.DelegatedBalance = delegator, delegator, tezos.NewZ(1_000_000_000)
+		report.Fee, report.IsSuccess = tezos.NewZ(amount/10), success
+		return report
+	}
+	reports := []common.PayoutReport{
+		report(alice, 100, 1_000_000, true),
+		report(alice, 101, 1_000_000, true),
+		report(bob, 100, 500_000, true),
+		report(carol, 101, 3_000_000, true),
+		report(bob, 101, 500_000, false),
+	}
+
+	delegators := BuildDelegatorStatistics(reports)
+	assert.Len(delegators, 3)
+	assert.Equal(carol, delegators[0].Delegator)
+	assert.Equal(alice, delegators[1].Delegator)
+	assert.Equal(2, delegators[1].CyclesPaid)
+	assert.Equal(int64(2_000_000), delegators[1].TotalReceived.Int64())
+	assert.InDelta(0.001, delegators[1].EffectiveYield, 1e-9)
+	assert.Greater(delegators[1].EstimatedApy, delegators[1].EffectiveYield)
+
+	churn := BuildChurnStatistics(reports)
+	assert.Len(churn, 2)
+	assert.Equal(0, churn[0].Joined)
+	assert.Equal(2, churn[1].Delegators)
+	assert.Equal([]tezos.Address{carol}, churn[1].JoinedDelegators)
+	assert.Equal([]tezos.Address{bob}, churn[1].LeftDelegators)
+
+	summaries := []common.CyclePayoutSummary{
+		{Cycle: 101, ExternalDelegatedBalance: tezos.NewZ(3_000_000_000), OwnStakedBalance: tezos.NewZ(1_000_000_000), IncomeTotal: tezos.NewZ(1_000_000)},
+		{Cycle: 100, ExternalDelegatedBalance: tezos.NewZ(2_000_000_000)},
+	}
+	apy := BuildApyStatistics(summaries, reports)
+	assert.Equal(int64(100), apy[0].Cycle)
+	assert.InDelta(0.00075, apy[0].DelegatorYield, 1e-9)
+	assert.InDelta(4.0/3000, apy[1].DelegatorYield, 1e-9)
+	assert.InDelta(0.001, apy[1].BakerYield, 1e-9)
+	assert.Zero(apy[1].StakerBonusYield)
+
+	data, err := MarshalStatistics(delegators, enums.STATISTICS_FORMAT_CSV)
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(string(data), "delegator,cycles_paid"))
+	assert.Contains(string(data), alice.String())
+
+	data, err = MarshalStatistics(&summaries[0], enums.STATISTICS_FORMAT_CSV)
+	assert.Nil(err)
+	assert.Contains(string(data), "total_income,1000000")
+
+	_, err = MarshalStatistics(delegators, enums.STATISTICS_FORMAT_TABLE)
+	assert.NotNil(err)
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func GetWalletStatus(collector common.CollectorEngine, addr tezos.Address) (*common.WalletStatus, error) {
+	status, err := collector.GetWalletStatus(addr)
+	if err != nil {
+		return nil, errors.Join(constants.ErrWalletStatusLoadFailed, fmt.Errorf("address - %s", addr), err)
+	}
+	return status, nil
+}
+
+// RevealWallet publishes public key of the wallet controlled by the signer
+func RevealWallet(collector common.CollectorEngine, signer common.SignerEngine, transactor common.TransactorEngine, confirmations int64) (*rpc.Receipt, error) {
+	status, err := GetWalletStatus(collector, signer.GetPKH())
+	if err != nil {
+		return nil, err
+	}
+	if status.IsRevealed {
+		return nil, constants.ErrWalletAlreadyRevealed
+	}
+
+	reveal := &codec.Reveal{
+		Manager: codec.Manager{
+			Source: signer.GetPKH(),
+		},
+		PublicKey: signer.GetKey(),
+	}
+	reveal.WithLimits(rpc.DefaultRevealLimits)
+	op := codec.NewOp().WithSource(signer.GetPKH()).WithContents(reveal)
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+
+	opts := rpc.DefaultOptions
+	opts.Confirmations = confirmations
+	opts.Signer = signer.GetSigner()
+	rcpt, err := transactor.Send(op, &opts)
+	if err != nil {
+		return nil, errors.Join(constants.ErrWalletRevealFailed, err)
+	}
+	if !rcpt.IsSuccess() {
+		return rcpt, errors.Join(constants.ErrWalletRevealFailed, rcpt.Error())
+	}
+	return rcpt, nil
+}
+
+// EstimateWalletSweep estimates transfer of the whole balance of the source wallet to the destination.
+// The source wallet has to be revealed, otherwise reveal would be injected into the operation and consume part of the balance.
+func EstimateWalletSweep(collector common.CollectorEngine, config *configuration.RuntimeConfiguration, source tezos.Key, destination tezos.Address) (*common.WalletSweep, error) {
+	status, err := GetWalletStatus(collector, source.Address())
+	if err != nil {
+		return nil, err
+	}
+	if !status.IsRevealed {
+		return nil, errors.Join(constants.ErrNotRevealed, fmt.Errorf("address - %s", source.Address()))
+	}
+	balance := status.Balance.Int64()
+
+	op := codec.NewOp().WithSource(source.Address())
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	op.WithTransfer(destination, 1)
+	receipt, err := collector.Simulate(op, source)
+	if err != nil || (receipt != nil && !receipt.IsSuccess()) {
+		if receipt != nil && receipt.Error() != nil {
+			err = errors.Join(receipt.Error(), err)
+		}
+		return nil, errors.Join(constants.ErrWalletSweepFailed, err)
+	}
+	costs := receipt.Op.Costs()
+	if len(costs) != 1 {
+		return nil, errors.Join(constants.ErrWalletSweepFailed, fmt.Errorf("unexpected simulation costs: %v", costs))
+	}
+
+	sweep := &common.WalletSweep{
+		Source:       source.Address(),
+		Destination:  destination,
+		GasLimit:     costs[0].GasUsed + config.PayoutConfiguration.TxGasLimitBuffer,
+		StorageLimit: utils.CalculateStorageLimit(costs[0]),
+		Burn:         costs[0].AllocationBurn + costs[0].StorageBurn,
+	}
+	// estimate fee with the whole balance as amount, final operation can only be smaller
+	feeOp := buildWalletSweepOp(sweep, balance, op.Params)
+	sweep.Fee = utils.EstimateTransactionFee(feeOp, []int64{sweep.GasLimit + config.PayoutConfiguration.TxDeserializationGasBuffer}, config.PayoutConfiguration.TxFeeBuffer)
+	sweep.Amount = balance - sweep.Fee - sweep.Burn
+	if sweep.Amount <= 0 {
+		return nil, errors.Join(constants.ErrWalletInsufficientBalance, fmt.Errorf("balance %s, fee %s, burn %s", common.MutezToTezS(balance), common.MutezToTezS(sweep.Fee), common.MutezToTezS(sweep.Burn)))
+	}
+	return sweep, nil
+}
+
+func buildWalletSweepOp(sweep *common.WalletSweep, amount int64, params *tezos.Params) *codec.Op {
+	op := codec.NewOp().WithSource(sweep.Source).WithParams(params)
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	op.WithTransfer(sweep.Destination, amount)
+	common.InjectLimits(op, []tezos.Limits{{
+		Fee:          sweep.Fee,
+		GasLimit:     sweep.GasLimit,
+		StorageLimit: sweep.StorageLimit,
+	}})
+	return op
+}
+
+// SweepWallet transfers estimated amount with estimated limits, signer has to control the sweep source
+func SweepWallet(sweep *common.WalletSweep, signer common.SignerEngine, transactor common.TransactorEngine, confirmations int64) (*rpc.Receipt, error) {
+	if !signer.GetPKH().Equal(sweep.Source) {
+		return nil, errors.Join(constants.ErrWalletSweepFailed, fmt.Errorf("signer %s does not control %s", signer.GetPKH(), sweep.Source))
+	}
+	op := buildWalletSweepOp(sweep, sweep.Amount, nil)
+
+	opts := rpc.DefaultOptions
+	opts.Confirmations = confirmations
+	opts.Signer = signer.GetSigner()
+	// limits are precomputed so the fee and burns are covered exactly by the remaining balance
+	opts.IgnoreLimits = true
+	rcpt, err := transactor.Send(op, &opts)
+	if err != nil {
+		return nil, errors.Join(constants.ErrWalletSweepFailed, err)
+	}
+	if !rcpt.IsSuccess() {
+		return rcpt, errors.Join(constants.ErrWalletSweepFailed, rcpt.Error())
+	}
+	return rcpt, nil
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestEstimateWalletSweep(t *testing.T) {
+	assert := assert.New(t)
+	collector := mock.InitSimpleColletor()
+	config := configuration.GetDefaultRuntimeConfiguration()
+
+	source, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+	destination := mock.GetRandomAddress()
+
+	sweep, err := EstimateWalletSweep(collector, &config, source.Public(), destination)
+	assert.Nil(err)
+	balance, _ := collector.GetBalance(source.Address())
+	assert.Equal(balance.Int64(), sweep.Amount+sweep.Fee+sweep.Burn)
+	assert.Greater(sweep.Fee, int64(0))
+	assert.True(destination.Equal(sweep.Destination))
+
+	collector.SetOpts(&mock.SimpleCollectorOpts{FailWithError: constants.ErrNotImplemented})
+	_, err = EstimateWalletSweep(collector, &config, source.Public(), destination)
+	assert.ErrorIs(err, constants.ErrWalletSweepFailed)
+}
@@ -36,6 +36,7 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 	donate := 0.025
 	donateFees := 0.05
 	donateBonds := 0.03
+	donateStakingEdge := 0.03
 	gasLimitBuffer := int64(200)
 	deserializationGasBuffer := int64(5)
 	feeBuffer := int64(10)
@@ -44,6 +45,9 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 	maximumBalance := float64(1000.0)
 	minimumDelayBlocks := int64(10)
 	maximumDelayBlocks := int64(250)
+	mempoolFeePercentile := float64(75)
+	maximumFeeMultiplier := float64(3)
+	topUpBuffer := float64(25)
 
 	return &tezpay_configuration.ConfigurationV0{
 		Version:  0,
@@ -77,10 +81,18 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 			ProtocolRewardsUrl:     constants.DEFAULT_PROTOCOL_REWARDS_URL,
 			Explorer:               "https://tzstats.com/",
 			DoNotPaySmartContracts: true,
+			BroadcastMode:          enums.BROADCAST_MODE_FAN_OUT,
+			InjectionEndpoints:     []string{"https://rpc.tzbeta.net/"},
 		},
 		Overdelegation: tezpay_configuration.OverdelegationConfigurationV0{
 			IsProtectionEnabled: true,
 		},
+		SigningPolicy: tezpay_configuration.SigningPolicyConfigurationV0{
+			MaximumTotalPerCycle:   10000,
+			MaximumPerRecipient:    1000,
+			MaximumFeePerOperation: 1,
+			RestrictRecipients:     true,
+		},
 		PayoutConfiguration: tezpay_configuration.PayoutConfigurationV0{
 			WalletMode:                 enums.WALLET_MODE_LOCAL_PRIVATE_KEY,
 			PayoutMode:                 enums.PAYOUT_MODE_IDEAL,
@@ -95,6 +107,27 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 			KtTxFeeBuffer:              &ktFeeBuffer,
 			MinimumDelayBlocks:         &minimumDelayBlocks,
 			MaximumDelayBlocks:         &maximumDelayBlocks,
+			Multisig: &tezpay_configuration.MultisigWalletV0{
+				Contract: tezos.MustParseAddress("KT1VqarPDicMFn1ejmQqqshUkUXTCTXwmkCN"),
+				Signers:  []string{"remote:tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM@http://127.0.0.1:20090"},
+			},
+			FeeStrategy: &tezpay_configuration.FeeStrategyV0{
+				Kind:              enums.FEE_STRATEGY_MEMPOOL,
+				MempoolPercentile: &mempoolFeePercentile,
+				MaximumMultiplier: &maximumFeeMultiplier,
+			},
+			TopUp: &tezpay_configuration.TopUpV0{
+				Signer:             "remote:tz1UVFyNAw4JkvekYrbuxyyV6rAFey9m4XnR@http://127.0.0.1:20091",
+				Buffer:             &topUpBuffer,
+				DailyLimit:         500,
+				MaximumPerTransfer: 250,
+			},
+			AutoStake: &tezpay_configuration.AutoStakeV0{
+				Signer:        "remote:tz1g7FrSapWND759VEWarCHQJGM5bhhmW2ZE@http://127.0.0.1:20090",
+				BondsShare:    0.5,
+				FeesShare:     0.25,
+				LiquidReserve: 100,
+			},
 		},
 		NotificationConfigurations: []json.RawMessage{
 			json.RawMessage(`{
@@ -160,6 +193,31 @@ func genrateSample() *tezpay_configuration.ConfigurationV0 {
 				"tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM": 0.10,
 				"tz1UGkfyrT9yBt6U5PV7Qeui3pt3a8jffoWv": 0.90,
 			},
+			StakingEdge: map[string]float64{
+				"tz1X7U9XxVz6NDxL4DSZhijME61PW45bYUJE": 1,
+			},
+			DonateStakingEdge: &donateStakingEdge,
+		},
+		Reports: tezpay_configuration.ReportsConfigurationV0{
+			Engine:    enums.REPORTER_ENGINE_SQLITE,
+			ExportCsv: true,
+			Ledger:    true,
+			Sinks: []tezpay_configuration.ReportSinkV0{
+				{
+					Engine: enums.REPORTER_ENGINE_HTTP,
+					Url:    "https://accounting.example.com/tezpay/reports",
+					Token:  "<token>",
+					Policy: enums.REPORT_SINK_POLICY_BEST_EFFORT,
+				},
+			},
+		},
+		Accounting: tezpay_configuration.AccountingV0{
+			Currencies:  []string{"eur", "usd"},
+			PriceSource: enums.PRICE_SOURCE_COINGECKO,
+		},
+		StakerBonus: &tezpay_configuration.StakerBonusV0{
+			Share:         0.5,
+			MinimumAmount: 0.1,
 		},
 		Extensions: []tezpay_configuration.ExtensionConfigurationV0{
 			common.ExtensionDefinition{
@@ -233,6 +291,7 @@ func GenerateStarterHJson() {
 
 	node.DeleteKey("network")
 	node.DeleteKey("income_recipients")
+	node.DeleteKey("signing_policy")
 	node.NKC("baker").Value = "your-baker-address"
 	node.NKC("payouts").DeleteKey("wallet_mode")
 	node.NKC("payouts").DeleteKey("payout_mode")
@@ -94,6 +94,21 @@ func (engine *DefaultRpcAndTzktColletor) IsRevealed(addr tezos.Address) (bool, e
 	return state.IsRevealed(), nil
 }
 
+func (engine *DefaultRpcAndTzktColletor) GetWalletStatus(addr tezos.Address) (*common.WalletStatus, error) {
+	state, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.ContractInfo, error) {
+		return client.GetContractExt(defaultCtx, addr, rpc.Head)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &common.WalletStatus{
+		Address:    addr,
+		Balance:    tezos.NewZ(state.Balance),
+		IsRevealed: state.IsRevealed(),
+		Counter:    state.Counter,
+	}, nil
+}
+
 func (engine *DefaultRpcAndTzktColletor) GetCurrentCycleNumber() (int64, error) {
 	head, err := utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*rpc.Block, error) {
 		return client.GetHeadBlock(defaultCtx)
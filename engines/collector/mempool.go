@@ -0,0 +1,60 @@
+package collector_engines
+
+import (
+	"strconv"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/rpc"
+)
+
+type mempoolRational [2]string
+
+func (r mempoolRational) float() float64 {
+	numerator, _ := strconv.ParseFloat(r[0], 64)
+	denominator, _ := strconv.ParseFloat(r[1], 64)
+	if denominator == 0 {
+		return numerator
+	}
+	return numerator / denominator
+}
+
+type mempoolFilter struct {
+	MinimalFees              string          `json:"minimal_fees"`
+	MinimalNanotezPerGasUnit mempoolRational `json:"minimal_nanotez_per_gas_unit"`
+	MinimalNanotezPerByte    mempoolRational `json:"minimal_nanotez_per_byte"`
+}
+
+func (engine *DefaultRpcAndTzktColletor) GetMempoolFeeLevels() (*common.MempoolFeeLevels, error) {
+	return utils.AttemptWithRpcClients(defaultCtx, engine.rpcs, func(client *rpc.Client) (*common.MempoolFeeLevels, error) {
+		var filter mempoolFilter
+		if err := client.Get(defaultCtx, "chains/main/mempool/filter", &filter); err != nil {
+			return nil, err
+		}
+		mempool, err := client.GetMempool(defaultCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		minimalFees, _ := strconv.ParseInt(filter.MinimalFees, 10, 64)
+		levels := &common.MempoolFeeLevels{
+			MinimalFees:              minimalFees,
+			MinimalNanotezPerGasUnit: filter.MinimalNanotezPerGasUnit.float(),
+			MinimalNanotezPerByte:    filter.MinimalNanotezPerByte.float(),
+			PendingFeeRates:          make([]float64, 0, len(mempool.Applied)),
+		}
+		for _, op := range mempool.Applied {
+			fee, gas := int64(0), int64(0)
+			for _, content := range op.Contents {
+				limits := content.Limits()
+				fee += limits.Fee
+				gas += limits.GasLimit
+			}
+			if fee == 0 || gas == 0 { // not a manager operation
+				continue
+			}
+			levels.PendingFeeRates = append(levels.PendingFeeRates, float64(fee)*1000/float64(gas))
+		}
+		return levels, nil
+	})
+}
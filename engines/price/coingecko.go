@@ -0,0 +1,127 @@
+package price_engines
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+)
+
+const (
+	coingeckoDateFormat     = "02-01-2006"
+	coingeckoRateLimitDelay = time.Minute
+	coingeckoMaxAttempts    = 3
+)
+
+type coingeckoHistoryResponse struct {
+	MarketData struct {
+		CurrentPrice map[string]float64 `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// CoinGeckoPriceEngine provides daily tez prices from coingecko, fetched prices are cached in cache file
+// so repeated exports do not hit the api rate limits
+type CoinGeckoPriceEngine struct {
+	url       string
+	apiKey    string
+	cacheFile string
+	client    *http.Client
+	// date -> currency -> price
+	cache map[string]map[string]float64
+}
+
+func InitCoinGeckoPriceEngine(url string, apiKey string, cacheFile string) (*CoinGeckoPriceEngine, error) {
+	cache := make(map[string]map[string]float64)
+	data, err := os.ReadFile(cacheFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &cache); err != nil {
+			return nil, errors.Join(constants.ErrPriceEngineLoadFailed, fmt.Errorf("price cache - %s", cacheFile), err)
+		}
+	case !os.IsNotExist(err):
+		return nil, errors.Join(constants.ErrPriceEngineLoadFailed, err)
+	}
+
+	return &CoinGeckoPriceEngine{
+		url:       strings.TrimSuffix(url, "/"),
+		apiKey:    apiKey,
+		cacheFile: cacheFile,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		cache:     cache,
+	}, nil
+}
+
+func (engine *CoinGeckoPriceEngine) GetId() string {
+	return "CoinGeckoPriceEngine"
+}
+
+func (engine *CoinGeckoPriceEngine) fetch(date string) (map[string]float64, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/coins/tezos/history?date=%s&localization=false", engine.url, date), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if engine.apiKey != "" {
+		req.Header.Set("x-cg-demo-api-key", engine.apiKey)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := engine.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < coingeckoMaxAttempts {
+			resp.Body.Close()
+			slog.Info("price api rate limit reached, waiting", "delay", coingeckoRateLimitDelay.String())
+			time.Sleep(coingeckoRateLimitDelay)
+			continue
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch price, status code: %d", resp.StatusCode)
+		}
+		var response coingeckoHistoryResponse
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return nil, err
+		}
+		return response.MarketData.CurrentPrice, nil
+	}
+}
+
+func (engine *CoinGeckoPriceEngine) saveCache() error {
+	data, err := json.Marshal(engine.cache)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(engine.cacheFile, data, 0644)
+}
+
+func (engine *CoinGeckoPriceEngine) GetPrice(currency string, at time.Time) (float64, error) {
+	currency = strings.ToLower(currency)
+	date := at.UTC().Format(coingeckoDateFormat)
+	prices, ok := engine.cache[date]
+	if !ok {
+		var err error
+		if prices, err = engine.fetch(date); err != nil {
+			return 0, errors.Join(constants.ErrPriceNotAvailable, fmt.Errorf("date - %s", date), err)
+		}
+		// prices of the current day are not final yet
+		if date != time.Now().UTC().Format(coingeckoDateFormat) {
+			engine.cache[date] = prices
+			if err := engine.saveCache(); err != nil {
+				slog.Warn("failed to save price cache", "file", engine.cacheFile, "error", err.Error())
+			}
+		}
+	}
+	price, ok := prices[currency]
+	if !ok {
+		return 0, errors.Join(constants.ErrPriceNotAvailable, fmt.Errorf("no %s price for %s", currency, date))
+	}
+	return price, nil
+}
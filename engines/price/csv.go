@@ -0,0 +1,96 @@
+package price_engines
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+)
+
+type pricePoint struct {
+	timestamp time.Time
+	prices    map[string]float64
+}
+
+// CsvPriceEngine looks up the latest price at or before requested time in local price file
+type CsvPriceEngine struct {
+	points []pricePoint
+}
+
+func parsePriceTimestamp(value string) (time.Time, error) {
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func InitCsvPriceEngine(priceFile string) (*CsvPriceEngine, error) {
+	f, err := os.Open(priceFile)
+	if err != nil {
+		return nil, errors.Join(constants.ErrPriceEngineLoadFailed, err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, errors.Join(constants.ErrPriceEngineLoadFailed, err)
+	}
+	if len(records) == 0 || len(records[0]) < 2 {
+		return nil, errors.Join(constants.ErrPriceEngineLoadFailed, errors.New("price file has to have header with timestamp and at least one currency column"))
+	}
+	currencies := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		currencies[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	points := make([]pricePoint, 0, len(records)-1)
+	for line, record := range records[1:] {
+		timestamp, err := parsePriceTimestamp(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, errors.Join(constants.ErrPriceEngineLoadFailed, fmt.Errorf("line %d", line+2), err)
+		}
+		point := pricePoint{timestamp: timestamp, prices: make(map[string]float64, len(currencies)-1)}
+		for i, value := range record[1:] {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, errors.Join(constants.ErrPriceEngineLoadFailed, fmt.Errorf("line %d", line+2), err)
+			}
+			point.prices[currencies[i+1]] = price
+		}
+		points = append(points, point)
+	}
+	slices.SortFunc(points, func(a, b pricePoint) int { return a.timestamp.Compare(b.timestamp) })
+
+	return &CsvPriceEngine{
+		points: points,
+	}, nil
+}
+
+func (engine *CsvPriceEngine) GetId() string {
+	return "CsvPriceEngine"
+}
+
+func (engine *CsvPriceEngine) GetPrice(currency string, at time.Time) (float64, error) {
+	currency = strings.ToLower(currency)
+	index, found := slices.BinarySearchFunc(engine.points, at, func(point pricePoint, target time.Time) int {
+		return point.timestamp.Compare(target)
+	})
+	if !found {
+		index--
+	}
+	for ; index >= 0; index-- {
+		if price, ok := engine.points[index].prices[currency]; ok {
+			return price, nil
+		}
+	}
+	return 0, errors.Join(constants.ErrPriceNotAvailable, fmt.Errorf("no %s price at or before %s", currency, at.Format(time.RFC3339)))
+}
@@ -0,0 +1,36 @@
+package price_engines
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+func TestCsvPriceEngine(t *testing.T) {
+	assert := assert.New(t)
+
+	priceFile := path.Join(t.TempDir(), "prices.csv")
+	assert.Nil(os.WriteFile(priceFile, []byte("timestamp,EUR,usd\n2024-05-02,0.95,1.02\n2024-05-01,0.90,\n2024-05-03T12:00:00Z,1.00,1.10\n"), 0600))
+	engine, err := InitCsvPriceEngine(priceFile)
+	assert.Nil(err)
+
+	price, err := engine.GetPrice("eur", time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal(0.90, price)
+	price, err = engine.GetPrice("EUR", time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal(1.00, price)
+
+	t.Log("missing currency falls back to earlier price")
+	price, err = engine.GetPrice("usd", time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal(1.02, price)
+	_, err = engine.GetPrice("usd", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	assert.ErrorIs(err, constants.ErrPriceNotAvailable)
+	_, err = engine.GetPrice("eur", time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC))
+	assert.ErrorIs(err, constants.ErrPriceNotAvailable)
+}
@@ -0,0 +1,24 @@
+package price_engines
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/state"
+)
+
+// Load returns price engine selected in accounting configuration
+func Load(config *configuration.RuntimeAccounting) (common.PriceEngine, error) {
+	switch config.PriceSource {
+	case enums.PRICE_SOURCE_CSV:
+		return InitCsvPriceEngine(config.PriceFile)
+	case enums.PRICE_SOURCE_COINGECKO, "":
+		return InitCoinGeckoPriceEngine(constants.DEFAULT_COINGECKO_URL, config.PriceApiKey, state.Global.GetPriceCacheFilePath())
+	default:
+		return nil, errors.Join(constants.ErrPriceEngineLoadFailed, fmt.Errorf("unsupported price source - %s", config.PriceSource))
+	}
+}
@@ -0,0 +1,391 @@
+package reporter_engines
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type ReportIssueKind string
+
+const (
+	REPORT_ISSUE_KIND_SCHEMA                ReportIssueKind = "schema"
+	REPORT_ISSUE_KIND_DUPLICATE             ReportIssueKind = "duplicate"
+	REPORT_ISSUE_KIND_SUMMARY_MISMATCH      ReportIssueKind = "summary_mismatch"
+	REPORT_ISSUE_KIND_MISSING_OP_HASH       ReportIssueKind = "missing_op_hash"
+	REPORT_ISSUE_KIND_OPERATION_NOT_APPLIED ReportIssueKind = "operation_not_applied"
+)
+
+// ReportIssue is inconsistency found in cycle reports, Row is 1 based index of data row in the file
+type ReportIssue struct {
+	Cycle      int64           `json:"cycle"`
+	File       string          `json:"file"`
+	Row        int             `json:"row,omitempty"`
+	Kind       ReportIssueKind `json:"kind"`
+	Reason     string          `json:"reason"`
+	Repairable bool            `json:"repairable"`
+}
+
+type cycleReportFiles struct {
+	cycle         int64
+	payouts       []common.PayoutReport
+	invalid       []common.PayoutReport
+	summary       *common.CyclePayoutSummary
+	summaryExists bool
+}
+
+func getPayoutReportColumns() []string {
+	reportType := reflect.TypeOf(common.PayoutReport{})
+	columns := make([]string, 0, reportType.NumField())
+	for i := 0; i < reportType.NumField(); i++ {
+		column, _, _ := strings.Cut(reportType.Field(i).Tag.Get("csv"), ",")
+		if column == "" || column == "-" {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func checkReportsHeader(cycle int64, file string, header []string) []ReportIssue {
+	issues := make([]ReportIssue, 0)
+	expected := getPayoutReportColumns()
+	if missing := lo.Without(expected, header...); len(missing) > 0 {
+		issues = append(issues, ReportIssue{Cycle: cycle, File: file, Kind: REPORT_ISSUE_KIND_SCHEMA, Reason: fmt.Sprintf("missing columns %s", strings.Join(missing, ", ")), Repairable: true})
+	}
+	if unknown := lo.Without(header, expected...); len(unknown) > 0 {
+		issues = append(issues, ReportIssue{Cycle: cycle, File: file, Kind: REPORT_ISSUE_KIND_SCHEMA, Reason: fmt.Sprintf("unknown columns %s", strings.Join(unknown, ", ")), Repairable: true})
+	}
+	return issues
+}
+
+func (engine *FsReporter) loadCycleReportFiles(cycle int64) (*cycleReportFiles, []ReportIssue, error) {
+	reportsDirectory, err := engine.getReportsDirectory()
+	if err != nil {
+		return nil, nil, err
+	}
+	cycleDirectory := path.Join(reportsDirectory, fmt.Sprintf("%d", cycle))
+	files := &cycleReportFiles{cycle: cycle}
+	issues := make([]ReportIssue, 0)
+
+	for _, file := range []string{constants.PAYOUT_REPORT_FILE_NAME, constants.INVALID_REPORT_FILE_NAME} {
+		header, reports, err := readReportsFile(cycleDirectory, file)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case errors.Is(err, os.ErrPermission):
+			return nil, nil, err
+		case err != nil:
+			issues = append(issues, ReportIssue{Cycle: cycle, File: file, Kind: REPORT_ISSUE_KIND_SCHEMA, Reason: fmt.Sprintf("failed to parse - %s", err.Error())})
+			continue
+		}
+		issues = append(issues, checkReportsHeader(cycle, file, header)...)
+		if file == constants.PAYOUT_REPORT_FILE_NAME {
+			files.payouts = reports
+		} else {
+			files.invalid = reports
+		}
+	}
+
+	data, err := os.ReadFile(path.Join(cycleDirectory, constants.REPORT_SUMMARY_FILE_NAME))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, nil, err
+	default:
+		files.summaryExists = true
+		var summary common.CyclePayoutSummary
+		if err := json.Unmarshal(data, &summary); err != nil {
+			issues = append(issues, ReportIssue{Cycle: cycle, File: constants.REPORT_SUMMARY_FILE_NAME, Kind: REPORT_ISSUE_KIND_SCHEMA, Reason: fmt.Sprintf("failed to parse - %s", err.Error())})
+			break
+		}
+		files.summary = &summary
+	}
+	return files, issues, nil
+}
+
+func getAccumulatedBaseCycle(report *common.PayoutReport) (int64, bool) {
+	if report.Kind != enums.PAYOUT_KIND_ACCUMULATED {
+		return 0, false
+	}
+	_, cycle, found := strings.Cut(report.Note, "#")
+	if !found {
+		return 0, false
+	}
+	baseCycle, err := strconv.ParseInt(cycle, 10, 64)
+	return baseCycle, err == nil
+}
+
+// getMergedAccumulatedAmounts sums accumulated payouts which were paid as part of payout of another cycle
+func (engine *FsReporter) getMergedAccumulatedAmounts(cycles []int64) map[int64]tezos.Z {
+	merged := make(map[int64]tezos.Z)
+	for _, cycle := range cycles {
+		invalid, err := engine.getExistingInvalidReports(cycle)
+		if err != nil {
+			continue
+		}
+		for _, report := range invalid {
+			if baseCycle, ok := getAccumulatedBaseCycle(&report); ok {
+				merged[baseCycle] = merged[baseCycle].Add(report.Amount)
+			}
+		}
+	}
+	return merged
+}
+
+func isSameReport(a, b *common.PayoutReport) bool {
+	aData, _ := json.Marshal(a)
+	bData, _ := json.Marshal(b)
+	return bytes.Equal(aData, bData)
+}
+
+// getUniqueReports drops rows identical to one of previous rows
+func getUniqueReports(reports []common.PayoutReport) []common.PayoutReport {
+	unique := make([]common.PayoutReport, 0, len(reports))
+	for _, report := range reports {
+		if !lo.ContainsBy(unique, func(other common.PayoutReport) bool { return isSameReport(&report, &other) }) {
+			unique = append(unique, report)
+		}
+	}
+	return unique
+}
+
+// getExpectedTotals computes summary totals supported by the payout reports
+func (files *cycleReportFiles) getExpectedTotals(merged tezos.Z) (distributed tezos.Z, paidDelegators int) {
+	accumulated := lo.Filter(files.invalid, func(report common.PayoutReport, _ int) bool {
+		return report.Kind == enums.PAYOUT_KIND_ACCUMULATED
+	})
+	reports := append(getUniqueReports(files.payouts), accumulated...)
+	distributed = tezos.Zero
+	delegators := make([]tezos.Address, 0, len(reports))
+	for _, report := range reports {
+		distributed = distributed.Add(report.Amount)
+		if (report.Kind == enums.PAYOUT_KIND_DELEGATOR_REWARD || report.Kind == enums.PAYOUT_KIND_ACCUMULATED) && !report.Delegator.Equal(report.Baker) {
+			delegators = append(delegators, report.Delegator)
+		}
+	}
+	return distributed.Sub(merged), len(lo.Uniq(delegators))
+}
+
+func (files *cycleReportFiles) check(merged tezos.Z, collector common.CollectorEngine, statuses map[string]common.OperationStatus) []ReportIssue {
+	issues := make([]ReportIssue, 0)
+	if files.payouts == nil {
+		return issues
+	}
+	newIssue := func(row int, kind ReportIssueKind, reason string, repairable bool) ReportIssue {
+		return ReportIssue{Cycle: files.cycle, File: constants.PAYOUT_REPORT_FILE_NAME, Row: row, Kind: kind, Reason: reason, Repairable: repairable}
+	}
+
+	seen := make(map[string]int, len(files.payouts))
+	for i, report := range files.payouts {
+		row := i + 1
+		if report.Cycle != files.cycle {
+			issues = append(issues, newIssue(row, REPORT_ISSUE_KIND_SCHEMA, fmt.Sprintf("row belongs to cycle %d", report.Cycle), false))
+		}
+		identifier := report.GetIdentifier()
+		if first, ok := seen[identifier]; ok {
+			// only identical rows can be dropped safely, other duplicates may differ in payout status
+			identical := isSameReport(&report, &files.payouts[first-1])
+			issues = append(issues, newIssue(row, REPORT_ISSUE_KIND_DUPLICATE, fmt.Sprintf("duplicate of row %d", first), identical))
+		} else {
+			seen[identifier] = row
+		}
+
+		if !report.IsSuccess {
+			continue
+		}
+		if !report.OpHash.IsValid() {
+			issues = append(issues, newIssue(row, REPORT_ISSUE_KIND_MISSING_OP_HASH, "successful payout without operation hash", false))
+			continue
+		}
+		if collector == nil {
+			continue
+		}
+		status, ok := statuses[report.OpHash.String()]
+		if !ok {
+			var err error
+			if status, err = collector.WasOperationApplied(report.OpHash); err != nil {
+				status = common.OPERATION_STATUS_UNKNOWN
+			}
+			statuses[report.OpHash.String()] = status
+		}
+		// not existing operation may be caused by indexer lag or failed request, only failure confirmed
+		// on chain is repaired, otherwise the payout would be paid again
+		if status != common.OPERATION_STATUS_APPLIED {
+			issues = append(issues, newIssue(row, REPORT_ISSUE_KIND_OPERATION_NOT_APPLIED, fmt.Sprintf("operation %s recorded as successful is %s on chain", report.OpHash, status), status == common.OPERATION_STATUS_FAILED))
+		}
+	}
+
+	summaryIssue := func(reason string) ReportIssue {
+		return ReportIssue{Cycle: files.cycle, File: constants.REPORT_SUMMARY_FILE_NAME, Kind: REPORT_ISSUE_KIND_SUMMARY_MISMATCH, Reason: reason, Repairable: true}
+	}
+	switch {
+	case files.summary == nil && !files.summaryExists:
+		// cycle data of the summary can not be recovered from payouts
+		missing := summaryIssue("summary is missing")
+		missing.Repairable = false
+		issues = append(issues, missing)
+	case files.summary != nil:
+		distributed, paidDelegators := files.getExpectedTotals(merged)
+		if files.summary.Cycle != files.cycle {
+			issues = append(issues, summaryIssue(fmt.Sprintf("summary belongs to cycle %d", files.summary.Cycle)))
+		}
+		if !files.summary.DistributedRewards.Equal(distributed) {
+			issues = append(issues, summaryIssue(fmt.Sprintf("distributed rewards %s do not match payouts %s", files.summary.DistributedRewards, distributed)))
+		}
+		if files.summary.PaidDelegators != paidDelegators {
+			issues = append(issues, summaryIssue(fmt.Sprintf("paid delegators %d do not match payouts %d", files.summary.PaidDelegators, paidDelegators)))
+		}
+	}
+	return issues
+}
+
+// CheckReports validates report files of given cycles, all reported cycles are checked if none are given.
+// Operations of successful payouts are checked on chain only if collector is provided.
+func (engine *FsReporter) CheckReports(collector common.CollectorEngine, cycles ...int64) ([]ReportIssue, error) {
+	reportedCycles, err := engine.getReportedCycles()
+	if err != nil {
+		return nil, errors.Join(constants.ErrReportsCheckFailed, err)
+	}
+	if len(cycles) == 0 {
+		cycles = reportedCycles
+	}
+	merged := engine.getMergedAccumulatedAmounts(reportedCycles)
+	statuses := make(map[string]common.OperationStatus)
+
+	issues := make([]ReportIssue, 0)
+	for _, cycle := range cycles {
+		files, cycleIssues, err := engine.loadCycleReportFiles(cycle)
+		if err != nil {
+			return nil, errors.Join(constants.ErrReportsCheckFailed, fmt.Errorf("cycle %d", cycle), err)
+		}
+		issues = append(issues, cycleIssues...)
+		issues = append(issues, files.check(merged[cycle], collector, statuses)...)
+	}
+	return issues, nil
+}
+
+func backupCycleReports(reportsDirectory string, backupDirectory string, cycle int64) error {
+	source := path.Join(reportsDirectory, fmt.Sprintf("%d", cycle))
+	target := path.Join(backupDirectory, fmt.Sprintf("%d", cycle))
+	if err := os.MkdirAll(target, 0700); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(path.Join(source, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path.Join(target, entry.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func markInconsistentReport(report *common.PayoutReport, reason string) {
+	report.IsSuccess = false
+	if report.Note == "" {
+		report.Note = reason
+		return
+	}
+	report.Note = fmt.Sprintf("%s; %s", report.Note, reason)
+}
+
+func (files *cycleReportFiles) repair(issues []ReportIssue, merged tezos.Z) {
+	rowIssues := lo.GroupBy(lo.Filter(issues, func(issue ReportIssue, _ int) bool {
+		return issue.File == constants.PAYOUT_REPORT_FILE_NAME && issue.Row > 0 && issue.Repairable
+	}), func(issue ReportIssue) int { return issue.Row })
+
+	payouts := make([]common.PayoutReport, 0, len(files.payouts))
+	first := make(map[string]common.PayoutReport, len(files.payouts))
+	for i, report := range files.payouts {
+		identifier := report.GetIdentifier()
+		if _, ok := first[identifier]; !ok {
+			first[identifier] = report
+		}
+		original := first[identifier]
+		if lo.ContainsBy(rowIssues[i+1], func(issue ReportIssue) bool { return issue.Kind == REPORT_ISSUE_KIND_DUPLICATE }) && isSameReport(&report, &original) {
+			continue
+		}
+		for _, issue := range rowIssues[i+1] {
+			markInconsistentReport(&report, issue.Reason)
+		}
+		payouts = append(payouts, report)
+	}
+	files.payouts = payouts
+
+	if files.summary == nil {
+		// missing or unreadable summary is not rebuilt, it would miss cycle data not recorded in payouts
+		return
+	}
+	files.summary.Cycle = files.cycle
+	files.summary.DistributedRewards, files.summary.PaidDelegators = files.getExpectedTotals(merged)
+}
+
+// RepairReports backs up cycles with repairable issues and repairs them. Rows of operations failed on chain are marked
+// as not successful, rows identical to previous ones are removed and summary totals are rebuilt from payouts.
+// Issues have to be checked while the cycles are locked, rows are matched by index.
+// Returns directory with the backup.
+func (engine *FsReporter) RepairReports(issues []ReportIssue) (string, error) {
+	repairable := lo.Filter(issues, func(issue ReportIssue, _ int) bool { return issue.Repairable })
+	if len(repairable) == 0 {
+		return "", nil
+	}
+	reportsDirectory, err := engine.getReportsDirectory()
+	if err != nil {
+		return "", errors.Join(constants.ErrReportsRepairFailed, err)
+	}
+	cycles := lo.Uniq(lo.Map(repairable, func(issue ReportIssue, _ int) int64 { return issue.Cycle }))
+	backupDirectory := path.Join(reportsDirectory, constants.REPORTS_BACKUP_DIRECTORY, time.Now().UTC().Format("20060102T150405Z"))
+	for _, cycle := range cycles {
+		if err := backupCycleReports(reportsDirectory, backupDirectory, cycle); err != nil {
+			return "", errors.Join(constants.ErrReportsRepairFailed, fmt.Errorf("failed to backup cycle %d", cycle), err)
+		}
+	}
+
+	reportedCycles, err := engine.getReportedCycles()
+	if err != nil {
+		return backupDirectory, errors.Join(constants.ErrReportsRepairFailed, err)
+	}
+	merged := engine.getMergedAccumulatedAmounts(reportedCycles)
+	for _, cycle := range cycles {
+		files, _, err := engine.loadCycleReportFiles(cycle)
+		if err != nil {
+			return backupDirectory, errors.Join(constants.ErrReportsRepairFailed, fmt.Errorf("cycle %d", cycle), err)
+		}
+		if files.payouts == nil {
+			continue
+		}
+		files.repair(lo.Filter(repairable, func(issue ReportIssue, _ int) bool { return issue.Cycle == cycle }), merged[cycle])
+
+		err = writeReportsFile(path.Join(reportsDirectory, fmt.Sprintf("%d", cycle)), constants.PAYOUT_REPORT_FILE_NAME, files.payouts)
+		if err == nil && files.summary != nil {
+			err = engine.ReportCycleSummary(*files.summary)
+		}
+		if err != nil {
+			return backupDirectory, errors.Join(constants.ErrReportsRepairFailed, fmt.Errorf("cycle %d", cycle), err)
+		}
+	}
+	return backupDirectory, nil
+}
@@ -0,0 +1,147 @@
+package reporter_engines
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/gocarina/gocsv"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestCheckAndRepairReports(t *testing.T) {
+	assert := assert.New(t)
+	reportsDirectory := t.TempDir()
+	t.Setenv("REPORTS_DIRECTORY", reportsDirectory)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	engine := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	report := func(cycle int64, kind enums.EPayoutKind, amount int64, opHash tezos.OpHash) common.PayoutReport {
+		report := mock.NewPayoutReport(cycle, amount)
+		report.Baker, report.Kind, report.OpHash = config.BakerPKH, kind, opHash
+		report.IsSuccess = kind != enums.PAYOUT_KIND_ACCUMULATED
+		return report
+	}
+
+	paid := report(100, enums.PAYOUT_KIND_DELEGATOR_REWARD, 10, tezos.NewOpHash([]byte("01234567890123456789012345678901")))
+	missingOpHash := report(100, enums.PAYOUT_KIND_DELEGATOR_REWARD, 20, tezos.ZeroOpHash)
+	// rows are written ordered by amount
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{paid, missingOpHash, paid}))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 100, DistributedRewards: tezos.NewZ(25), PaidDelegators: 3}))
+
+	// accumulated in cycle 101 and paid together with cycle 100
+	accumulated := report(101, enums.PAYOUT_KIND_ACCUMULATED, 5, tezos.ZeroOpHash)
+	accumulated.Note = "0123456789abcdef#100"
+	data, err := gocsv.MarshalBytes([]common.PayoutReport{accumulated})
+	assert.Nil(err)
+	assert.Nil(os.MkdirAll(path.Join(reportsDirectory, "101"), 0700))
+	assert.Nil(os.WriteFile(path.Join(reportsDirectory, "101", constants.INVALID_REPORT_FILE_NAME), data, 0644))
+
+	issues, err := engine.CheckReports(nil)
+	assert.Nil(err)
+	assert.Len(issues, 3)
+	kinds := map[ReportIssueKind]int{}
+	for _, issue := range issues {
+		assert.Equal(int64(100), issue.Cycle)
+		assert.Equal(issue.Kind != REPORT_ISSUE_KIND_MISSING_OP_HASH, issue.Repairable)
+		kinds[issue.Kind] = issue.Row
+	}
+	assert.Equal(map[ReportIssueKind]int{REPORT_ISSUE_KIND_MISSING_OP_HASH: 1, REPORT_ISSUE_KIND_DUPLICATE: 3, REPORT_ISSUE_KIND_SUMMARY_MISMATCH: 0}, kinds)
+
+	backupDirectory, err := engine.RepairReports(issues)
+	assert.Nil(err)
+	assert.FileExists(path.Join(backupDirectory, "100", constants.PAYOUT_REPORT_FILE_NAME))
+
+	t.Log("payouts without operation hash are left for manual review")
+	reports, err := engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 2)
+	assert.True(reports[0].IsSuccess)
+	assert.True(reports[1].IsSuccess)
+	summary, err := engine.GetExistingCycleSummary(100)
+	assert.Nil(err)
+	assert.Equal(2, summary.PaidDelegators)
+	assert.Equal(int64(25), summary.DistributedRewards.Int64())
+
+	issues, err = engine.CheckReports(nil)
+	assert.Nil(err)
+	assert.Len(issues, 1)
+	assert.Equal(REPORT_ISSUE_KIND_MISSING_OP_HASH, issues[0].Kind)
+}
+
+type operationStatusCollector struct {
+	*mock.SimpleColletor
+	statuses map[string]common.OperationStatus
+}
+
+func (collector *operationStatusCollector) WasOperationApplied(opHash tezos.OpHash) (common.OperationStatus, error) {
+	return collector.statuses[opHash.String()], nil
+}
+
+func TestRepairReportsOperationStatus(t *testing.T) {
+	assert := assert.New(t)
+	reportsDirectory := t.TempDir()
+	t.Setenv("REPORTS_DIRECTORY", reportsDirectory)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	engine := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	failedOpHash := tezos.NewOpHash([]byte("01234567890123456789012345678901"))
+	unknownOpHash := tezos.NewOpHash([]byte("11234567890123456789012345678901"))
+	collector := &operationStatusCollector{
+		SimpleColletor: mock.InitSimpleColletor(),
+		statuses: map[string]common.OperationStatus{
+			failedOpHash.String():  common.OPERATION_STATUS_FAILED,
+			unknownOpHash.String(): common.OPERATION_STATUS_NOT_EXISTS,
+		},
+	}
+	reports := lo.Map([]tezos.OpHash{failedOpHash, unknownOpHash}, func(opHash tezos.OpHash, i int) common.PayoutReport {
+		report := mock.NewPayoutReport(100, int64(20-i))
+		report.OpHash = opHash
+		return report
+	})
+	assert.Nil(engine.ReportPayouts(reports))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 100, DistributedRewards: tezos.NewZ(39), PaidDelegators: 2}))
+
+	issues, err := engine.CheckReports(collector)
+	assert.Nil(err)
+	assert.Len(issues, 2)
+	assert.True(issues[0].Repairable)
+	assert.False(issues[1].Repairable)
+
+	_, err = engine.RepairReports(issues)
+	assert.Nil(err)
+	reports, err = engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.False(reports[0].IsSuccess)
+	assert.True(reports[1].IsSuccess, "operation not found by indexer must stay paid")
+}
+
+func TestMissingSummaryIsNotRepaired(t *testing.T) {
+	assert := assert.New(t)
+	reportsDirectory := t.TempDir()
+	t.Setenv("REPORTS_DIRECTORY", reportsDirectory)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	engine := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	report := mock.NewPayoutReport(100, 10)
+	report.OpHash = tezos.NewOpHash([]byte("01234567890123456789012345678901"))
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{report}))
+
+	issues, err := engine.CheckReports(nil)
+	assert.Nil(err)
+	assert.Len(issues, 1)
+	assert.Equal(REPORT_ISSUE_KIND_SUMMARY_MISMATCH, issues[0].Kind)
+	assert.False(issues[0].Repairable)
+
+	backupDirectory, err := engine.RepairReports(issues)
+	assert.Nil(err)
+	assert.Empty(backupDirectory)
+	assert.NoFileExists(path.Join(reportsDirectory, "100", constants.REPORT_SUMMARY_FILE_NAME))
+}
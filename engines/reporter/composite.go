@@ -0,0 +1,142 @@
+package reporter_engines
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+type pendingSinkWrite struct {
+	kind    string
+	write   func(engine common.ReporterEngine) error
+	retries int
+}
+
+type ReportSink struct {
+	Name       string
+	Engine     common.ReporterEngine
+	Policy     enums.EReportSinkPolicy
+	RetryLimit int
+
+	// failed best effort writes kept in memory and retried in order before next write
+	pending []*pendingSinkWrite
+}
+
+// CompositeReporter reads from the primary engine and mirrors all writes to the sinks
+type CompositeReporter struct {
+	primary common.ReporterEngine
+	sinks   []*ReportSink
+}
+
+func NewCompositeReporter(primary common.ReporterEngine, sinks []*ReportSink) *CompositeReporter {
+	return &CompositeReporter{
+		primary: primary,
+		sinks:   sinks,
+	}
+}
+
+// flush retries pending writes in order and stops at first failure to keep the order of writes
+func (sink *ReportSink) flush() {
+	for len(sink.pending) > 0 {
+		pending := sink.pending[0]
+		err := pending.write(sink.Engine)
+		if err == nil {
+			slog.Info("pending report written to sink", "sink", sink.Name, "kind", pending.kind)
+			sink.pending = sink.pending[1:]
+			continue
+		}
+		pending.retries++
+		if pending.retries < sink.RetryLimit {
+			slog.Warn("failed to write pending report to sink, will retry later", "sink", sink.Name, "kind", pending.kind, "retries", pending.retries, "error", err.Error())
+			return
+		}
+		slog.Error("failed to write pending report to sink, dropping it", "sink", sink.Name, "kind", pending.kind, "retries", pending.retries, "error", err.Error())
+		sink.pending = sink.pending[1:]
+	}
+}
+
+func (sink *ReportSink) write(kind string, write func(engine common.ReporterEngine) error) error {
+	if sink.Policy == enums.REPORT_SINK_POLICY_MUST_SUCCEED {
+		if err := write(sink.Engine); err != nil {
+			return errors.Join(constants.ErrReportSinkWriteFailed, fmt.Errorf("sink - %s", sink.Name), err)
+		}
+		return nil
+	}
+
+	sink.flush()
+	if len(sink.pending) > 0 {
+		sink.pending = append(sink.pending, &pendingSinkWrite{kind: kind, write: write})
+		return nil
+	}
+	if err := write(sink.Engine); err != nil {
+		if sink.RetryLimit == 0 {
+			slog.Error("failed to write report to sink", "sink", sink.Name, "kind", kind, "error", err.Error())
+			return nil
+		}
+		slog.Warn("failed to write report to sink, will retry later", "sink", sink.Name, "kind", kind, "error", err.Error())
+		sink.pending = append(sink.pending, &pendingSinkWrite{kind: kind, write: write})
+	}
+	return nil
+}
+
+func (engine *CompositeReporter) write(kind string, write func(engine common.ReporterEngine) error) error {
+	if err := write(engine.primary); err != nil {
+		return err
+	}
+	errs := make([]error, 0)
+	for _, sink := range engine.sinks {
+		if err := sink.write(kind, write); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (engine *CompositeReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.primary.GetExistingReports(cycle)
+}
+
+func (engine *CompositeReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	return engine.write("payouts", func(e common.ReporterEngine) error {
+		return e.ReportPayouts(payouts)
+	})
+}
+
+func (engine *CompositeReporter) ReportInvalidPayouts(payouts []common.PayoutRecipe) error {
+	return engine.write("invalid payouts", func(e common.ReporterEngine) error {
+		return e.ReportInvalidPayouts(payouts)
+	})
+}
+
+func (engine *CompositeReporter) ReportCycleSummary(summary common.CyclePayoutSummary) error {
+	return engine.write("cycle summary", func(e common.ReporterEngine) error {
+		return e.ReportCycleSummary(summary)
+	})
+}
+
+func (engine *CompositeReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	return engine.primary.GetExistingCycleSummary(cycle)
+}
+
+// Close retries pending writes one last time and closes engines holding resources
+func (engine *CompositeReporter) Close() error {
+	errs := make([]error, 0)
+	for _, sink := range engine.sinks {
+		sink.flush()
+		if len(sink.pending) > 0 {
+			slog.Error("reports not written to sink", "sink", sink.Name, "count", len(sink.pending))
+		}
+		if closer, ok := sink.Engine.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	if closer, ok := engine.primary.(io.Closer); ok {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package reporter_engines
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+type recordingReporter struct {
+	StdioReporter
+	Summaries []int64
+	Err       error
+}
+
+func (engine *recordingReporter) ReportCycleSummary(summary common.CyclePayoutSummary) error {
+	if engine.Err != nil {
+		return engine.Err
+	}
+	engine.Summaries = append(engine.Summaries, summary.Cycle)
+	return nil
+}
+
+func (engine *recordingReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	return &common.CyclePayoutSummary{Cycle: cycle}, nil
+}
+
+func TestCompositeReporter(t *testing.T) {
+	assert := assert.New(t)
+
+	primary := &recordingReporter{}
+	mustSucceed := &recordingReporter{}
+	bestEffort := &recordingReporter{}
+	engine := NewCompositeReporter(primary, []*ReportSink{
+		{Name: "must succeed", Engine: mustSucceed, Policy: enums.REPORT_SINK_POLICY_MUST_SUCCEED},
+		{Name: "best effort", Engine: bestEffort, Policy: enums.REPORT_SINK_POLICY_BEST_EFFORT, RetryLimit: 2},
+	})
+
+	t.Log("writes are mirrored and reads go to primary")
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 100}))
+	assert.Equal([]int64{100}, primary.Summaries)
+	assert.Equal([]int64{100}, mustSucceed.Summaries)
+	assert.Equal([]int64{100}, bestEffort.Summaries)
+	bestEffort.Summaries = []int64{}
+	summary, err := engine.GetExistingCycleSummary(100)
+	assert.Nil(err)
+	assert.Equal(int64(100), summary.Cycle)
+
+	t.Log("best effort failures are queued and retried in order")
+	bestEffort.Err = errors.New("unavailable")
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 101}))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 102}))
+	assert.Empty(bestEffort.Summaries)
+	bestEffort.Err = nil
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 103}))
+	assert.Equal([]int64{101, 102, 103}, bestEffort.Summaries)
+
+	t.Log("best effort writes are dropped after retry limit")
+	bestEffort.Err = errors.New("unavailable")
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 104}))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 105}))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 106}))
+	bestEffort.Err = nil
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 107}))
+	assert.Equal([]int64{101, 102, 103, 105, 106, 107}, bestEffort.Summaries)
+
+	t.Log("must succeed failures fail the write")
+	mustSucceed.Err = errors.New("unavailable")
+	err = engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 108})
+	assert.ErrorIs(err, constants.ErrReportSinkWriteFailed)
+	assert.Contains(primary.Summaries, int64(108))
+
+	t.Log("primary failure is not mirrored")
+	primary.Err = errors.New("unavailable")
+	mustSucceed.Err = nil
+	assert.NotNil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 109}))
+	assert.NotContains(mustSucceed.Summaries, int64(109))
+}
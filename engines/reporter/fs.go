@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"sort"
+	"strconv"
 
-	"github.com/gocarina/gocsv"
 	"github.com/samber/lo"
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
@@ -38,21 +39,59 @@ func (engine *FsReporter) getReportsDirectory() (string, error) {
 	return directory, os.MkdirAll(directory, 0700)
 }
 
-func (engine *FsReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+func (engine *FsReporter) getCycleDirectory(cycle int64) (string, error) {
 	reportsDirectory, err := engine.getReportsDirectory()
 	if err != nil {
-		return []common.PayoutReport{}, err
+		return "", err
 	}
-	sourceFile := path.Join(reportsDirectory, fmt.Sprintf("%d", cycle), constants.PAYOUT_REPORT_FILE_NAME)
-	data, err := os.ReadFile(sourceFile)
+	return path.Join(reportsDirectory, fmt.Sprintf("%d", cycle)), nil
+}
+
+func (engine *FsReporter) readReports(cycle int64, fileName string) ([]common.PayoutReport, error) {
+	cycleDirectory, err := engine.getCycleDirectory(cycle)
 	if err != nil {
 		return []common.PayoutReport{}, err
 	}
-	reports := make([]common.PayoutReport, 0)
-	err = gocsv.UnmarshalBytes(data, &reports)
+	_, reports, err := readReportsFile(cycleDirectory, fileName)
+	if reports == nil {
+		reports = []common.PayoutReport{}
+	}
 	return reports, err
 }
 
+func (engine *FsReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.readReports(cycle, constants.PAYOUT_REPORT_FILE_NAME)
+}
+
+func (engine *FsReporter) getExistingInvalidReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.readReports(cycle, constants.INVALID_REPORT_FILE_NAME)
+}
+
+// getReportedCycles lists cycles with a report directory
+func (engine *FsReporter) getReportedCycles() ([]int64, error) {
+	reportsDirectory, err := engine.getReportsDirectory()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(reportsDirectory)
+	if err != nil {
+		return nil, err
+	}
+	cycles := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		cycle, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		cycles = append(cycles, cycle)
+	}
+	slices.Sort(cycles)
+	return cycles, nil
+}
+
 func (engine *FsReporter) ReportPayouts(payouts []common.PayoutReport) error {
 	if len(payouts) == 0 {
 		return nil
@@ -61,13 +100,8 @@ func (engine *FsReporter) ReportPayouts(payouts []common.PayoutReport) error {
 		return pr.Cycle
 	}))
 
-	reportsDirectory, err := engine.getReportsDirectory()
-	if err != nil {
-		return err
-	}
 	for _, cycle := range cyclesToBeWritten {
-		targetFile := path.Join(reportsDirectory, fmt.Sprintf("%d", cycle), constants.PAYOUT_REPORT_FILE_NAME)
-		err := os.MkdirAll(path.Dir(targetFile), 0700)
+		cycleDirectory, err := engine.getCycleDirectory(cycle)
 		if err != nil {
 			return err
 		}
@@ -79,12 +113,7 @@ func (engine *FsReporter) ReportPayouts(payouts []common.PayoutReport) error {
 		reports := lo.Filter(payouts, func(payout common.PayoutReport, _ int) bool {
 			return payout.Cycle == cycle
 		})
-		csv, err := gocsv.MarshalBytes(reports)
-		if err != nil {
-			return err
-		}
-		err = os.WriteFile(targetFile, csv, 0644)
-		if err != nil {
+		if err := writeReportsFile(cycleDirectory, constants.PAYOUT_REPORT_FILE_NAME, reports); err != nil {
 			return err
 		}
 	}
@@ -104,23 +133,13 @@ func (engine *FsReporter) ReportInvalidPayouts(payouts []common.PayoutRecipe) er
 		return pr.Cycle
 	}))
 
-	reportsDirectory, err := engine.getReportsDirectory()
-	if err != nil {
-		return err
-	}
 	for _, cycle := range cyclesToBeWritten {
-		targetFile := path.Join(reportsDirectory, fmt.Sprintf("%d", cycle), constants.INVALID_REPORT_FILE_NAME)
-		err := os.MkdirAll(path.Dir(targetFile), 0700)
+		cycleDirectory, err := engine.getCycleDirectory(cycle)
 		if err != nil {
 			return err
 		}
 		reports := lo.Map(utils.FilterPayoutsByCycle(invalid, cycle), mapPayoutRecipeToPayoutReport)
-		csv, err := gocsv.MarshalBytes(reports)
-		if err != nil {
-			return err
-		}
-		err = os.WriteFile(targetFile, csv, 0644)
-		if err != nil {
+		if err := writeReportsFile(cycleDirectory, constants.INVALID_REPORT_FILE_NAME, reports); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,105 @@
+package reporter_engines
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+const (
+	httpReportKindPayouts        = "payouts"
+	httpReportKindInvalidPayouts = "invalid_payouts"
+	httpReportKindCycleSummary   = "cycle_summary"
+)
+
+type httpReport struct {
+	Kind   string  `json:"kind"`
+	Cycles []int64 `json:"cycles"`
+	Data   any     `json:"data"`
+}
+
+// HttpReporter posts reports as json to configured url, it is write only and meant to be used as sink
+type HttpReporter struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHttpReporter(sink *configuration.RuntimeReportSink) *HttpReporter {
+	return &HttpReporter{
+		url:    sink.Url,
+		token:  sink.Token,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (engine *HttpReporter) post(report httpReport) error {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", engine.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if engine.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", engine.token))
+	}
+
+	resp, err := engine.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post %s report, status code: %d", report.Kind, resp.StatusCode)
+	}
+	return nil
+}
+
+func (engine *HttpReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return nil, errors.Join(constants.ErrReporterReadNotSupported, errors.New("engine - http"))
+}
+
+func (engine *HttpReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	return engine.post(httpReport{
+		Kind:   httpReportKindPayouts,
+		Cycles: lo.Uniq(lo.Map(payouts, func(pr common.PayoutReport, _ int) int64 { return pr.Cycle })),
+		Data:   payouts,
+	})
+}
+
+func (engine *HttpReporter) ReportInvalidPayouts(payouts []common.PayoutRecipe) error {
+	invalid := utils.OnlyInvalidPayouts(payouts)
+	if len(invalid) == 0 {
+		return nil
+	}
+	return engine.post(httpReport{
+		Kind:   httpReportKindInvalidPayouts,
+		Cycles: lo.Uniq(lo.Map(invalid, func(pr common.PayoutRecipe, _ int) int64 { return pr.Cycle })),
+		Data:   invalid,
+	})
+}
+
+func (engine *HttpReporter) ReportCycleSummary(summary common.CyclePayoutSummary) error {
+	return engine.post(httpReport{
+		Kind:   httpReportKindCycleSummary,
+		Cycles: []int64{summary.Cycle},
+		Data:   summary,
+	})
+}
+
+func (engine *HttpReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	return nil, errors.Join(constants.ErrReporterReadNotSupported, errors.New("engine - http"))
+}
@@ -0,0 +1,536 @@
+package reporter_engines
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/filelock"
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+)
+
+type LedgerEntryKind string
+
+const (
+	LEDGER_ENTRY_KIND_PAYOUT         LedgerEntryKind = "payout"
+	LEDGER_ENTRY_KIND_PAYOUT_REMOVED LedgerEntryKind = "payout_removed"
+	LEDGER_ENTRY_KIND_INVALID_PAYOUT LedgerEntryKind = "invalid_payout"
+	LEDGER_ENTRY_KIND_CYCLE_SUMMARY  LedgerEntryKind = "cycle_summary"
+)
+
+// LedgerEntry is single record of the append only payout ledger. Hash covers all other fields
+// including hash of the previous entry, so any modification, removal or reordering breaks the chain.
+type LedgerEntry struct {
+	Index     int64           `json:"index"`
+	Batch     int64           `json:"batch"`
+	Timestamp time.Time       `json:"timestamp"`
+	Kind      LedgerEntryKind `json:"kind"`
+	Cycle     int64           `json:"cycle"`
+	// ConfigurationHash is hash of the runtime configuration the entry was written with
+	ConfigurationHash string          `json:"configuration_hash"`
+	PreviousHash      string          `json:"previous_hash"`
+	Data              json.RawMessage `json:"data"`
+	Hash              string          `json:"hash"`
+}
+
+type LedgerViolation struct {
+	Index  int64  `json:"index"`
+	Cycle  int64  `json:"cycle,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (entry *LedgerEntry) computeHash() (string, error) {
+	hashed := *entry
+	hashed.Hash = ""
+	data, err := json.Marshal(hashed)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func getConfigurationHash(config *configuration.RuntimeConfiguration) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func getLedgerFile(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (string, error) {
+	reportsDirectory, err := NewFileSystemReporter(config, options).getReportsDirectory()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(reportsDirectory, constants.REPORTS_LEDGER_FILE_NAME), nil
+}
+
+// ReadLedger loads all ledger entries, missing ledger is treated as empty
+func ReadLedger(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) ([]LedgerEntry, error) {
+	ledgerFile, err := getLedgerFile(config, options)
+	if err != nil {
+		return nil, errors.Join(constants.ErrLedgerLoadFailed, err)
+	}
+	return readLedgerFile(ledgerFile)
+}
+
+func readLedgerFile(ledgerFile string) ([]LedgerEntry, error) {
+	data, err := os.ReadFile(ledgerFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []LedgerEntry{}, nil
+		}
+		return nil, errors.Join(constants.ErrLedgerLoadFailed, err)
+	}
+
+	entries := make([]LedgerEntry, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry LedgerEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Join(constants.ErrLedgerLoadFailed, fmt.Errorf("line %d", line), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// readLedgerTail reads only the last entry of the ledger, nil if ledger is empty
+func readLedgerTail(ledgerFile string) (*LedgerEntry, error) {
+	f, err := os.Open(ledgerFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := info.Size()
+	for chunk := int64(4096); ; chunk *= 2 {
+		offset := max(size-chunk, 0)
+		data := make([]byte, size-offset)
+		if _, err := f.ReadAt(data, offset); err != nil {
+			return nil, err
+		}
+		data = bytes.TrimRight(data, " \t\r\n")
+		start := bytes.LastIndexByte(data, '\n')
+		if start < 0 && offset > 0 {
+			continue
+		}
+		if len(data) == 0 {
+			return nil, nil
+		}
+		var entry LedgerEntry
+		if err := json.Unmarshal(data[start+1:], &entry); err != nil {
+			return nil, err
+		}
+		return &entry, nil
+	}
+}
+
+// ledgerPayouts are payouts currently recorded in ledger for each cycle, counted by their serialized form
+type ledgerPayouts map[int64]map[string]int
+
+func (payouts ledgerPayouts) apply(entry *LedgerEntry) {
+	key := string(entry.Data)
+	switch entry.Kind {
+	case LEDGER_ENTRY_KIND_PAYOUT:
+		if payouts[entry.Cycle] == nil {
+			payouts[entry.Cycle] = make(map[string]int)
+		}
+		payouts[entry.Cycle][key]++
+	case LEDGER_ENTRY_KIND_PAYOUT_REMOVED:
+		if payouts[entry.Cycle][key] > 1 {
+			payouts[entry.Cycle][key]--
+		} else {
+			delete(payouts[entry.Cycle], key)
+		}
+	}
+}
+
+// getChanges returns records of payouts new or changed compared to the ledger and of recorded payouts
+// no longer reported. Reported payouts replace all payouts of their cycles, same as in file system reporter.
+func (payouts ledgerPayouts) getChanges(reports []common.PayoutReport) ([]ledgerRecord, error) {
+	records := make([]ledgerRecord, 0)
+	cycles := lo.Uniq(lo.Map(reports, func(report common.PayoutReport, _ int) int64 { return report.Cycle }))
+	for _, cycle := range cycles {
+		remaining := make(map[string]int, len(payouts[cycle]))
+		for key, count := range payouts[cycle] {
+			remaining[key] = count
+		}
+		for _, report := range reports {
+			if report.Cycle != cycle {
+				continue
+			}
+			data, err := json.Marshal(report)
+			if err != nil {
+				return nil, err
+			}
+			if remaining[string(data)] > 0 {
+				remaining[string(data)]--
+				continue
+			}
+			records = append(records, ledgerRecord{kind: LEDGER_ENTRY_KIND_PAYOUT, cycle: cycle, data: json.RawMessage(data)})
+		}
+		removed := lo.Keys(remaining)
+		slices.Sort(removed)
+		for _, key := range removed {
+			for i := 0; i < remaining[key]; i++ {
+				records = append(records, ledgerRecord{kind: LEDGER_ENTRY_KIND_PAYOUT_REMOVED, cycle: cycle, data: json.RawMessage(key)})
+			}
+		}
+	}
+	return records, nil
+}
+
+func getLedgerPayouts(entries []LedgerEntry) ledgerPayouts {
+	payouts := make(ledgerPayouts)
+	for i := range entries {
+		payouts.apply(&entries[i])
+	}
+	return payouts
+}
+
+// LedgerReporter appends everything successfully written by the wrapped engine to the payout ledger.
+// Payouts are recorded only when new or changed, the ledger is locked while appending so processes
+// paying different cycles continue the same chain.
+type LedgerReporter struct {
+	engine            common.ReporterEngine
+	ledgerFile        string
+	configurationHash string
+	last              *LedgerEntry
+	payouts           ledgerPayouts
+}
+
+func NewLedgerReporter(engine common.ReporterEngine, config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (*LedgerReporter, error) {
+	ledgerFile, err := getLedgerFile(config, options)
+	if err != nil {
+		return nil, errors.Join(constants.ErrLedgerLoadFailed, err)
+	}
+	configurationHash, err := getConfigurationHash(config)
+	if err != nil {
+		return nil, errors.Join(constants.ErrLedgerLoadFailed, err)
+	}
+
+	reporter := &LedgerReporter{
+		engine:            engine,
+		ledgerFile:        ledgerFile,
+		configurationHash: configurationHash,
+	}
+	if err := reporter.load(); err != nil {
+		return nil, err
+	}
+	return reporter, nil
+}
+
+func (engine *LedgerReporter) load() error {
+	entries, err := readLedgerFile(engine.ledgerFile)
+	if err != nil {
+		return err
+	}
+	engine.last = nil
+	if len(entries) > 0 {
+		engine.last = &entries[len(entries)-1]
+	}
+	engine.payouts = getLedgerPayouts(entries)
+	return nil
+}
+
+// refresh reloads the ledger if it was appended by another process, expects the ledger to be locked
+func (engine *LedgerReporter) refresh() error {
+	tail, err := readLedgerTail(engine.ledgerFile)
+	if err != nil {
+		return errors.Join(constants.ErrLedgerLoadFailed, err)
+	}
+	if tail == nil && engine.last == nil || tail != nil && engine.last != nil && tail.Hash == engine.last.Hash {
+		return nil
+	}
+	return engine.load()
+}
+
+type ledgerRecord struct {
+	kind  LedgerEntryKind
+	cycle int64
+	data  any
+}
+
+func (engine *LedgerReporter) append(getRecords func() ([]ledgerRecord, error)) error {
+	if err := os.MkdirAll(path.Dir(engine.ledgerFile), 0700); err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	lock, err := filelock.NewLocker(engine.ledgerFile + ".lock").Open()
+	if err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	defer lock.Close()
+	if err := engine.refresh(); err != nil {
+		return err
+	}
+	records, err := getRecords()
+	if err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	index, previousHash := int64(0), ""
+	if engine.last != nil {
+		index, previousHash = engine.last.Index+1, engine.last.Hash
+	}
+	batch := index
+	timestamp := time.Now().UTC()
+
+	entries := make([]LedgerEntry, 0, len(records))
+	var buffer bytes.Buffer
+	for _, record := range records {
+		data, err := json.Marshal(record.data)
+		if err != nil {
+			return errors.Join(constants.ErrLedgerWriteFailed, err)
+		}
+		entry := LedgerEntry{
+			Index:             index,
+			Batch:             batch,
+			Timestamp:         timestamp,
+			Kind:              record.kind,
+			Cycle:             record.cycle,
+			ConfigurationHash: engine.configurationHash,
+			PreviousHash:      previousHash,
+			Data:              data,
+		}
+		if entry.Hash, err = entry.computeHash(); err != nil {
+			return errors.Join(constants.ErrLedgerWriteFailed, err)
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return errors.Join(constants.ErrLedgerWriteFailed, err)
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+		entries = append(entries, entry)
+		index, previousHash = index+1, entry.Hash
+	}
+
+	f, err := os.OpenFile(engine.ledgerFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(buffer.Bytes()); err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Join(constants.ErrLedgerWriteFailed, err)
+	}
+	for i := range entries {
+		engine.payouts.apply(&entries[i])
+	}
+	engine.last = &entries[len(entries)-1]
+	return nil
+}
+
+func (engine *LedgerReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	return engine.engine.GetExistingReports(cycle)
+}
+
+func (engine *LedgerReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	if err := engine.engine.ReportPayouts(payouts); err != nil {
+		return err
+	}
+	return engine.append(func() ([]ledgerRecord, error) {
+		return engine.payouts.getChanges(payouts)
+	})
+}
+
+func (engine *LedgerReporter) ReportInvalidPayouts(payouts []common.PayoutRecipe) error {
+	if err := engine.engine.ReportInvalidPayouts(payouts); err != nil {
+		return err
+	}
+	return engine.append(func() ([]ledgerRecord, error) {
+		return lo.Map(utils.OnlyInvalidPayouts(payouts), func(payout common.PayoutRecipe, _ int) ledgerRecord {
+			return ledgerRecord{kind: LEDGER_ENTRY_KIND_INVALID_PAYOUT, cycle: payout.Cycle, data: payout.ToPayoutReport()}
+		}), nil
+	})
+}
+
+func (engine *LedgerReporter) ReportCycleSummary(summary common.CyclePayoutSummary) error {
+	if err := engine.engine.ReportCycleSummary(summary); err != nil {
+		return err
+	}
+	return engine.append(func() ([]ledgerRecord, error) {
+		return []ledgerRecord{{kind: LEDGER_ENTRY_KIND_CYCLE_SUMMARY, cycle: summary.Cycle, data: summary}}, nil
+	})
+}
+
+func (engine *LedgerReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	return engine.engine.GetExistingCycleSummary(cycle)
+}
+
+func (engine *LedgerReporter) Close() error {
+	if closer, ok := engine.engine.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// VerifyLedgerChain checks entries are consecutive, unmodified and chained in order
+func VerifyLedgerChain(entries []LedgerEntry) []LedgerViolation {
+	violations := make([]LedgerViolation, 0)
+	previousHash := ""
+	for i, entry := range entries {
+		if entry.Index != int64(i) {
+			violations = append(violations, LedgerViolation{Index: entry.Index, Cycle: entry.Cycle, Reason: fmt.Sprintf("expected entry %d, entries are missing or reordered", i)})
+		}
+		if entry.PreviousHash != previousHash {
+			violations = append(violations, LedgerViolation{Index: entry.Index, Cycle: entry.Cycle, Reason: "previous hash does not match, chain is broken"})
+		}
+		if hash, err := entry.computeHash(); err != nil || hash != entry.Hash {
+			violations = append(violations, LedgerViolation{Index: entry.Index, Cycle: entry.Cycle, Reason: "entry hash does not match, entry was modified"})
+		}
+		previousHash = entry.Hash
+	}
+	return violations
+}
+
+// getLatestBatches returns entries of the last batch of given kind for each cycle, later writes replace earlier ones
+func getLatestBatches(entries []LedgerEntry, kind LedgerEntryKind) map[int64][]LedgerEntry {
+	result := make(map[int64][]LedgerEntry)
+	for _, entry := range entries {
+		if entry.Kind != kind {
+			continue
+		}
+		current, ok := result[entry.Cycle]
+		if !ok || current[0].Batch != entry.Batch {
+			current = make([]LedgerEntry, 0)
+		}
+		result[entry.Cycle] = append(current, entry)
+	}
+	return result
+}
+
+func getPayoutReportFingerprint(report *common.PayoutReport) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%t", report.Id, report.Delegator, report.Recipient, report.Kind, report.TxKind, report.Amount, report.OpHash, report.IsSuccess)
+}
+
+// VerifyLedgerReports cross-checks payouts and the latest summaries recorded in ledger against reports read from reporter
+func VerifyLedgerReports(entries []LedgerEntry, reporter common.ReporterEngine) []LedgerViolation {
+	violations := make([]LedgerViolation, 0)
+
+	payouts := make(ledgerPayouts)
+	lastIndex := make(map[int64]int64)
+	for i := range entries {
+		if entries[i].Kind == LEDGER_ENTRY_KIND_PAYOUT || entries[i].Kind == LEDGER_ENTRY_KIND_PAYOUT_REMOVED {
+			payouts.apply(&entries[i])
+			lastIndex[entries[i].Cycle] = entries[i].Index
+		}
+	}
+	cycles := lo.Keys(lastIndex)
+	slices.Sort(cycles)
+	for _, cycle := range cycles {
+		violation := LedgerViolation{Index: lastIndex[cycle], Cycle: cycle}
+		reports, err := reporter.GetExistingReports(cycle)
+		if err != nil && !os.IsNotExist(err) {
+			violation.Reason = fmt.Sprintf("failed to read payout reports - %s", err.Error())
+			violations = append(violations, violation)
+			continue
+		}
+
+		expected := make([]string, 0, len(payouts[cycle]))
+		for data, count := range payouts[cycle] {
+			var report common.PayoutReport
+			if err := json.Unmarshal([]byte(data), &report); err != nil {
+				violations = append(violations, LedgerViolation{Index: lastIndex[cycle], Cycle: cycle, Reason: "failed to parse payout entry"})
+				continue
+			}
+			for i := 0; i < count; i++ {
+				expected = append(expected, getPayoutReportFingerprint(&report))
+			}
+		}
+		actual := lo.Map(reports, func(report common.PayoutReport, _ int) string { return getPayoutReportFingerprint(&report) })
+		slices.Sort(expected)
+		slices.Sort(actual)
+		if !slices.Equal(expected, actual) {
+			missing, unexpected := lo.Difference(expected, actual)
+			violation.Reason = fmt.Sprintf("payout reports do not match ledger, %d recorded payouts missing or modified, %d unexpected payouts", len(missing), len(unexpected))
+			violations = append(violations, violation)
+		}
+	}
+
+	summaryBatches := getLatestBatches(entries, LEDGER_ENTRY_KIND_CYCLE_SUMMARY)
+	cycles = lo.Keys(summaryBatches)
+	slices.Sort(cycles)
+	for _, cycle := range cycles {
+		entry := summaryBatches[cycle][len(summaryBatches[cycle])-1]
+		violation := LedgerViolation{Index: entry.Index, Cycle: cycle}
+		summary, err := reporter.GetExistingCycleSummary(cycle)
+		if err != nil {
+			violation.Reason = fmt.Sprintf("failed to read cycle summary - %s", err.Error())
+			violations = append(violations, violation)
+			continue
+		}
+		var expected common.CyclePayoutSummary
+		if err := json.Unmarshal(entry.Data, &expected); err != nil {
+			violation.Reason = "failed to parse cycle summary entry"
+			violations = append(violations, violation)
+			continue
+		}
+		expectedData, _ := json.Marshal(expected)
+		actualData, _ := json.Marshal(summary)
+		if !bytes.Equal(expectedData, actualData) {
+			violation.Reason = "cycle summary does not match ledger"
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// VerifyLedgerOperations checks operations of successful payouts recorded in ledger were applied on chain
+func VerifyLedgerOperations(entries []LedgerEntry, collector common.CollectorEngine) []LedgerViolation {
+	violations := make([]LedgerViolation, 0)
+	checked := make(map[string]common.OperationStatus)
+	for _, entry := range entries {
+		if entry.Kind != LEDGER_ENTRY_KIND_PAYOUT {
+			continue
+		}
+		var report common.PayoutReport
+		if err := json.Unmarshal(entry.Data, &report); err != nil || !report.IsSuccess || !report.OpHash.IsValid() {
+			continue
+		}
+		status, ok := checked[report.OpHash.String()]
+		if !ok {
+			var err error
+			if status, err = collector.WasOperationApplied(report.OpHash); err != nil {
+				status = common.OPERATION_STATUS_UNKNOWN
+			}
+			checked[report.OpHash.String()] = status
+		}
+		if status != common.OPERATION_STATUS_APPLIED {
+			violations = append(violations, LedgerViolation{Index: entry.Index, Cycle: entry.Cycle, Reason: fmt.Sprintf("operation %s recorded as successful is %s on chain", report.OpHash, status)})
+		}
+	}
+	return violations
+}
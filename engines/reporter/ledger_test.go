@@ -0,0 +1,92 @@
+package reporter_engines
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestLedgerReporter(t *testing.T) {
+	assert := assert.New(t)
+	reportsDirectory := t.TempDir()
+	t.Setenv("REPORTS_DIRECTORY", reportsDirectory)
+	ledgerFile := path.Join(reportsDirectory, constants.REPORTS_LEDGER_FILE_NAME)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.Reports.Ledger = true
+	options := &common.ReporterEngineOptions{}
+
+	t.Log("only new or changed payouts are recorded and verified against reports")
+	engine, err := Load(&config, options)
+	assert.Nil(err)
+	first, second := mock.NewPayoutReport(100, 10), mock.NewPayoutReport(100, 20)
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{first}))
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{first, second}))
+	assert.Nil(engine.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 100, DistributedRewards: tezos.NewZ(30), Timestamp: mock.PayoutReportTimestamp}))
+	failed := mock.NewPayoutReport(100, 5)
+	failed.IsSuccess = false
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{first, second, failed}))
+	paid := failed
+	paid.IsSuccess = true
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{first, second, paid}))
+
+	entries, err := ReadLedger(&config, options)
+	assert.Nil(err)
+	assert.Len(entries, 6)
+	assert.Equal(LEDGER_ENTRY_KIND_PAYOUT_REMOVED, entries[5].Kind)
+	assert.Empty(VerifyLedgerChain(entries))
+	assert.Empty(VerifyLedgerReports(entries, engine))
+
+	t.Log("reporters loaded at the same time continue the same chain")
+	other, err := Load(&config, options)
+	assert.Nil(err)
+	assert.Nil(other.ReportPayouts([]common.PayoutReport{mock.NewPayoutReport(101, 7)}))
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{mock.NewPayoutReport(102, 8)}))
+
+	entries, err = ReadLedger(&config, options)
+	assert.Nil(err)
+	assert.Len(entries, 8)
+	assert.Equal(entries[6].Hash, entries[7].PreviousHash)
+	assert.Empty(VerifyLedgerChain(entries))
+	assert.Empty(VerifyLedgerReports(entries, engine))
+
+	t.Log("modified payout report is detected")
+	fsReporter := NewFileSystemReporter(&config, options)
+	tampered := mock.NewPayoutReport(101, 7000)
+	assert.Nil(fsReporter.ReportPayouts([]common.PayoutReport{tampered}))
+	violations := VerifyLedgerReports(entries, engine)
+	assert.Len(violations, 1)
+	assert.Equal(int64(101), violations[0].Cycle)
+
+	t.Log("modified, removed and reordered entries are detected")
+	data, err := os.ReadFile(ledgerFile)
+	assert.Nil(err)
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+
+	modified := bytes.Replace(data, []byte(`"amount":"10"`), []byte(`"amount":"1000"`), 1)
+	assert.NotEqual(data, modified)
+	assert.Nil(os.WriteFile(ledgerFile, modified, 0600))
+	entries, err = ReadLedger(&config, options)
+	assert.Nil(err)
+	assert.Len(VerifyLedgerChain(entries), 1)
+
+	removed := bytes.Join(append(lines[:2:2], lines[3:]...), []byte("\n"))
+	assert.Nil(os.WriteFile(ledgerFile, removed, 0600))
+	entries, err = ReadLedger(&config, options)
+	assert.Nil(err)
+	assert.NotEmpty(VerifyLedgerChain(entries))
+
+	reordered := bytes.Join(append([][]byte{lines[1], lines[0]}, lines[2:]...), []byte("\n"))
+	assert.Nil(os.WriteFile(ledgerFile, reordered, 0600))
+	entries, err = ReadLedger(&config, options)
+	assert.Nil(err)
+	assert.NotEmpty(VerifyLedgerChain(entries))
+}
@@ -0,0 +1,78 @@
+package reporter_engines
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+)
+
+func loadEngine(engine enums.EReporterEngine, config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (common.ReporterEngine, error) {
+	switch engine {
+	case enums.REPORTER_ENGINE_FS, "":
+		return NewFileSystemReporter(config, options), nil
+	case enums.REPORTER_ENGINE_SQLITE:
+		return NewSqliteReporter(config, options)
+	default:
+		return nil, errors.Join(constants.ErrUnsupportedReporterEngine, fmt.Errorf("engine - %s", engine))
+	}
+}
+
+func loadSink(sink *configuration.RuntimeReportSink, config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (common.ReporterEngine, error) {
+	switch sink.Engine {
+	case enums.REPORTER_ENGINE_HTTP:
+		return NewHttpReporter(sink), nil
+	case enums.REPORTER_ENGINE_SQLITE:
+		// csv exports are already written by the primary fs engine
+		sinkConfig := *config
+		sinkConfig.Reports.ExportCsv = false
+		return loadEngine(sink.Engine, &sinkConfig, options)
+	default:
+		return loadEngine(sink.Engine, config, options)
+	}
+}
+
+// Load returns reporter engine selected in configuration, wrapped in ledger reporter if ledger is enabled
+// and in composite reporter if sinks are configured
+func Load(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (common.ReporterEngine, error) {
+	primary, err := loadEngine(config.Reports.Engine, config, options)
+	if err != nil {
+		return nil, err
+	}
+	if config.Reports.Ledger {
+		if primary, err = NewLedgerReporter(primary, config, options); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.Reports.Sinks) == 0 {
+		return primary, nil
+	}
+
+	sinks := make([]*ReportSink, 0, len(config.Reports.Sinks))
+	for i := range config.Reports.Sinks {
+		sinkConfiguration := &config.Reports.Sinks[i]
+		if options.DryRun && sinkConfiguration.Engine == enums.REPORTER_ENGINE_HTTP {
+			slog.Debug("skipping http report sink in dry run", "url", sinkConfiguration.Url)
+			continue
+		}
+		engine, err := loadSink(sinkConfiguration, config, options)
+		if err != nil {
+			return nil, errors.Join(constants.ErrReporterLoadFailed, fmt.Errorf("sink %d", i), err)
+		}
+		name := string(sinkConfiguration.Engine)
+		if sinkConfiguration.Url != "" {
+			name = fmt.Sprintf("%s:%s", name, sinkConfiguration.Url)
+		}
+		sinks = append(sinks, &ReportSink{
+			Name:       name,
+			Engine:     engine,
+			Policy:     sinkConfiguration.Policy,
+			RetryLimit: sinkConfiguration.RetryLimit,
+		})
+	}
+	return NewCompositeReporter(primary, sinks), nil
+}
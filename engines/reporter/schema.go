@@ -0,0 +1,136 @@
+package reporter_engines
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/gocarina/gocsv"
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+)
+
+type reportsMigration func(records [][]string) ([][]string, error)
+
+// reportsMigrations upgrade csv records of report files, migration i upgrades records from schema version i to i+1.
+// Files written before schema versioning have version 0. Migrations have to tolerate records which were already
+// upgraded. Never modify already released migrations, append new ones instead.
+var reportsMigrations = []reportsMigration{
+	func(records [][]string) ([][]string, error) {
+		return addReportsColumn(records, "staked_balance", "0"), nil
+	},
+	func(records [][]string) ([][]string, error) {
+		return addReportsColumn(records, "tx_fee_collected", ""), nil
+	},
+}
+
+// reportsSchema is stored next to report files and maps report file name to its schema version
+type reportsSchema map[string]int
+
+func getReportsSchemaVersion() int {
+	return len(reportsMigrations)
+}
+
+func addReportsColumn(records [][]string, column string, value string) [][]string {
+	if len(records) == 0 || lo.Contains(records[0], column) {
+		return records
+	}
+	for i := range records {
+		if i == 0 {
+			records[i] = append(records[i], column)
+			continue
+		}
+		records[i] = append(records[i], value)
+	}
+	return records
+}
+
+func migrateReports(records [][]string, version int) ([][]string, error) {
+	if version > getReportsSchemaVersion() {
+		return nil, errors.Join(constants.ErrReportsSchemaMigrationFailed, fmt.Errorf("report schema version %d is newer than supported %d", version, getReportsSchemaVersion()))
+	}
+	var err error
+	for i := version; i < getReportsSchemaVersion(); i++ {
+		if records, err = reportsMigrations[i](records); err != nil {
+			return nil, errors.Join(constants.ErrReportsSchemaMigrationFailed, fmt.Errorf("version %d", i+1), err)
+		}
+	}
+	return records, nil
+}
+
+func readReportsSchema(cycleDirectory string) (reportsSchema, error) {
+	data, err := os.ReadFile(path.Join(cycleDirectory, constants.REPORT_SCHEMA_FILE_NAME))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reportsSchema{}, nil
+		}
+		return nil, err
+	}
+	schema := reportsSchema{}
+	err = json.Unmarshal(data, &schema)
+	return schema, err
+}
+
+func writeReportsSchema(cycleDirectory string, fileName string) error {
+	schema, err := readReportsSchema(cycleDirectory)
+	if err != nil {
+		schema = reportsSchema{}
+	}
+	schema[fileName] = getReportsSchemaVersion()
+	data, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(cycleDirectory, constants.REPORT_SCHEMA_FILE_NAME), data, 0644)
+}
+
+// readReportsFile reads report file upgraded to the current schema, returns header of the upgraded file
+func readReportsFile(cycleDirectory string, fileName string) (header []string, reports []common.PayoutReport, err error) {
+	data, err := os.ReadFile(path.Join(cycleDirectory, fileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	schema, err := readReportsSchema(cycleDirectory)
+	if err != nil {
+		return nil, nil, errors.Join(constants.ErrReportsSchemaMigrationFailed, err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("empty report file")
+	}
+	if records, err = migrateReports(records, schema[fileName]); err != nil {
+		return nil, nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, nil, err
+	}
+	reports = make([]common.PayoutReport, 0)
+	err = gocsv.UnmarshalBytes(buffer.Bytes(), &reports)
+	return records[0], reports, err
+}
+
+// writeReportsFile writes reports with the current schema and records its version
+func writeReportsFile(cycleDirectory string, fileName string, reports []common.PayoutReport) error {
+	if err := os.MkdirAll(cycleDirectory, 0700); err != nil {
+		return err
+	}
+	data, err := gocsv.MarshalBytes(reports)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path.Join(cycleDirectory, fileName), data, 0644); err != nil {
+		return err
+	}
+	return writeReportsSchema(cycleDirectory, fileName)
+}
@@ -0,0 +1,62 @@
+package reporter_engines
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestReportsSchemaMigration(t *testing.T) {
+	assert := assert.New(t)
+	reportsDirectory := t.TempDir()
+	t.Setenv("REPORTS_DIRECTORY", reportsDirectory)
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	engine := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	cycleDirectory := path.Join(reportsDirectory, "100")
+	delegator := mock.GetRandomAddress()
+
+	t.Log("files written before schema versioning are upgraded on read")
+	legacy := "id,baker,timestamp,cycle,kind,op_kind,contract,token_id,fa_alias,fa_decimals,delegator,delegator_balance,recipient,amount,fee_rate,fee,tx_fee,op_hash,success,note\n" +
+		"0123456789abcdef,," + "2024-05-10T12:00:00Z,100,delegator reward,tez,,0,,0," + delegator.String() + ",1000," + delegator.String() + ",10,0.05,1,500,,true,\n"
+	assert.Nil(os.MkdirAll(cycleDirectory, 0700))
+	assert.Nil(os.WriteFile(path.Join(cycleDirectory, constants.PAYOUT_REPORT_FILE_NAME), []byte(legacy), 0644))
+	reports, err := engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 1)
+	assert.Equal(int64(10), reports[0].Amount.Int64())
+	assert.True(reports[0].StakedBalance.IsZero())
+	assert.Nil(reports[0].TxFeeCollected)
+
+	t.Log("written files record current schema version")
+	report := reports[0]
+	assert.Equal(enums.PAYOUT_KIND_DELEGATOR_REWARD, report.Kind)
+	report.StakedBalance = tezos.NewZ(500)
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{report}))
+	schema, err := readReportsSchema(cycleDirectory)
+	assert.Nil(err)
+	assert.Equal(getReportsSchemaVersion(), schema[constants.PAYOUT_REPORT_FILE_NAME])
+	reports, err = engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Equal(int64(500), reports[0].StakedBalance.Int64())
+	issues, err := engine.CheckReports(nil)
+	assert.Nil(err)
+	assert.False(lo.ContainsBy(issues, func(issue ReportIssue) bool { return issue.Kind == REPORT_ISSUE_KIND_SCHEMA }))
+
+	t.Log("files written by newer versions are rejected")
+	data, _ := json.Marshal(reportsSchema{constants.PAYOUT_REPORT_FILE_NAME: getReportsSchemaVersion() + 1})
+	assert.Nil(os.WriteFile(path.Join(cycleDirectory, constants.REPORT_SCHEMA_FILE_NAME), data, 0644))
+	_, err = engine.GetExistingReports(100)
+	assert.True(errors.Is(err, constants.ErrReportsSchemaMigrationFailed))
+}
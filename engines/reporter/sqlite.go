@@ -0,0 +1,366 @@
+package reporter_engines
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	sqliteFsImportMetadataKey = "fs_import"
+)
+
+// sqliteMigrations are applied in order, each migration is applied exactly once.
+// Never modify already released migrations, append new ones instead.
+var sqliteMigrations = []string{
+	`CREATE TABLE payouts (
+		cycle INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		baker TEXT NOT NULL,
+		delegator TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		tx_kind TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		op_hash TEXT NOT NULL,
+		success INTEGER NOT NULL,
+		timestamp TEXT NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (cycle, position)
+	);
+	CREATE INDEX payouts_delegator ON payouts (delegator);
+	CREATE INDEX payouts_recipient ON payouts (recipient);
+	CREATE TABLE invalid_payouts (
+		cycle INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		baker TEXT NOT NULL,
+		delegator TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		note TEXT NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (cycle, position)
+	);
+	CREATE TABLE cycle_summaries (
+		cycle INTEGER PRIMARY KEY,
+		timestamp TEXT NOT NULL,
+		data TEXT NOT NULL
+	);
+	CREATE TABLE metadata (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);`,
+}
+
+type SqliteReporter struct {
+	configuration *configuration.RuntimeConfiguration
+	options       *common.ReporterEngineOptions
+	db            *sql.DB
+	// csv and json exports written after successful database writes, nil if disabled
+	export *FsReporter
+}
+
+func NewSqliteReporter(config *configuration.RuntimeConfiguration, options *common.ReporterEngineOptions) (*SqliteReporter, error) {
+	fsReporter := NewFileSystemReporter(config, options)
+	reportsDirectory, err := fsReporter.getReportsDirectory()
+	if err != nil {
+		return nil, errors.Join(constants.ErrReporterLoadFailed, err)
+	}
+	databaseFile := path.Join(reportsDirectory, constants.REPORTS_DATABASE_FILE_NAME)
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", databaseFile))
+	if err != nil {
+		return nil, errors.Join(constants.ErrReporterLoadFailed, err)
+	}
+	// sqlite allows single writer, sharing one connection avoids busy errors within the process
+	db.SetMaxOpenConns(1)
+
+	engine := &SqliteReporter{
+		configuration: config,
+		options:       options,
+		db:            db,
+	}
+	if config.Reports.ExportCsv {
+		engine.export = fsReporter
+	}
+	if err := engine.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := engine.importFsReports(fsReporter); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return engine, nil
+}
+
+func (engine *SqliteReporter) Close() error {
+	return engine.db.Close()
+}
+
+func (engine *SqliteReporter) migrate() error {
+	if _, err := engine.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		return errors.Join(constants.ErrReportsDatabaseMigrationFailed, err)
+	}
+	var version int
+	if err := engine.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return errors.Join(constants.ErrReportsDatabaseMigrationFailed, err)
+	}
+	if version > len(sqliteMigrations) {
+		return errors.Join(constants.ErrReportsDatabaseMigrationFailed, fmt.Errorf("database schema version %d is newer than supported %d", version, len(sqliteMigrations)))
+	}
+
+	for i := version; i < len(sqliteMigrations); i++ {
+		slog.Debug("migrating reports database", "version", i+1)
+		err := engine.inTransaction(func(tx *sql.Tx) error {
+			if _, err := tx.Exec(sqliteMigrations[i]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, i+1, time.Now().UTC().Format(time.RFC3339))
+			return err
+		})
+		if err != nil {
+			return errors.Join(constants.ErrReportsDatabaseMigrationFailed, fmt.Errorf("version %d", i+1), err)
+		}
+	}
+	return nil
+}
+
+// importFsReports imports existing csv and json reports once, when the database is used for the first time
+func (engine *SqliteReporter) importFsReports(fsReporter *FsReporter) error {
+	var imported string
+	err := engine.db.QueryRow(`SELECT value FROM metadata WHERE key = ?`, sqliteFsImportMetadataKey).Scan(&imported)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return errors.Join(constants.ErrReportsImportFailed, err)
+	}
+
+	cycles, err := fsReporter.getReportedCycles()
+	if err != nil {
+		return errors.Join(constants.ErrReportsImportFailed, err)
+	}
+	err = engine.inTransaction(func(tx *sql.Tx) error {
+		for _, cycle := range cycles {
+			reports, err := fsReporter.GetExistingReports(cycle)
+			if err != nil && !os.IsNotExist(err) {
+				return errors.Join(fmt.Errorf("cycle %d", cycle), err)
+			}
+			if err := replacePayoutReports(tx, cycle, reports); err != nil {
+				return err
+			}
+			invalid, err := fsReporter.getExistingInvalidReports(cycle)
+			if err != nil && !os.IsNotExist(err) {
+				return errors.Join(fmt.Errorf("cycle %d", cycle), err)
+			}
+			if err := replaceInvalidPayoutReports(tx, cycle, invalid); err != nil {
+				return err
+			}
+			summary, err := fsReporter.GetExistingCycleSummary(cycle)
+			switch {
+			case err == nil:
+				if err := replaceCycleSummary(tx, summary); err != nil {
+					return err
+				}
+			case !os.IsNotExist(err):
+				return errors.Join(fmt.Errorf("cycle %d", cycle), err)
+			}
+		}
+		_, err := tx.Exec(`INSERT INTO metadata (key, value) VALUES (?, ?)`, sqliteFsImportMetadataKey, time.Now().UTC().Format(time.RFC3339))
+		return err
+	})
+	if err != nil {
+		return errors.Join(constants.ErrReportsImportFailed, err)
+	}
+	if len(cycles) > 0 {
+		slog.Info("imported file system reports into reports database", "cycles", len(cycles))
+	}
+	return nil
+}
+
+func (engine *SqliteReporter) inTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := engine.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func replacePayoutReports(tx *sql.Tx, cycle int64, reports []common.PayoutReport) error {
+	if _, err := tx.Exec(`DELETE FROM payouts WHERE cycle = ?`, cycle); err != nil {
+		return err
+	}
+	for i, report := range reports {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO payouts (cycle, position, baker, delegator, recipient, kind, tx_kind, amount, op_hash, success, timestamp, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			cycle, i, report.Baker.String(), report.Delegator.String(), report.Recipient.String(), string(report.Kind), string(report.TxKind),
+			report.Amount.Int64(), report.OpHash.String(), report.IsSuccess, report.Timestamp.UTC().Format(time.RFC3339), string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceInvalidPayoutReports(tx *sql.Tx, cycle int64, reports []common.PayoutReport) error {
+	if _, err := tx.Exec(`DELETE FROM invalid_payouts WHERE cycle = ?`, cycle); err != nil {
+		return err
+	}
+	for i, report := range reports {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO invalid_payouts (cycle, position, baker, delegator, recipient, kind, note, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			cycle, i, report.Baker.String(), report.Delegator.String(), report.Recipient.String(), string(report.Kind), report.Note, string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceCycleSummary(tx *sql.Tx, summary *common.CyclePayoutSummary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO cycle_summaries (cycle, timestamp, data) VALUES (?, ?, ?) ON CONFLICT (cycle) DO UPDATE SET timestamp = excluded.timestamp, data = excluded.data`,
+		summary.Cycle, summary.Timestamp.UTC().Format(time.RFC3339), string(data))
+	return err
+}
+
+func (engine *SqliteReporter) GetExistingReports(cycle int64) ([]common.PayoutReport, error) {
+	rows, err := engine.db.Query(`SELECT data FROM payouts WHERE cycle = ? ORDER BY position`, cycle)
+	if err != nil {
+		return []common.PayoutReport{}, err
+	}
+	defer rows.Close()
+
+	reports := make([]common.PayoutReport, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return []common.PayoutReport{}, err
+		}
+		var report common.PayoutReport
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			return []common.PayoutReport{}, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+func (engine *SqliteReporter) ReportPayouts(payouts []common.PayoutReport) error {
+	if len(payouts) == 0 {
+		return nil
+	}
+	sort.Slice(payouts, func(i, j int) bool {
+		return !payouts[i].Amount.IsLess(payouts[j].Amount)
+	})
+	cyclesToBeWritten := lo.Uniq(lo.Map(payouts, func(pr common.PayoutReport, _ int) int64 {
+		return pr.Cycle
+	}))
+
+	err := engine.inTransaction(func(tx *sql.Tx) error {
+		for _, cycle := range cyclesToBeWritten {
+			reports := lo.Filter(payouts, func(payout common.PayoutReport, _ int) bool {
+				return payout.Cycle == cycle
+			})
+			if err := replacePayoutReports(tx, cycle, reports); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(constants.ErrReportsWriteFailed, err)
+	}
+	if engine.export != nil {
+		if err := engine.export.ReportPayouts(payouts); err != nil {
+			slog.Warn("failed to export payout reports", "error", err.Error())
+		}
+	}
+	return nil
+}
+
+func (engine *SqliteReporter) ReportInvalidPayouts(payouts []common.PayoutRecipe) error {
+	invalid := utils.OnlyInvalidPayouts(payouts)
+	if len(invalid) == 0 {
+		return nil
+	}
+	cyclesToBeWritten := lo.Uniq(lo.Map(invalid, func(pr common.PayoutRecipe, _ int) int64 {
+		return pr.Cycle
+	}))
+
+	err := engine.inTransaction(func(tx *sql.Tx) error {
+		for _, cycle := range cyclesToBeWritten {
+			reports := lo.Map(utils.FilterPayoutsByCycle(invalid, cycle), mapPayoutRecipeToPayoutReport)
+			if err := replaceInvalidPayoutReports(tx, cycle, reports); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(constants.ErrReportsWriteFailed, err)
+	}
+	if engine.export != nil {
+		if err := engine.export.ReportInvalidPayouts(payouts); err != nil {
+			slog.Warn("failed to export invalid payout reports", "error", err.Error())
+		}
+	}
+	return nil
+}
+
+func (engine *SqliteReporter) ReportCycleSummary(summary common.CyclePayoutSummary) error {
+	err := engine.inTransaction(func(tx *sql.Tx) error {
+		return replaceCycleSummary(tx, &summary)
+	})
+	if err != nil {
+		return errors.Join(constants.ErrReportsWriteFailed, err)
+	}
+	if engine.export != nil {
+		if err := engine.export.ReportCycleSummary(summary); err != nil {
+			slog.Warn("failed to export cycle summary", "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// GetExistingCycleSummary returns error matching os.ErrNotExist if there is no summary for the cycle
+func (engine *SqliteReporter) GetExistingCycleSummary(cycle int64) (*common.CyclePayoutSummary, error) {
+	var data string
+	err := engine.db.QueryRow(`SELECT data FROM cycle_summaries WHERE cycle = ?`, cycle).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Join(os.ErrNotExist, fmt.Errorf("no summary for cycle %d", cycle))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var summary common.CyclePayoutSummary
+	err = json.Unmarshal([]byte(data), &summary)
+	return &summary, err
+}
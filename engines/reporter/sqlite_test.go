@@ -0,0 +1,59 @@
+package reporter_engines
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/test/mock"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestSqliteReporter(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("REPORTS_DIRECTORY", t.TempDir())
+
+	config := configuration.GetDefaultRuntimeConfiguration()
+	config.Reports.Engine = enums.REPORTER_ENGINE_SQLITE
+
+	t.Log("import existing file system reports")
+	fsReporter := NewFileSystemReporter(&config, &common.ReporterEngineOptions{})
+	assert.Nil(fsReporter.ReportPayouts([]common.PayoutReport{mock.NewPayoutReport(100, 10), mock.NewPayoutReport(100, 20)}))
+	assert.Nil(fsReporter.ReportCycleSummary(common.CyclePayoutSummary{Cycle: 100, DistributedRewards: tezos.NewZ(30), Timestamp: mock.PayoutReportTimestamp}))
+
+	engine, err := NewSqliteReporter(&config, &common.ReporterEngineOptions{})
+	assert.Nil(err)
+	reports, err := engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 2)
+	assert.Equal(int64(20), reports[0].Amount.Int64())
+	summary, err := engine.GetExistingCycleSummary(100)
+	assert.Nil(err)
+	assert.Equal(int64(30), summary.DistributedRewards.Int64())
+
+	t.Log("replace reports of a cycle")
+	assert.Nil(engine.ReportPayouts([]common.PayoutReport{mock.NewPayoutReport(100, 5), mock.NewPayoutReport(101, 7)}))
+	reports, err = engine.GetExistingReports(100)
+	assert.Nil(err)
+	assert.Len(reports, 1)
+	assert.Equal(int64(5), reports[0].Amount.Int64())
+	assert.True(reports[0].Timestamp.Equal(mock.PayoutReportTimestamp))
+	_, err = engine.GetExistingCycleSummary(101)
+	assert.ErrorIs(err, os.ErrNotExist)
+	assert.Nil(engine.Close())
+
+	t.Log("import runs only once")
+	assert.Nil(fsReporter.ReportPayouts([]common.PayoutReport{mock.NewPayoutReport(102, 1)}))
+	engine, err = NewSqliteReporter(&config, &common.ReporterEngineOptions{})
+	assert.Nil(err)
+	reports, err = engine.GetExistingReports(102)
+	assert.Nil(err)
+	assert.Empty(reports)
+	reports, err = engine.GetExistingReports(101)
+	assert.Nil(err)
+	assert.Len(reports, 1)
+	assert.Nil(engine.Close())
+}
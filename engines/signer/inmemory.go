@@ -14,7 +14,7 @@ type InMemorySigner struct {
 }
 
 func InitInMemorySigner(key string) (*InMemorySigner, error) {
-	tkey, err := tezos.ParsePrivateKey(key)
+	tkey, err := parsePrivateKey(key)
 	if err != nil {
 		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
 	}
@@ -42,6 +42,11 @@ func (inMemSigner *InMemorySigner) Sign(op *codec.Op) error {
 	return nil
 }
 
+func (inMemSigner *InMemorySigner) SignPayload(data []byte) (tezos.Signature, error) {
+	digest := tezos.Digest(data)
+	return inMemSigner.Key.Sign(digest[:])
+}
+
 func (inMemSigner *InMemorySigner) GetSigner() signer.Signer {
 	return signer.NewFromKey(inMemSigner.Key)
 }
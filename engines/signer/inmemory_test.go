@@ -0,0 +1,31 @@
+package signer_engines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestInitInMemorySignerWithEncryptedKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.NoError(err)
+	encrypted, err := key.Encrypt(func() ([]byte, error) { return []byte("secret"), nil })
+	assert.NoError(err)
+
+	t.Setenv(PRIVATE_KEY_PASSPHRASE_ENV, "secret")
+	signer, err := InitInMemorySigner(encrypted)
+	assert.NoError(err)
+	assert.Equal(key.Address(), signer.GetPKH())
+
+	signer, err = InitInMemorySigner("encrypted:" + encrypted)
+	assert.NoError(err)
+	assert.Equal(key.Address(), signer.GetPKH())
+
+	t.Setenv(PRIVATE_KEY_PASSPHRASE_ENV, "wrong")
+	_, err = InitInMemorySigner(encrypted)
+	assert.ErrorIs(err, constants.ErrSignerLoadFailed)
+}
@@ -43,6 +43,22 @@ func Load(kind string) (common.SignerEngine, error) {
 			return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("failed to unmarshal remote specs"), err)
 		}
 		return InitRemoteSignerFromSpecs(remoteSpecs)
+	case string(enums.WALLET_MODE_TRANSIT_SIGNER2):
+		fallthrough
+	case string(enums.WALLET_MODE_TRANSIT_SIGNER):
+		slog.Debug("creating TransitSigner")
+		transitSpecsFile := state.Global.GetTransitSpecsFilePath()
+		slog.Debug("loading transit signer specification from file", "path", transitSpecsFile)
+		transitSpecsBytes, err := os.ReadFile(transitSpecsFile)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+		}
+		transitSpecs := TransitSignerSpecs{}
+		err = hjson.Unmarshal(transitSpecsBytes, &transitSpecs)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("failed to unmarshal transit signer specs"), err)
+		}
+		return InitTransitSignerFromSpecs(transitSpecs)
 	}
 
 	if strings.HasPrefix(kind, "key:") {
@@ -50,6 +66,11 @@ func Load(kind string) (common.SignerEngine, error) {
 		return InitInMemorySigner(strings.TrimPrefix(kind, "key:"))
 	}
 
+	if strings.HasPrefix(kind, "public:") {
+		slog.Debug("creating PublicKeySigner from parameters")
+		return InitPublicKeySigner(strings.TrimPrefix(kind, "public:"))
+	}
+
 	if strings.HasPrefix(kind, "remote:") {
 		slog.Debug("creating RemoteSigner from parameters")
 		specs := strings.TrimPrefix(kind, "remote:")
@@ -62,3 +83,16 @@ func Load(kind string) (common.SignerEngine, error) {
 
 	return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("invalid payout wallet specification: '%s'", kind))
 }
+
+// LoadMultisigSigners loads signers approving multisig proposals, specifications are the same as for payout wallet
+func LoadMultisigSigners(specs []string) ([]common.SignerEngine, error) {
+	signers := make([]common.SignerEngine, 0, len(specs))
+	for _, spec := range specs {
+		signer, err := Load(spec)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("multisig signer '%s'", spec), err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
@@ -0,0 +1,120 @@
+package signer_engines
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	PRIVATE_KEY_PASSPHRASE_ENV    = "PRIVATE_KEY_PASSPHRASE"
+	PRIVATE_KEY_PASSPHRASE_FD_ENV = "PRIVATE_KEY_PASSPHRASE_FD"
+)
+
+var passphraseFd = -1
+
+// SetPassphraseFd sets file descriptor the private key passphrase is read from
+func SetPassphraseFd(fd int) {
+	passphraseFd = fd
+}
+
+// passphraseFiles keep descriptors referenced, closing (or collecting) os.File would close descriptor owned by the caller
+var (
+	passphraseFiles    = map[int]*os.File{}
+	passphraseFilesMtx sync.Mutex
+)
+
+// readPassphraseFromFd reads single line from the descriptor, it is read byte by byte to not consume data after it
+func readPassphraseFromFd(fd int) ([]byte, error) {
+	passphraseFilesMtx.Lock()
+	defer passphraseFilesMtx.Unlock()
+	file, ok := passphraseFiles[fd]
+	if !ok {
+		if file = os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd)); file == nil {
+			return nil, fmt.Errorf("invalid file descriptor %d", fd)
+		}
+		passphraseFiles[fd] = file
+	}
+
+	line := make([]byte, 0, 64)
+	buffer := make([]byte, 1)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			if buffer[0] == '\n' {
+				break
+			}
+			line = append(line, buffer[0])
+			continue
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bytes.TrimRight(line, "\r"), nil
+}
+
+func getPassphraseFd() (int, error) {
+	if passphraseFd >= 0 {
+		return passphraseFd, nil
+	}
+	if fdEnv, ok := os.LookupEnv(PRIVATE_KEY_PASSPHRASE_FD_ENV); ok {
+		fd, err := strconv.Atoi(fdEnv)
+		if err != nil {
+			return -1, fmt.Errorf("invalid %s value '%s'", PRIVATE_KEY_PASSPHRASE_FD_ENV, fdEnv)
+		}
+		return fd, nil
+	}
+	return -1, nil
+}
+
+// GetPassphrase looks for the private key passphrase in the environment, the passphrase file descriptor
+// and falls back to interactive prompt when running in terminal
+func GetPassphrase() ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(PRIVATE_KEY_PASSPHRASE_ENV); ok {
+		return []byte(passphrase), nil
+	}
+
+	fd, err := getPassphraseFd()
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerPassphraseUnavailable, err)
+	}
+	if fd >= 0 {
+		passphrase, err := readPassphraseFromFd(fd)
+		if err != nil {
+			return nil, errors.Join(constants.ErrSignerPassphraseUnavailable, err)
+		}
+		return passphrase, nil
+	}
+
+	if utils.IsTty() {
+		passphrase := ""
+		if err := survey.AskOne(&survey.Password{Message: "Payout wallet private key passphrase:"}, &passphrase); err != nil {
+			return nil, errors.Join(constants.ErrSignerPassphraseUnavailable, err)
+		}
+		return []byte(passphrase), nil
+	}
+	return nil, constants.ErrSignerPassphraseUnavailable
+}
+
+func parsePrivateKey(key string) (tezos.PrivateKey, error) {
+	// octez-client stores keys prefixed with their encryption status
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "unencrypted:"), "encrypted:")
+	if tezos.IsEncryptedKey(key) {
+		return tezos.ParseEncryptedPrivateKey(key, GetPassphrase)
+	}
+	return tezos.ParsePrivateKey(key)
+}
@@ -0,0 +1,29 @@
+package signer_engines
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPassphraseFromFd(t *testing.T) {
+	assert := assert.New(t)
+
+	r, w, err := os.Pipe()
+	assert.Nil(err)
+	defer r.Close()
+	_, err = w.WriteString("secret\r\nrest")
+	assert.Nil(err)
+	assert.Nil(w.Close())
+
+	passphrase, err := readPassphraseFromFd(int(r.Fd()))
+	assert.Nil(err)
+	assert.Equal("secret", string(passphrase))
+
+	// descriptor stays open and data after the passphrase is not consumed
+	rest, err := io.ReadAll(r)
+	assert.Nil(err)
+	assert.Equal("rest", string(rest))
+}
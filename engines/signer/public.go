@@ -0,0 +1,71 @@
+package signer_engines
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// PublicKeySigner knows only the public key of the payout wallet.
+// It is used on the online host when the private key is kept on an offline machine.
+type PublicKeySigner struct {
+	Key tezos.Key
+}
+
+func InitPublicKeySigner(key string) (*PublicKeySigner, error) {
+	tkey, err := tezos.ParseKey(key)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	return &PublicKeySigner{
+		Key: tkey,
+	}, nil
+}
+
+func (pkSigner *PublicKeySigner) GetId() string {
+	return "PublicKeySigner"
+}
+
+func (pkSigner *PublicKeySigner) GetPKH() tezos.Address {
+	return pkSigner.Key.Address()
+}
+
+func (pkSigner *PublicKeySigner) GetKey() tezos.Key {
+	return pkSigner.Key
+}
+
+func (pkSigner *PublicKeySigner) Sign(op *codec.Op) error {
+	return constants.ErrSignerCannotSign
+}
+
+func (pkSigner *PublicKeySigner) GetSigner() signer.Signer {
+	return &publicKeyOnlySigner{key: pkSigner.Key}
+}
+
+type publicKeyOnlySigner struct {
+	key tezos.Key
+}
+
+func (s *publicKeyOnlySigner) ListAddresses(context.Context) ([]tezos.Address, error) {
+	return []tezos.Address{s.key.Address()}, nil
+}
+
+func (s *publicKeyOnlySigner) GetKey(context.Context, tezos.Address) (tezos.Key, error) {
+	return s.key, nil
+}
+
+func (s *publicKeyOnlySigner) SignMessage(context.Context, tezos.Address, string) (tezos.Signature, error) {
+	return tezos.InvalidSignature, constants.ErrSignerCannotSign
+}
+
+func (s *publicKeyOnlySigner) SignOperation(context.Context, tezos.Address, *codec.Op) (tezos.Signature, error) {
+	return tezos.InvalidSignature, constants.ErrSignerCannotSign
+}
+
+func (s *publicKeyOnlySigner) SignBlock(context.Context, tezos.Address, *codec.BlockHeader) (tezos.Signature, error) {
+	return tezos.InvalidSignature, constants.ErrSignerCannotSign
+}
@@ -1,9 +1,17 @@
 package signer_engines
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/trilitech/tzgo/codec"
@@ -21,6 +29,8 @@ type RemoteSigner struct {
 	Address tezos.Address
 	Remote  *remote.RemoteSigner
 	Key     tezos.Key
+	Url     string
+	client  *http.Client
 }
 
 func InitRemoteSignerFromSpecs(specs RemoteSignerSpecs) (*RemoteSigner, error) {
@@ -49,6 +59,8 @@ func InitRemoteSigner(address string, remoteUrl string) (*RemoteSigner, error) {
 		Address: addr,
 		Remote:  rs,
 		Key:     key,
+		Url:     remoteUrl,
+		client:  &http.Client{Timeout: 30 * time.Second},
 	}, nil
 }
 
@@ -76,3 +88,33 @@ func (remoteSigner *RemoteSigner) Sign(op *codec.Op) error {
 	op.WithSignature(sig)
 	return nil
 }
+
+type remoteSignerSignature struct {
+	Signature tezos.Signature `json:"signature"`
+}
+
+// SignPayload sends the data to the remote signer as is, the remote signer has to allow the 0x05 magic byte
+func (remoteSigner *RemoteSigner) SignPayload(data []byte) (tezos.Signature, error) {
+	body, err := json.Marshal(hex.EncodeToString(data))
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	signUrl, err := url.JoinPath(remoteSigner.Url, "keys", remoteSigner.Address.String())
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	resp, err := remoteSigner.client.Post(signUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return tezos.InvalidSignature, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return tezos.InvalidSignature, fmt.Errorf("remote signer returned %d - %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	result := remoteSignerSignature{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return tezos.InvalidSignature, err
+	}
+	return result.Signature, nil
+}
@@ -0,0 +1,383 @@
+package signer_engines
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/signer"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+const (
+	TRANSIT_SIGNER_TOKEN_ENV     = "TRANSIT_SIGNER_TOKEN"
+	DEFAULT_TRANSIT_MOUNT        = "transit"
+	TRANSIT_TOKEN_RENEW_BEFORE   = 5 * time.Minute
+	DEFAULT_TRANSIT_HTTP_TIMEOUT = 30 * time.Second
+)
+
+type TransitSignerSpecs struct {
+	Url string `json:"url"`
+	// name of the transit secrets engine mount, defaults to 'transit'
+	Mount string `json:"mount,omitempty"`
+	Key   string `json:"key"`
+	// token can be also provided through TRANSIT_SIGNER_TOKEN environment variable or token_file
+	Token     string `json:"token,omitempty"`
+	TokenFile string `json:"token_file,omitempty"`
+	// optional, useful for vault enterprise namespaces
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (specs *TransitSignerSpecs) getToken() (string, error) {
+	if token, ok := os.LookupEnv(TRANSIT_SIGNER_TOKEN_ENV); ok {
+		return token, nil
+	}
+	if specs.TokenFile != "" {
+		tokenBytes, err := os.ReadFile(specs.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(tokenBytes)), nil
+	}
+	if specs.Token == "" {
+		return "", errors.New("transit signer token not specified")
+	}
+	return specs.Token, nil
+}
+
+type TransitSigner struct {
+	Address tezos.Address
+	Key     tezos.Key
+
+	baseUrl   *url.URL
+	mount     string
+	keyName   string
+	namespace string
+	client    *http.Client
+
+	mtx             sync.Mutex
+	token           string
+	tokenExpiresAt  time.Time
+	tokenRenewable  bool
+	tokenKnowsTTL   bool
+	keyVersion      int64
+	isEcdsaP256Key  bool
+	renewBeforeTime time.Duration
+}
+
+type transitResponse[T any] struct {
+	Data   T            `json:"data"`
+	Auth   *transitAuth `json:"auth,omitempty"`
+	Errors []string     `json:"errors,omitempty"`
+}
+
+type transitAuth struct {
+	LeaseDuration int64 `json:"lease_duration"`
+	Renewable     bool  `json:"renewable"`
+}
+
+type transitTokenLookup struct {
+	TTL       int64 `json:"ttl"`
+	Renewable bool  `json:"renewable"`
+}
+
+type transitKeyVersion struct {
+	PublicKey string `json:"public_key"`
+}
+
+type transitKey struct {
+	Type          string                       `json:"type"`
+	LatestVersion int64                        `json:"latest_version"`
+	Keys          map[string]transitKeyVersion `json:"keys"`
+}
+
+type transitSignature struct {
+	Signature string `json:"signature"`
+}
+
+func InitTransitSignerFromSpecs(specs TransitSignerSpecs) (*TransitSigner, error) {
+	baseUrl, err := url.Parse(specs.Url)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	if specs.Key == "" {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, errors.New("transit signer key name not specified"))
+	}
+	token, err := specs.getToken()
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	mount := specs.Mount
+	if mount == "" {
+		mount = DEFAULT_TRANSIT_MOUNT
+	}
+
+	transitSigner := &TransitSigner{
+		baseUrl:         baseUrl,
+		mount:           strings.Trim(mount, "/"),
+		keyName:         specs.Key,
+		namespace:       specs.Namespace,
+		client:          &http.Client{Timeout: DEFAULT_TRANSIT_HTTP_TIMEOUT},
+		token:           token,
+		renewBeforeTime: TRANSIT_TOKEN_RENEW_BEFORE,
+	}
+	if err := transitSigner.lookupToken(); err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	if err := transitSigner.loadKey(); err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, err)
+	}
+	return transitSigner, nil
+}
+
+func (transitSigner *TransitSigner) request(method string, path string, body any, result any) error {
+	rel, err := url.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return err
+	}
+	base := *transitSigner.baseUrl
+	base.Path = strings.TrimSuffix(base.Path, "/") + "/"
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequest(method, base.ResolveReference(rel).String(), reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("X-Vault-Token", transitSigner.token)
+	request.Header.Set("Content-Type", "application/json")
+	if transitSigner.namespace != "" {
+		request.Header.Set("X-Vault-Namespace", transitSigner.namespace)
+	}
+
+	response, err := transitSigner.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		errResponse := transitResponse[json.RawMessage]{}
+		if json.Unmarshal(data, &errResponse) == nil && len(errResponse.Errors) > 0 {
+			return fmt.Errorf("transit signer returned %d: %s", response.StatusCode, strings.Join(errResponse.Errors, "; "))
+		}
+		return fmt.Errorf("transit signer returned %d", response.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (transitSigner *TransitSigner) lookupToken() error {
+	lookup := transitResponse[transitTokenLookup]{}
+	if err := transitSigner.request(http.MethodGet, "v1/auth/token/lookup-self", nil, &lookup); err != nil {
+		return err
+	}
+	transitSigner.setTokenExpiration(lookup.Data.TTL, lookup.Data.Renewable)
+	return nil
+}
+
+func (transitSigner *TransitSigner) setTokenExpiration(ttl int64, renewable bool) {
+	transitSigner.tokenRenewable = renewable
+	// root and periodic-less tokens without ttl never expire
+	transitSigner.tokenKnowsTTL = ttl > 0
+	transitSigner.tokenExpiresAt = time.Now().Add(time.Duration(ttl) * time.Second)
+}
+
+// renewTokenIfNeeded renews the token when it is close to expiration
+func (transitSigner *TransitSigner) renewTokenIfNeeded() error {
+	if !transitSigner.tokenKnowsTTL || !transitSigner.tokenRenewable {
+		return nil
+	}
+	if time.Until(transitSigner.tokenExpiresAt) > transitSigner.renewBeforeTime {
+		return nil
+	}
+	renewal := transitResponse[json.RawMessage]{}
+	if err := transitSigner.request(http.MethodPost, "v1/auth/token/renew-self", map[string]any{}, &renewal); err != nil {
+		return errors.Join(errors.New("failed to renew transit signer token"), err)
+	}
+	if renewal.Auth == nil {
+		return errors.New("failed to renew transit signer token - missing auth data")
+	}
+	transitSigner.setTokenExpiration(renewal.Auth.LeaseDuration, renewal.Auth.Renewable)
+	return nil
+}
+
+func (transitSigner *TransitSigner) loadKey() error {
+	keyResponse := transitResponse[transitKey]{}
+	if err := transitSigner.request(http.MethodGet, fmt.Sprintf("v1/%s/keys/%s", transitSigner.mount, url.PathEscape(transitSigner.keyName)), nil, &keyResponse); err != nil {
+		return err
+	}
+	keyData := keyResponse.Data
+	version, ok := keyData.Keys[fmt.Sprintf("%d", keyData.LatestVersion)]
+	if !ok {
+		return fmt.Errorf("transit key '%s' version %d not found", transitSigner.keyName, keyData.LatestVersion)
+	}
+
+	var key tezos.Key
+	switch keyData.Type {
+	case "ed25519":
+		publicKey, err := base64.StdEncoding.DecodeString(version.PublicKey)
+		if err != nil {
+			return err
+		}
+		key = tezos.Key{Type: tezos.KeyTypeEd25519, Data: publicKey}
+	case "ecdsa-p256":
+		block, _ := pem.Decode([]byte(version.PublicKey))
+		if block == nil {
+			return errors.New("invalid ecdsa-p256 public key")
+		}
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		ecdsaKey, ok := parsed.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("invalid ecdsa-p256 public key")
+		}
+		key = tezos.Key{Type: tezos.KeyTypeP256, Data: elliptic.MarshalCompressed(elliptic.P256(), ecdsaKey.X, ecdsaKey.Y)}
+		transitSigner.isEcdsaP256Key = true
+	default:
+		return fmt.Errorf("unsupported transit key type '%s'", keyData.Type)
+	}
+	if !key.IsValid() {
+		return errors.New("invalid transit public key")
+	}
+
+	transitSigner.Key = key
+	transitSigner.Address = key.Address()
+	transitSigner.keyVersion = keyData.LatestVersion
+	return nil
+}
+
+func (transitSigner *TransitSigner) signDigest(digest []byte) (tezos.Signature, error) {
+	transitSigner.mtx.Lock()
+	defer transitSigner.mtx.Unlock()
+
+	if err := transitSigner.renewTokenIfNeeded(); err != nil {
+		return tezos.InvalidSignature, err
+	}
+
+	body := map[string]any{
+		"input":       base64.StdEncoding.EncodeToString(digest),
+		"key_version": transitSigner.keyVersion,
+	}
+	if transitSigner.isEcdsaP256Key {
+		// digest is already blake2b hash, tezos expects raw r||s signature
+		body["prehashed"] = true
+		body["hash_algorithm"] = "sha2-256"
+		body["marshaling_algorithm"] = "jws"
+	}
+	signResponse := transitResponse[transitSignature]{}
+	if err := transitSigner.request(http.MethodPost, fmt.Sprintf("v1/%s/sign/%s", transitSigner.mount, url.PathEscape(transitSigner.keyName)), body, &signResponse); err != nil {
+		return tezos.InvalidSignature, err
+	}
+
+	// vault:v<version>:<signature>
+	parts := strings.Split(signResponse.Data.Signature, ":")
+	if len(parts) != 3 {
+		return tezos.InvalidSignature, errors.New("invalid transit signature format")
+	}
+	signatureData, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		if signatureData, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+			return tezos.InvalidSignature, err
+		}
+	}
+
+	signature := tezos.Signature{Type: tezos.SignatureTypeEd25519, Data: signatureData}
+	if transitSigner.isEcdsaP256Key {
+		signature.Type = tezos.SignatureTypeP256
+	}
+	if err := transitSigner.Key.Verify(digest, signature); err != nil {
+		return tezos.InvalidSignature, errors.Join(errors.New("transit signature verification failed"), err)
+	}
+	return signature, nil
+}
+
+func (transitSigner *TransitSigner) GetId() string {
+	return "TransitSigner"
+}
+
+func (transitSigner *TransitSigner) GetPKH() tezos.Address {
+	return transitSigner.Address
+}
+
+func (transitSigner *TransitSigner) GetKey() tezos.Key {
+	return transitSigner.Key
+}
+
+func (transitSigner *TransitSigner) GetSigner() signer.Signer {
+	return &transitSignerAdapter{transitSigner: transitSigner}
+}
+
+func (transitSigner *TransitSigner) Sign(op *codec.Op) error {
+	sig, err := transitSigner.signDigest(op.Digest())
+	if err != nil {
+		return err
+	}
+	op.WithSignature(sig)
+	return nil
+}
+
+func (transitSigner *TransitSigner) SignPayload(data []byte) (tezos.Signature, error) {
+	digest := tezos.Digest(data)
+	return transitSigner.signDigest(digest[:])
+}
+
+// transitSignerAdapter exposes TransitSigner through tzgo signer interface
+type transitSignerAdapter struct {
+	transitSigner *TransitSigner
+}
+
+func (adapter *transitSignerAdapter) ListAddresses(context.Context) ([]tezos.Address, error) {
+	return []tezos.Address{adapter.transitSigner.Address}, nil
+}
+
+func (adapter *transitSignerAdapter) GetKey(_ context.Context, address tezos.Address) (tezos.Key, error) {
+	if !address.Equal(adapter.transitSigner.Address) {
+		return tezos.InvalidKey, signer.ErrAddressMismatch
+	}
+	return adapter.transitSigner.Key, nil
+}
+
+func (adapter *transitSignerAdapter) SignMessage(context.Context, tezos.Address, string) (tezos.Signature, error) {
+	return tezos.InvalidSignature, constants.ErrNotImplemented
+}
+
+func (adapter *transitSignerAdapter) SignOperation(_ context.Context, address tezos.Address, op *codec.Op) (tezos.Signature, error) {
+	if !address.Equal(adapter.transitSigner.Address) {
+		return tezos.InvalidSignature, signer.ErrAddressMismatch
+	}
+	return adapter.transitSigner.signDigest(op.Digest())
+}
+
+func (adapter *transitSignerAdapter) SignBlock(context.Context, tezos.Address, *codec.BlockHeader) (tezos.Signature, error) {
+	return tezos.InvalidSignature, constants.ErrNotImplemented
+}
@@ -0,0 +1,117 @@
+package signer_engines
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type transitTestServer struct {
+	*httptest.Server
+	key      ed25519.PrivateKey
+	token    string
+	renewals atomic.Int32
+	signs    atomic.Int32
+}
+
+func newTransitTestServer(t *testing.T, token string, ttl int64) *transitTestServer {
+	_, key, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	server := &transitTestServer{key: key, token: token}
+
+	writeJson := func(w http.ResponseWriter, data any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/auth/token/lookup-self", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, map[string]any{"data": map[string]any{"ttl": ttl, "renewable": true}})
+	})
+	mux.HandleFunc("POST /v1/auth/token/renew-self", func(w http.ResponseWriter, r *http.Request) {
+		server.renewals.Add(1)
+		writeJson(w, map[string]any{"auth": map[string]any{"lease_duration": 3600, "renewable": true}})
+	})
+	mux.HandleFunc("GET /v1/transit/keys/payout", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, map[string]any{"data": map[string]any{
+			"type":           "ed25519",
+			"latest_version": 1,
+			"keys": map[string]any{
+				"1": map[string]any{"public_key": base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))},
+			},
+		}})
+	})
+	mux.HandleFunc("POST /v1/transit/sign/payout", func(w http.ResponseWriter, r *http.Request) {
+		server.signs.Add(1)
+		request := struct {
+			Input string `json:"input"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&request)
+		input, err := base64.StdEncoding.DecodeString(request.Input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJson(w, map[string]any{"errors": []string{"invalid input"}})
+			return
+		}
+		signature := ed25519.Sign(key, input)
+		writeJson(w, map[string]any{"data": map[string]any{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(signature)}})
+	})
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != server.token {
+			w.WriteHeader(http.StatusForbidden)
+			writeJson(w, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTransitSignerSign(t *testing.T) {
+	assert := assert.New(t)
+	server := newTransitTestServer(t, "test-token", 0)
+
+	transitSigner, err := InitTransitSignerFromSpecs(TransitSignerSpecs{Url: server.URL, Key: "payout", Token: "test-token"})
+	assert.NoError(err)
+	expectedKey := tezos.Key{Type: tezos.KeyTypeEd25519, Data: server.key.Public().(ed25519.PublicKey)}
+	assert.Equal(expectedKey.Address(), transitSigner.GetPKH())
+
+	op := codec.NewOp().WithSource(transitSigner.GetPKH()).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	op.WithTransfer(tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"), 1000000)
+	assert.NoError(transitSigner.Sign(op))
+	assert.NoError(transitSigner.GetKey().Verify(op.Digest(), op.Signature))
+	assert.Equal(int32(0), server.renewals.Load())
+}
+
+func TestTransitSignerRenewsToken(t *testing.T) {
+	assert := assert.New(t)
+	server := newTransitTestServer(t, "test-token", 60)
+
+	transitSigner, err := InitTransitSignerFromSpecs(TransitSignerSpecs{Url: server.URL, Key: "payout", Token: "test-token"})
+	assert.NoError(err)
+
+	op := codec.NewOp().WithSource(transitSigner.GetPKH()).WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	op.WithTransfer(tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM"), 1000000)
+	assert.NoError(transitSigner.Sign(op))
+	assert.NoError(transitSigner.Sign(op))
+	// token expiring within renewal window is renewed once and the new lease is long enough
+	assert.Equal(int32(1), server.renewals.Load())
+	assert.Equal(int32(2), server.signs.Load())
+}
+
+func TestTransitSignerInvalidToken(t *testing.T) {
+	server := newTransitTestServer(t, "test-token", 0)
+
+	_, err := InitTransitSignerFromSpecs(TransitSignerSpecs{Url: server.URL, Key: "payout", Token: "wrong"})
+	assert.ErrorContains(t, err, "permission denied")
+}
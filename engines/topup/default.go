@@ -0,0 +1,181 @@
+package topup_engines
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/tez-capital/tezpay/state"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type TopUpRecordStatus string
+
+const (
+	// records written before status tracking have empty status and were confirmed
+	TOP_UP_RECORD_STATUS_CONFIRMED TopUpRecordStatus = ""
+	TOP_UP_RECORD_STATUS_PENDING   TopUpRecordStatus = "pending"
+	TOP_UP_RECORD_STATUS_FAILED    TopUpRecordStatus = "failed"
+)
+
+type TopUpRecord struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Source      tezos.Address     `json:"source"`
+	Destination tezos.Address     `json:"destination"`
+	Amount      tezos.Z           `json:"amount"`
+	OpHash      tezos.OpHash      `json:"op_hash"`
+	Status      TopUpRecordStatus `json:"status,omitempty"`
+}
+
+type DefaultTopUpEngine struct {
+	configuration *configuration.RuntimeTopUp
+	signer        common.SignerEngine
+	transactor    common.TransactorEngine
+	logFile       string
+}
+
+func InitDefaultTopUpEngine(config *configuration.RuntimeTopUp, transactor common.TransactorEngine) (*DefaultTopUpEngine, error) {
+	signer, err := signer_engines.Load(config.Signer)
+	if err != nil {
+		return nil, errors.Join(constants.ErrSignerLoadFailed, fmt.Errorf("top up signer '%s'", config.Signer), err)
+	}
+	return &DefaultTopUpEngine{
+		configuration: config,
+		signer:        signer,
+		transactor:    transactor,
+		logFile:       state.Global.GetTopUpLogFilePath(),
+	}, nil
+}
+
+func (engine *DefaultTopUpEngine) GetId() string {
+	return "DefaultTopUpEngine"
+}
+
+func loadTopUpLog(logFile string) ([]TopUpRecord, error) {
+	records := make([]TopUpRecord, 0)
+	data, err := os.ReadFile(logFile)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, errors.Join(constants.ErrTopUpLogLoadFailed, err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Join(constants.ErrTopUpLogLoadFailed, err)
+	}
+	return records, nil
+}
+
+func writeTopUpLog(logFile string, records []TopUpRecord) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return errors.Join(constants.ErrTopUpLogWriteFailed, err)
+	}
+	tmpFile := logFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return errors.Join(constants.ErrTopUpLogWriteFailed, err)
+	}
+	if err := os.Rename(tmpFile, logFile); err != nil {
+		return errors.Join(constants.ErrTopUpLogWriteFailed, err)
+	}
+	return nil
+}
+
+// getTransferredOnDay sums top ups made during the UTC day of the given time, pending top ups
+// are counted as they may have been included even if confirmation was not observed
+func getTransferredOnDay(records []TopUpRecord, day time.Time) tezos.Z {
+	start := day.UTC().Truncate(24 * time.Hour)
+	end := start.Add(24 * time.Hour)
+	return lo.Reduce(records, func(agg tezos.Z, record TopUpRecord, _ int) tezos.Z {
+		if record.Status == TOP_UP_RECORD_STATUS_FAILED || record.Timestamp.Before(start) || !record.Timestamp.Before(end) {
+			return agg
+		}
+		return agg.Add(record.Amount)
+	}, tezos.Zero)
+}
+
+func checkTopUpLimits(config *configuration.RuntimeTopUp, amount tezos.Z, transferredToday tezos.Z) error {
+	if !config.MaximumPerTransfer.IsZero() && config.MaximumPerTransfer.IsLess(amount) {
+		return errors.Join(constants.ErrTopUpLimitExceeded, fmt.Errorf("amount %s exceeds maximum per transfer %s", common.MutezToTezS(amount.Int64()), common.MutezToTezS(config.MaximumPerTransfer.Int64())))
+	}
+	if config.DailyLimit.IsLess(transferredToday.Add(amount)) {
+		return errors.Join(constants.ErrTopUpLimitExceeded, fmt.Errorf("amount %s exceeds remaining daily limit %s", common.MutezToTezS(amount.Int64()), common.MutezToTezS(config.DailyLimit.Sub(transferredToday).Int64())))
+	}
+	return nil
+}
+
+func (engine *DefaultTopUpEngine) TopUp(destination tezos.Address, shortfall tezos.Z) (tezos.Z, error) {
+	amount := shortfall.Add(engine.configuration.Buffer)
+	records, err := loadTopUpLog(engine.logFile)
+	if err != nil {
+		return tezos.Zero, err
+	}
+	now := time.Now().UTC()
+	if err := checkTopUpLimits(engine.configuration, amount, getTransferredOnDay(records, now)); err != nil {
+		return tezos.Zero, err
+	}
+
+	source := engine.signer.GetPKH()
+	slog.Info("topping up payout wallet", "source", source.String(), "destination", destination.String(), "amount", common.MutezToTezS(amount.Int64()), "phase", "top_up")
+	op := codec.NewOp().WithSource(source)
+	op.WithTTL(constants.MAX_OPERATION_TTL)
+	op.WithTransfer(destination, amount.Int64())
+	if err := engine.transactor.Complete(op, engine.signer.GetKey()); err != nil {
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	}
+	if err := engine.signer.Sign(op); err != nil {
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	}
+
+	// record is persisted before injection so the transfer counts towards the daily limit even if confirmation fails
+	opHash := op.Hash()
+	records = append(records, TopUpRecord{
+		Timestamp:   now,
+		Source:      source,
+		Destination: destination,
+		Amount:      amount,
+		OpHash:      opHash,
+		Status:      TOP_UP_RECORD_STATUS_PENDING,
+	})
+	if err := writeTopUpLog(engine.logFile, records); err != nil {
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	}
+	setStatus := func(status TopUpRecordStatus) {
+		records[len(records)-1].Status = status
+		if err := writeTopUpLog(engine.logFile, records); err != nil {
+			slog.Error("failed to update top up record", "op_hash", opHash.String(), "status", status, "error", err.Error())
+		}
+	}
+
+	opts := rpc.DefaultOptions
+	opts.Confirmations = 1
+	result, err := engine.transactor.Dispatch(op, &opts)
+	if err != nil {
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	}
+	err = result.WaitForApply()
+	if err == nil {
+		err = result.WaitForFinality(constants.DEFAULT_REQUIRED_CONFIRMATIONS, func(state common.OperationConfirmationState, level int64) {})
+	}
+	switch {
+	case err == nil:
+		setStatus(TOP_UP_RECORD_STATUS_CONFIRMED)
+	case errors.Is(err, constants.ErrOperationFailed), errors.Is(err, constants.ErrOperationDroppedByReorg):
+		setStatus(TOP_UP_RECORD_STATUS_FAILED)
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	default:
+		// outcome is unknown, record stays pending and keeps counting towards the daily limit
+		return tezos.Zero, errors.Join(constants.ErrTopUpFailed, err)
+	}
+	return amount, nil
+}
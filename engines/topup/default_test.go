@@ -0,0 +1,125 @@
+package topup_engines
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/configuration"
+	"github.com/tez-capital/tezpay/constants"
+	signer_engines "github.com/tez-capital/tezpay/engines/signer"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func TestTopUpLimits(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	records := []TopUpRecord{
+		{Timestamp: now.Add(-13 * time.Hour), Amount: tezos.NewZ(500)},
+		{Timestamp: now.Add(-2 * time.Hour), Amount: tezos.NewZ(300)},
+		{Timestamp: now.Add(-1 * time.Hour), Amount: tezos.NewZ(200)},
+	}
+	transferred := getTransferredOnDay(records, now)
+	assert.Equal(int64(500), transferred.Int64())
+
+	config := &configuration.RuntimeTopUp{
+		DailyLimit:         tezos.NewZ(1000),
+		MaximumPerTransfer: tezos.NewZ(400),
+	}
+	assert.Nil(checkTopUpLimits(config, tezos.NewZ(400), transferred))
+	assert.ErrorIs(checkTopUpLimits(config, tezos.NewZ(401), transferred), constants.ErrTopUpLimitExceeded)
+	assert.ErrorIs(checkTopUpLimits(config, tezos.NewZ(300), tezos.NewZ(800)), constants.ErrTopUpLimitExceeded)
+
+	config.MaximumPerTransfer = tezos.Zero
+	assert.Nil(checkTopUpLimits(config, tezos.NewZ(500), transferred))
+}
+
+func TestTopUpLog(t *testing.T) {
+	assert := assert.New(t)
+	logFile := path.Join(t.TempDir(), "top_up_log.json")
+
+	records, err := loadTopUpLog(logFile)
+	assert.Nil(err)
+	assert.Empty(records)
+
+	records = append(records, TopUpRecord{Timestamp: time.Now().UTC(), Amount: tezos.NewZ(1000)})
+	assert.Nil(writeTopUpLog(logFile, records))
+	loaded, err := loadTopUpLog(logFile)
+	assert.Nil(err)
+	assert.Len(loaded, 1)
+	assert.Equal(int64(1000), loaded[0].Amount.Int64())
+}
+
+type topUpTestOpResult struct {
+	err error
+}
+
+func (result *topUpTestOpResult) GetOpHash() tezos.OpHash { return tezos.ZeroOpHash }
+func (result *topUpTestOpResult) WaitForApply() error     { return result.err }
+func (result *topUpTestOpResult) WaitForFinality(confirmations int64, onStateChange func(state common.OperationConfirmationState, level int64)) error {
+	return nil
+}
+
+type topUpTestTransactor struct {
+	common.TransactorEngine
+	dispatched int
+	err        error
+}
+
+func (transactor *topUpTestTransactor) Complete(op *codec.Op, key tezos.Key) error {
+	op.WithBranch(tezos.MustParseBlockHash("BM4VEjb3EGdgNgJhwfVUsUqPYvZWJUHdmKKgabuDkwy6SmUKDve"))
+	return nil
+}
+
+func (transactor *topUpTestTransactor) Dispatch(op *codec.Op, opts *rpc.CallOptions) (common.OpResult, error) {
+	transactor.dispatched++
+	return &topUpTestOpResult{err: transactor.err}, nil
+}
+
+func TestTopUpCountsUnconfirmedTransfers(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := tezos.GenerateKey(tezos.KeyTypeEd25519)
+	assert.Nil(err)
+	signer, err := signer_engines.InitInMemorySigner(key.String())
+	assert.Nil(err)
+	transactor := &topUpTestTransactor{err: errors.New("confirmation timed out")}
+	engine := &DefaultTopUpEngine{
+		configuration: &configuration.RuntimeTopUp{DailyLimit: tezos.NewZ(1000)},
+		signer:        signer,
+		transactor:    transactor,
+		logFile:       path.Join(t.TempDir(), "top_up_log.json"),
+	}
+	destination := tezos.MustParseAddress("tz1P6WKJu2rcbxKiKRZHKQKmKrpC9TfW1AwM")
+
+	_, err = engine.TopUp(destination, tezos.NewZ(600))
+	assert.ErrorIs(err, constants.ErrTopUpFailed)
+	records, err := loadTopUpLog(engine.logFile)
+	assert.Nil(err)
+	assert.Len(records, 1)
+	assert.Equal(TOP_UP_RECORD_STATUS_PENDING, records[0].Status)
+	assert.False(records[0].OpHash.Equal(tezos.ZeroOpHash))
+
+	t.Log("retry would exceed daily limit with pending transfer")
+	_, err = engine.TopUp(destination, tezos.NewZ(600))
+	assert.ErrorIs(err, constants.ErrTopUpLimitExceeded)
+	assert.Equal(1, transactor.dispatched)
+
+	t.Log("failed transfers do not count")
+	transactor.err = constants.ErrOperationFailed
+	_, err = engine.TopUp(destination, tezos.NewZ(300))
+	assert.ErrorIs(err, constants.ErrTopUpFailed)
+	transactor.err = nil
+	amount, err := engine.TopUp(destination, tezos.NewZ(400))
+	assert.Nil(err)
+	assert.Equal(int64(400), amount.Int64())
+	records, err = loadTopUpLog(engine.logFile)
+	assert.Nil(err)
+	assert.Equal([]TopUpRecordStatus{TOP_UP_RECORD_STATUS_PENDING, TOP_UP_RECORD_STATUS_FAILED, TOP_UP_RECORD_STATUS_CONFIRMED}, lo.Map(records, func(record TopUpRecord, _ int) TopUpRecordStatus { return record.Status }))
+}
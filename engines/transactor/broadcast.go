@@ -0,0 +1,107 @@
+package transactor_engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+// alreadyKnownErrorMarkers are error ids of nodes rejecting injection of operation already present in mempool.
+// Conflicts and other rejections mean the operation was not accepted and must not be matched.
+var alreadyKnownErrorMarkers = []string{
+	"already_known",
+	"already_in_mempool",
+}
+
+func isAlreadyKnownError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, marker := range alreadyKnownErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+type injectionTarget struct {
+	url    string
+	inject func(ctx context.Context) (tezos.OpHash, error)
+}
+
+type injectionResult struct {
+	url  string
+	hash tezos.OpHash
+	err  error
+}
+
+func newInjectionTargets(op *codec.Op, clients ...[]*rpc.Client) []injectionTarget {
+	targets := make([]injectionTarget, 0)
+	for _, group := range clients {
+		for _, client := range group {
+			targets = append(targets, injectionTarget{
+				url: client.BaseURL.String(),
+				inject: func(ctx context.Context) (tezos.OpHash, error) {
+					return client.Broadcast(ctx, op)
+				},
+			})
+		}
+	}
+	return targets
+}
+
+// fanOutInjection injects through all targets at once and returns the first successful hash.
+// Nodes which already know the operation are considered successful with the locally computed hash.
+// Results arriving after the first success are only logged.
+func fanOutInjection(ctx context.Context, localHash tezos.OpHash, targets []injectionTarget) (tezos.OpHash, error) {
+	if len(targets) == 0 {
+		return tezos.ZeroOpHash, errors.Join(constants.ErrOperationBroadcastFailed, errors.New("no injection targets"))
+	}
+
+	results := make(chan injectionResult, len(targets))
+	for _, target := range targets {
+		go func(target injectionTarget) {
+			hash, err := target.inject(ctx)
+			if err != nil && isAlreadyKnownError(err) {
+				hash, err = localHash, nil
+			}
+			results <- injectionResult{url: target.url, hash: hash, err: err}
+		}(target)
+	}
+
+	errs := make([]error, 0, len(targets))
+	for received := 0; received < len(targets); received++ {
+		result := <-results
+		if result.err != nil {
+			slog.Debug("injection failed", "rpc_url", result.url, "error", result.err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", result.url, result.err))
+			continue
+		}
+		if localHash.IsValid() && !result.hash.Equal(localHash) {
+			slog.Warn("injected operation hash does not match local hash", "rpc_url", result.url, "op_hash", result.hash, "local_op_hash", localHash)
+		}
+		go logLateInjectionResults(results, len(targets)-received-1, result.hash)
+		return result.hash, nil
+	}
+	return tezos.ZeroOpHash, errors.Join(constants.ErrOperationBroadcastFailed, errors.Join(errs...))
+}
+
+func logLateInjectionResults(results <-chan injectionResult, remaining int, accepted tezos.OpHash) {
+	for i := 0; i < remaining; i++ {
+		result := <-results
+		switch {
+		case result.err != nil:
+			slog.Debug("injection failed", "rpc_url", result.url, "op_hash", accepted, "error", result.err.Error())
+		case !result.hash.Equal(accepted):
+			slog.Warn("rpc disagrees on injected operation hash", "rpc_url", result.url, "op_hash", result.hash, "accepted_op_hash", accepted)
+		default:
+			slog.Debug("operation injected", "rpc_url", result.url, "op_hash", result.hash)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package transactor_engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var broadcastTestHash = tezos.NewOpHash([]byte("01234567890123456789012345678901"))
+
+func injectionTargetWithResult(url string, delay time.Duration, hash tezos.OpHash, err error) injectionTarget {
+	return injectionTarget{
+		url: url,
+		inject: func(ctx context.Context) (tezos.OpHash, error) {
+			time.Sleep(delay)
+			return hash, err
+		},
+	}
+}
+
+func TestFanOutInjection(t *testing.T) {
+	assert := assert.New(t)
+
+	hash, err := fanOutInjection(context.Background(), broadcastTestHash, []injectionTarget{
+		injectionTargetWithResult("a", 0, tezos.ZeroOpHash, errors.New("connection refused")),
+		injectionTargetWithResult("b", 10*time.Millisecond, broadcastTestHash, nil),
+		injectionTargetWithResult("c", time.Second, broadcastTestHash, nil),
+	})
+	assert.Nil(err)
+	assert.True(hash.Equal(broadcastTestHash))
+
+	hash, err = fanOutInjection(context.Background(), broadcastTestHash, []injectionTarget{
+		injectionTargetWithResult("a", 0, tezos.ZeroOpHash, errors.New(`[{"kind":"temporary","id":"prevalidation.operation_already_in_mempool"}]`)),
+	})
+	assert.Nil(err)
+	assert.True(hash.Equal(broadcastTestHash))
+
+	_, err = fanOutInjection(context.Background(), broadcastTestHash, []injectionTarget{
+		injectionTargetWithResult("a", 0, tezos.ZeroOpHash, errors.New("connection refused")),
+		injectionTargetWithResult("b", 0, tezos.ZeroOpHash, errors.New("counter in the past")),
+	})
+	assert.ErrorIs(err, constants.ErrOperationBroadcastFailed)
+
+	_, err = fanOutInjection(context.Background(), broadcastTestHash, []injectionTarget{
+		injectionTargetWithResult("a", 0, tezos.ZeroOpHash, errors.New(`[{"kind":"temporary","id":"prevalidation.operation_conflict"}]`)),
+	})
+	assert.ErrorIs(err, constants.ErrOperationBroadcastFailed)
+
+	_, err = fanOutInjection(context.Background(), broadcastTestHash, []injectionTarget{})
+	assert.ErrorIs(err, constants.ErrOperationBroadcastFailed)
+}
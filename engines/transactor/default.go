@@ -10,23 +10,28 @@ import (
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/configuration"
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/constants/enums"
 	"github.com/tez-capital/tezpay/engines/tzkt"
 	"github.com/tez-capital/tezpay/utils"
 	"github.com/trilitech/tzgo/codec"
+	"github.com/trilitech/tzgo/micheline"
 	"github.com/trilitech/tzgo/rpc"
 	"github.com/trilitech/tzgo/tezos"
 )
 
 type DefaultRpcTransactor struct {
-	rpc_urls []string
-	rpcs     []*rpc.Client
-	tzkt     *tzkt.Client
+	rpc_urls          []string
+	rpcs              []*rpc.Client
+	injection_clients []*rpc.Client
+	broadcast_mode    enums.EBroadcastMode
+	tzkt              *tzkt.Client
 }
 
 type DefaultRpcTransactorOpResult struct {
 	opHash tezos.OpHash
 	result *rpc.Result
 	rpc    *rpc.Client
+	rpcs   []*rpc.Client
 	tzkt   *tzkt.Client
 }
 
@@ -80,6 +85,28 @@ func (result *DefaultRpcTransactorOpResult) WaitForApply() error {
 	return rcpt.Error()
 }
 
+func (result *DefaultRpcTransactorOpResult) WaitForFinality(confirmations int64, onStateChange func(state common.OperationConfirmationState, level int64)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	utils.CallbackOnInterrupt(ctx, func() {
+		slog.Warn("waiting for finality canceled", "op_hash", result.opHash)
+		cancel()
+	})
+
+	level, block := int64(0), tezos.ZeroBlockHash
+	if rcpt, _ := result.result.GetReceipt(ctx); rcpt != nil {
+		level, block = rcpt.Height, rcpt.Block
+	}
+	tracker := &finalityTracker{
+		view:          &rpcChainView{rpcs: result.rpcs},
+		opHash:        result.opHash,
+		confirmations: confirmations,
+		pollInterval:  constants.OPERATION_CONFIRMATION_POLL_SECONDS * time.Second,
+		onStateChange: onStateChange,
+	}
+	return tracker.track(ctx, level, block)
+}
+
 func InitDefaultTransactor(config *configuration.RuntimeConfiguration) (*DefaultRpcTransactor, error) {
 	http_client := &http.Client{
 		Timeout: 10 * 60 * time.Second,
@@ -98,10 +125,22 @@ func InitDefaultTransactor(config *configuration.RuntimeConfiguration) (*Default
 		return nil, err
 	}
 
+	injection_clients := make([]*rpc.Client, 0, len(config.Network.InjectionEndpoints))
+	for _, url := range config.Network.InjectionEndpoints {
+		client, err := rpc.NewClient(url, http_client)
+		if err != nil {
+			slog.Warn("failed to create injection client", "rpc_url", url, "error", err.Error())
+			continue
+		}
+		injection_clients = append(injection_clients, client)
+	}
+
 	result := &DefaultRpcTransactor{
-		rpc_urls: config.Network.RpcPool,
-		rpcs:     rpc_clients,
-		tzkt:     tzktClient,
+		rpc_urls:          config.Network.RpcPool,
+		rpcs:              rpc_clients,
+		injection_clients: injection_clients,
+		broadcast_mode:    config.Network.BroadcastMode,
+		tzkt:              tzktClient,
 	}
 	return result, result.RefreshParams()
 }
@@ -176,11 +215,15 @@ func (transactor *DefaultRpcTransactor) initOpResult(opHash tezos.OpHash, opts *
 		opHash: opHash,
 		result: res,
 		rpc:    rpc_client,
+		rpcs:   transactor.rpcs,
 		tzkt:   transactor.tzkt,
 	}, nil
 }
 
 func (transactor *DefaultRpcTransactor) broadcast(op *codec.Op) (tezos.OpHash, error) {
+	if transactor.broadcast_mode == enums.BROADCAST_MODE_FAN_OUT {
+		return fanOutInjection(context.Background(), op.Hash(), newInjectionTargets(op, transactor.rpcs, transactor.injection_clients))
+	}
 	return utils.AttemptWithRpcClients(context.Background(), transactor.rpcs, func(client *rpc.Client) (tezos.OpHash, error) {
 		return client.Broadcast(context.Background(), op)
 	})
@@ -206,3 +249,25 @@ func (transactor *DefaultRpcTransactor) Send(op *codec.Op, opts *rpc.CallOptions
 		return client.Send(context.Background(), op, opts)
 	})
 }
+
+func (transactor *DefaultRpcTransactor) GetChainId() (tezos.ChainIdHash, error) {
+	return utils.AttemptWithRpcClients(context.Background(), transactor.rpcs, func(client *rpc.Client) (tezos.ChainIdHash, error) {
+		return client.GetChainId(context.Background())
+	})
+}
+
+func (transactor *DefaultRpcTransactor) GetContractStorage(contract tezos.Address) (micheline.Prim, error) {
+	return utils.AttemptWithRpcClients(context.Background(), transactor.rpcs, func(client *rpc.Client) (micheline.Prim, error) {
+		return client.GetContractStorage(context.Background(), contract, rpc.Head)
+	})
+}
+
+func (transactor *DefaultRpcTransactor) Simulate(op *codec.Op, key tezos.Key) (*rpc.Receipt, error) {
+	return utils.AttemptWithRpcClients(context.Background(), transactor.rpcs, func(client *rpc.Client) (*rpc.Receipt, error) {
+		op = op.WithParams(client.Params)
+		if err := client.Complete(context.Background(), op, key); err != nil {
+			return nil, err
+		}
+		return client.Simulate(context.Background(), op, nil)
+	})
+}
@@ -0,0 +1,146 @@
+package transactor_engines
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/utils"
+	"github.com/trilitech/tzgo/rpc"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+type chainView interface {
+	GetHeadLevel(ctx context.Context) (int64, error)
+	GetBlockHash(ctx context.Context, level int64) (tezos.BlockHash, error)
+	// FindOperation looks for the operation in blocks between fromLevel and head
+	FindOperation(ctx context.Context, opHash tezos.OpHash, fromLevel int64) (int64, tezos.BlockHash, bool, error)
+}
+
+type rpcChainView struct {
+	rpcs []*rpc.Client
+}
+
+func (view *rpcChainView) GetHeadLevel(ctx context.Context) (int64, error) {
+	return utils.AttemptWithRpcClients(ctx, view.rpcs, func(client *rpc.Client) (int64, error) {
+		header, err := client.GetBlockHeader(ctx, rpc.Head)
+		if err != nil {
+			return 0, err
+		}
+		return header.Level, nil
+	})
+}
+
+func (view *rpcChainView) GetBlockHash(ctx context.Context, level int64) (tezos.BlockHash, error) {
+	return utils.AttemptWithRpcClients(ctx, view.rpcs, func(client *rpc.Client) (tezos.BlockHash, error) {
+		return client.GetBlockHash(ctx, rpc.BlockLevel(level))
+	})
+}
+
+func (view *rpcChainView) FindOperation(ctx context.Context, opHash tezos.OpHash, fromLevel int64) (int64, tezos.BlockHash, bool, error) {
+	head, err := view.GetHeadLevel(ctx)
+	if err != nil {
+		return 0, tezos.ZeroBlockHash, false, err
+	}
+	for level := fromLevel; level <= head; level++ {
+		block, err := utils.AttemptWithRpcClients(ctx, view.rpcs, func(client *rpc.Client) (*rpc.Block, error) {
+			return client.GetBlockHeight(ctx, level)
+		})
+		if err != nil {
+			return 0, tezos.ZeroBlockHash, false, err
+		}
+		for _, list := range block.Operations {
+			for _, op := range list {
+				if op.Hash.Equal(opHash) {
+					return level, block.Hash, true, nil
+				}
+			}
+		}
+	}
+	return 0, tezos.ZeroBlockHash, false, nil
+}
+
+type finalityTracker struct {
+	view          chainView
+	opHash        tezos.OpHash
+	confirmations int64
+	pollInterval  time.Duration
+	onStateChange func(state common.OperationConfirmationState, level int64)
+}
+
+func (tracker *finalityTracker) notify(state common.OperationConfirmationState, level int64) {
+	slog.Debug("operation confirmation state changed", "op_hash", tracker.opHash, "state", state, "level", level)
+	if tracker.onStateChange != nil {
+		tracker.onStateChange(state, level)
+	}
+}
+
+// locate finds the operation in recent blocks, it may take a while for the operation to show up
+func (tracker *finalityTracker) locate(ctx context.Context, fromLevel int64, expiresAt int64) (int64, tezos.BlockHash, error) {
+	for ctx.Err() == nil {
+		level, hash, found, err := tracker.view.FindOperation(ctx, tracker.opHash, fromLevel)
+		if err != nil {
+			slog.Debug("failed to look up operation", "op_hash", tracker.opHash, "error", err.Error())
+		} else if found {
+			return level, hash, nil
+		}
+		head, err := tracker.view.GetHeadLevel(ctx)
+		if err == nil && head > expiresAt {
+			return 0, tezos.ZeroBlockHash, constants.ErrOperationDroppedByReorg
+		}
+		utils.SleepContext(ctx, tracker.pollInterval)
+	}
+	return 0, tezos.ZeroBlockHash, ctx.Err()
+}
+
+func (tracker *finalityTracker) track(ctx context.Context, level int64, block tezos.BlockHash) error {
+	if !block.IsValid() {
+		head, err := tracker.view.GetHeadLevel(ctx)
+		if err != nil {
+			return err
+		}
+		if level, block, err = tracker.locate(ctx, head-constants.MAX_OPERATION_TTL, head+constants.MAX_OPERATION_TTL); err != nil {
+			return err
+		}
+	}
+	tracker.notify(common.OPERATION_CONFIRMATION_STATE_INCLUDED, level)
+
+	confirmed := false
+	for ctx.Err() == nil {
+		head, headErr := tracker.view.GetHeadLevel(ctx)
+		current, hashErr := tracker.view.GetBlockHash(ctx, level)
+		if err := errors.Join(headErr, hashErr); err != nil {
+			slog.Debug("failed to check operation finality", "op_hash", tracker.opHash, "error", err.Error())
+			utils.SleepContext(ctx, tracker.pollInterval)
+			continue
+		}
+
+		if !current.Equal(block) {
+			slog.Warn("operation block was reorganized out of the chain, looking for the operation again", "op_hash", tracker.opHash, "level", level, "block", block)
+			tracker.notify(common.OPERATION_CONFIRMATION_STATE_REORGED, level)
+			var err error
+			// operation can not be included later than its ttl allows
+			if level, block, err = tracker.locate(ctx, level, head+constants.MAX_OPERATION_TTL); err != nil {
+				return err
+			}
+			confirmed = false
+			tracker.notify(common.OPERATION_CONFIRMATION_STATE_INCLUDED, level)
+			continue
+		}
+
+		depth := head - level + 1
+		if !confirmed && depth >= tracker.confirmations {
+			confirmed = true
+			tracker.notify(common.OPERATION_CONFIRMATION_STATE_CONFIRMED, level)
+		}
+		if confirmed && head-level >= constants.OPERATION_FINALITY_DEPTH {
+			tracker.notify(common.OPERATION_CONFIRMATION_STATE_FINALIZED, level)
+			return nil
+		}
+		utils.SleepContext(ctx, tracker.pollInterval)
+	}
+	return ctx.Err()
+}
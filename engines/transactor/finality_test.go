@@ -0,0 +1,101 @@
+package transactor_engines
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+func finalityTestBlockHash(level int64, fork int) tezos.BlockHash {
+	return tezos.NewBlockHash([]byte(fmt.Sprintf("%032d", level*10+int64(fork))))
+}
+
+// finalityTestChain grows by one block on every head check, reorgs are scripted by onHead
+type finalityTestChain struct {
+	head    int64
+	fork    map[int64]int
+	opLevel int64
+	onHead  func(chain *finalityTestChain)
+}
+
+func (chain *finalityTestChain) GetHeadLevel(ctx context.Context) (int64, error) {
+	chain.head++
+	if chain.onHead != nil {
+		chain.onHead(chain)
+	}
+	return chain.head, nil
+}
+
+func (chain *finalityTestChain) GetBlockHash(ctx context.Context, level int64) (tezos.BlockHash, error) {
+	return finalityTestBlockHash(level, chain.fork[level]), nil
+}
+
+func (chain *finalityTestChain) FindOperation(ctx context.Context, opHash tezos.OpHash, fromLevel int64) (int64, tezos.BlockHash, bool, error) {
+	if chain.opLevel == 0 || chain.opLevel < fromLevel || chain.opLevel > chain.head {
+		return 0, tezos.ZeroBlockHash, false, nil
+	}
+	return chain.opLevel, finalityTestBlockHash(chain.opLevel, chain.fork[chain.opLevel]), true, nil
+}
+
+func trackFinality(chain *finalityTestChain, confirmations int64) ([]common.OperationConfirmationState, error) {
+	states := make([]common.OperationConfirmationState, 0)
+	tracker := &finalityTracker{
+		view:          chain,
+		confirmations: confirmations,
+		onStateChange: func(state common.OperationConfirmationState, level int64) {
+			states = append(states, state)
+		},
+	}
+	err := tracker.track(context.Background(), chain.opLevel, finalityTestBlockHash(chain.opLevel, 0))
+	return states, err
+}
+
+func TestFinalityTracking(t *testing.T) {
+	assert := assert.New(t)
+
+	chain := &finalityTestChain{head: 100, fork: map[int64]int{}, opLevel: 100}
+	states, err := trackFinality(chain, 4)
+	assert.Nil(err)
+	assert.Equal([]common.OperationConfirmationState{
+		common.OPERATION_CONFIRMATION_STATE_INCLUDED,
+		common.OPERATION_CONFIRMATION_STATE_CONFIRMED,
+		common.OPERATION_CONFIRMATION_STATE_FINALIZED,
+	}, states)
+	assert.Equal(int64(103), chain.head)
+
+	// operation block replaced, operation re-included one level later
+	chain = &finalityTestChain{head: 100, fork: map[int64]int{}, opLevel: 100, onHead: func(chain *finalityTestChain) {
+		if chain.head == 101 {
+			chain.fork[100] = 1
+			chain.opLevel = 102
+		}
+	}}
+	states, err = trackFinality(chain, 1)
+	assert.Nil(err)
+	assert.Equal([]common.OperationConfirmationState{
+		common.OPERATION_CONFIRMATION_STATE_INCLUDED,
+		common.OPERATION_CONFIRMATION_STATE_REORGED,
+		common.OPERATION_CONFIRMATION_STATE_INCLUDED,
+		common.OPERATION_CONFIRMATION_STATE_CONFIRMED,
+		common.OPERATION_CONFIRMATION_STATE_FINALIZED,
+	}, states)
+
+	// operation block replaced and operation never included again
+	chain = &finalityTestChain{head: 100, fork: map[int64]int{}, opLevel: 100, onHead: func(chain *finalityTestChain) {
+		if chain.head == 101 {
+			chain.fork[100] = 1
+			chain.opLevel = 0
+		}
+	}}
+	states, err = trackFinality(chain, 1)
+	assert.ErrorIs(err, constants.ErrOperationDroppedByReorg)
+	assert.Equal([]common.OperationConfirmationState{
+		common.OPERATION_CONFIRMATION_STATE_INCLUDED,
+		common.OPERATION_CONFIRMATION_STATE_REORGED,
+	}, states)
+}
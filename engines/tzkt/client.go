@@ -316,12 +316,13 @@ func (client *Client) GetCycleData(ctx context.Context, chainId tezos.ChainIdHas
 
 	precision := int64(10000)
 
-	var blockDelegatedRewards, endorsingDelegatedRewards, delegationShare tezos.Z
+	var blockDelegatedRewards, endorsingDelegatedRewards, delegationShare, externalStakingShare tezos.Z
 	firstAiActivatedCycle := constants.FIRST_PARIS_AI_ACTIVATED_CYCLE
 	if cycle >= firstAiActivatedCycle || chainId == tezos.Ghostnet {
 		blockDelegatedRewards = tezos.NewZ(tzktBakerCycleData.BlockRewardsDelegated)
 		endorsingDelegatedRewards = tezos.NewZ(tzktBakerCycleData.EndorsementRewardsDelegated)
 		delegationShare = tezos.NewZ(tzktBakerCycleData.BakingPower - tzktBakerCycleData.OwnStakedBalance - tzktBakerCycleData.ExternalStakedBalance).Mul64(precision).Div64(tzktBakerCycleData.BakingPower)
+		externalStakingShare = tezos.NewZ(tzktBakerCycleData.ExternalStakedBalance).Mul64(precision).Div64(tzktBakerCycleData.BakingPower)
 	} else {
 		blockDelegatedRewards = tezos.NewZ(tzktBakerCycleData.BlockRewardsLiquid).Add64(tzktBakerCycleData.BlockRewardsStakedOwn)
 		endorsingDelegatedRewards = tezos.NewZ(tzktBakerCycleData.EndorsementRewardsLiquid).Add64(tzktBakerCycleData.EndorsementRewardsStakedOwn)
@@ -331,6 +332,7 @@ func (client *Client) GetCycleData(ctx context.Context, chainId tezos.ChainIdHas
 
 	blockDelegatedFees := delegationShare.Mul64(tzktBakerCycleData.BlockFees).Div64(precision)
 	blockStakingFees := tezos.NewZ(tzktBakerCycleData.BlockFees).Sub(blockDelegatedFees)
+	blockExternalStakingFees := externalStakingShare.Mul64(tzktBakerCycleData.BlockFees).Div64(precision)
 
 	if client.balanceCheckMode == enums.PROTOCOL_BALANCE_CHECK_MODE {
 		protocolRewardsCycleData, err := client.getProtocolRewardsCycleData(ctx, bakerAddr, cycle)
@@ -448,6 +450,7 @@ func (client *Client) GetCycleData(ctx context.Context, chainId tezos.ChainIdHas
 		BlockStakingRewardsEdge:       tezos.NewZ(tzktBakerCycleData.BlockRewardsStakedEdge),
 		EndorsementStakingRewardsEdge: tezos.NewZ(tzktBakerCycleData.EndorsementRewardsStakedEdge),
 		BlockStakingFees:              blockStakingFees,
+		BlockExternalStakingFees:      blockExternalStakingFees,
 
 		FrozenDepositLimit: tezos.NewZ(tzktBakerData.FrozenDepositLimit),
 		Delegators: lo.Map(collectedDelegators, func(delegator splitDelegator, _ int) common.Delegator {
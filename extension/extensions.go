@@ -12,6 +12,7 @@ import (
 	"github.com/tez-capital/tezpay/common"
 	"github.com/tez-capital/tezpay/constants"
 	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/tez-capital/tezpay/metrics"
 )
 
 type ExtensionStoreEnviromnent struct {
@@ -104,6 +105,7 @@ func ExecuteHook[TData rpc.ResultType](hook enums.EExtensionHook, version string
 		}
 
 		var err error
+		hookStart := time.Now()
 		for i := 0; i < def.GetRetry(); i++ {
 			if i > 0 {
 				time.Sleep(time.Second * time.Duration(def.GetRetryDelay()))
@@ -115,6 +117,7 @@ func ExecuteHook[TData rpc.ResultType](hook enums.EExtensionHook, version string
 			case enums.EXTENSION_HOOK_MODE_READ_WRITE:
 				err = LoadExtension(ext)
 				if err != nil {
+					metrics.ExtensionHookErrors.Inc(def.Name, string(hook))
 					if ext.GetDefinition().Name != "" {
 						return fmt.Errorf("failed to load extension %s: %w", ext.GetDefinition().Name, err)
 					}
@@ -163,6 +166,12 @@ func ExecuteHook[TData rpc.ResultType](hook enums.EExtensionHook, version string
 				break
 			}
 		}
+		if matchedMode == enums.EXTENSION_HOOK_MODE_READ_ONLY || matchedMode == enums.EXTENSION_HOOK_MODE_READ_WRITE {
+			metrics.ExtensionHookDuration.Observe(time.Since(hookStart).Seconds(), def.Name, string(hook))
+			if err != nil {
+				metrics.ExtensionHookErrors.Inc(def.Name, string(hook))
+			}
+		}
 		if err != nil {
 			switch def.ErrorAction {
 			case enums.EXTENSION_ERROR_ACTION_CONTINUE:
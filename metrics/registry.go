@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minimal prometheus text exposition, we only need a handful of metrics
+// so we do not pull in whole client library
+
+var (
+	DefaultBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+)
+
+type metric interface {
+	getName() string
+	write(w io.Writer)
+}
+
+type Registry struct {
+	metrics []metric
+	mtx     sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes all registered metrics in prometheus text format
+func (r *Registry) Write(w io.Writer) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	for _, m := range r.metrics {
+		m.write(w)
+	}
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func formatLabels(names []string, values []string, extra ...string) string {
+	pairs := make([]string, 0, len(names)+len(extra)/2)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, values[i]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", extra[i], extra[i+1]))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+type descriptor struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+func (d *descriptor) getName() string {
+	return d.name
+}
+
+func (d *descriptor) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, d.help, d.name, d.kind)
+}
+
+// series keeps values per label combination
+type series[T any] struct {
+	descriptor
+	values map[string]*T
+	labels map[string][]string
+	mtx    sync.Mutex
+}
+
+func (s *series[T]) get(labelValues []string, create func() *T) *T {
+	if len(labelValues) != len(s.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d labels, got %d", s.name, len(s.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if value, ok := s.values[key]; ok {
+		return value
+	}
+	value := create()
+	s.values[key] = value
+	s.labels[key] = slices.Clone(labelValues)
+	return value
+}
+
+func (s *series[T]) sortedKeys() []string {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func newSeries[T any](name, help, kind string, labelNames []string) series[T] {
+	return series[T]{
+		descriptor: descriptor{name: name, help: help, kind: kind, labelNames: labelNames},
+		values:     make(map[string]*T),
+		labels:     make(map[string][]string),
+	}
+}
+
+type Gauge struct {
+	series[float64]
+}
+
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	g := &Gauge{series: newSeries[float64](name, help, "gauge", labelNames)}
+	r.register(g)
+	return g
+}
+
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	v := g.get(labelValues, func() *float64 { return new(float64) })
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	*v = value
+}
+
+func (g *Gauge) write(w io.Writer) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if len(g.values) == 0 {
+		return
+	}
+	g.writeHeader(w)
+	for _, key := range g.sortedKeys() {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, formatLabels(g.labelNames, g.labels[key]), formatValue(*g.values[key]))
+	}
+}
+
+// GaugeFunc is evaluated on each scrape, it is not written if the function reports no value
+type GaugeFunc struct {
+	descriptor
+	f func() (float64, bool)
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, f func() (float64, bool)) *GaugeFunc {
+	g := &GaugeFunc{descriptor: descriptor{name: name, help: help, kind: "gauge"}, f: f}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	value, ok := g.f()
+	if !ok {
+		return
+	}
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.name, formatValue(value))
+}
+
+type Counter struct {
+	series[float64]
+}
+
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{series: newSeries[float64](name, help, "counter", labelNames)}
+	r.register(c)
+	return c
+}
+
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		panic(fmt.Sprintf("counter %s can not decrease", c.name))
+	}
+	v := c.get(labelValues, func() *float64 { return new(float64) })
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	*v += value
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.values) == 0 {
+		return
+	}
+	c.writeHeader(w)
+	for _, key := range c.sortedKeys() {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labelNames, c.labels[key]), formatValue(*c.values[key]))
+	}
+}
+
+type histogramValue struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type Histogram struct {
+	series[histogramValue]
+	buckets []float64
+}
+
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	h := &Histogram{series: newSeries[histogramValue](name, help, "histogram", labelNames), buckets: buckets}
+	r.register(h)
+	return h
+}
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	v := h.get(labelValues, func() *histogramValue { return &histogramValue{counts: make([]uint64, len(h.buckets))} })
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for i, bound := range h.buckets {
+		if value <= bound {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if len(h.values) == 0 {
+		return
+	}
+	h.writeHeader(w)
+	for _, key := range h.sortedKeys() {
+		labels, value := h.labels[key], h.values[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labelNames, labels, "le", formatValue(bound)), value.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labelNames, labels, "le", "+Inf"), value.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labelNames, labels), formatValue(value.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, labels), value.count)
+	}
+}
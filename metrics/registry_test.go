@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewRegistry()
+	gauge := registry.NewGauge("test_gauge", "test gauge", "node")
+	counter := registry.NewCounter("test_counter", "test counter")
+	histogram := registry.NewHistogram("test_duration_seconds", "test histogram", []float64{1, .5}, "hook")
+	registry.NewCounter("test_unused", "never written")
+
+	gauge.Set(10, "b")
+	gauge.Set(5, "a")
+	gauge.Set(7, "a")
+	counter.Inc()
+	counter.Add(2)
+	histogram.Observe(.3, "after_payouts")
+	histogram.Observe(.7, "after_payouts")
+	histogram.Observe(3, "after_payouts")
+
+	var buffer bytes.Buffer
+	registry.Write(&buffer)
+	output := buffer.String()
+
+	assert.Contains(output, "# TYPE test_gauge gauge\ntest_gauge{node=\"a\"} 7\ntest_gauge{node=\"b\"} 10\n")
+	assert.Contains(output, "# TYPE test_counter counter\ntest_counter 3\n")
+	assert.Contains(output, "test_duration_seconds_bucket{hook=\"after_payouts\",le=\"0.5\"} 1\n")
+	assert.Contains(output, "test_duration_seconds_bucket{hook=\"after_payouts\",le=\"1\"} 2\n")
+	assert.Contains(output, "test_duration_seconds_bucket{hook=\"after_payouts\",le=\"+Inf\"} 3\n")
+	assert.Contains(output, "test_duration_seconds_sum{hook=\"after_payouts\"} 4\n")
+	assert.False(strings.Contains(output, "test_unused"))
+
+	assert.Panics(func() { gauge.Set(1) })
+	assert.Panics(func() { counter.Add(-1) })
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"bytes"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	METRICS_PATH         = "/metrics"
+	METRICS_CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+func Handler(c *fiber.Ctx) error {
+	var buffer bytes.Buffer
+	Default.Write(&buffer)
+	c.Set(fiber.HeaderContentType, METRICS_CONTENT_TYPE)
+	return c.Send(buffer.Bytes())
+}
+
+// StartServer launches standalone metrics server at specified address
+func StartServer(address string) {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Get(METRICS_PATH, Handler)
+
+	go func() {
+		slog.Info("starting metrics server", "address", address)
+		if err := app.Listen(address); err != nil {
+			slog.Error("metrics server failed", "error", err.Error())
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	BATCH_RESULT_SUCCESS = "success"
+	BATCH_RESULT_FAILURE = "failure"
+)
+
+var (
+	Default = NewRegistry()
+
+	LastProcessedCycle = Default.NewGauge("tezpay_last_processed_cycle",
+		"last cycle processed in continual mode")
+	LastProcessedCycleTimestamp = Default.NewGauge("tezpay_last_processed_cycle_timestamp_seconds",
+		"unix time when last cycle was processed in continual mode")
+	OnchainCompletedCycle = Default.NewGauge("tezpay_onchain_completed_cycle",
+		"last completed cycle on chain as seen by tezpay")
+	PayoutWalletBalance = Default.NewGauge("tezpay_payout_wallet_balance_mutez",
+		"balance of the payout wallet in mutez", "wallet")
+	Batches = Default.NewCounter("tezpay_batches_total",
+		"number of executed payout batches by result", "result")
+	SimulationDuration = Default.NewHistogram("tezpay_simulation_duration_seconds",
+		"duration of operation simulations", DefaultBuckets)
+	DispatchDuration = Default.NewHistogram("tezpay_dispatch_duration_seconds",
+		"duration of batch broadcasts", DefaultBuckets)
+	RpcFailovers = Default.NewCounter("tezpay_rpc_failovers_total",
+		"number of times rpc node was skipped because it was out of sync or failed", "node")
+	ExtensionHookDuration = Default.NewHistogram("tezpay_extension_hook_duration_seconds",
+		"duration of extension hook executions", DefaultBuckets, "extension", "hook")
+	ExtensionHookErrors = Default.NewCounter("tezpay_extension_hook_errors_total",
+		"number of failed extension hook executions", "extension", "hook")
+
+	nextCycleExpectedAt atomic.Int64
+	_                   = Default.NewGaugeFunc("tezpay_seconds_until_next_cycle",
+		"estimated time until the next cycle starts", func() (float64, bool) {
+			at := nextCycleExpectedAt.Load()
+			if at == 0 {
+				return 0, false
+			}
+			return max(time.Until(time.Unix(at, 0)).Seconds(), 0), true
+		})
+)
+
+// SetNextCycleExpectedAt records when the next cycle is expected to start
+func SetNextCycleExpectedAt(at time.Time) {
+	nextCycleExpectedAt.Store(at.Unix())
+}
+
+// SetLastProcessedCycle records processed cycle together with the time it was processed at
+func SetLastProcessedCycle(cycle int64) {
+	LastProcessedCycle.Set(float64(cycle))
+	LastProcessedCycleTimestamp.Set(float64(time.Now().Unix()))
+}
+
+// ObserveDuration returns function observing time elapsed since call to the histogram
+func ObserveDuration(histogram *Histogram, labelValues ...string) func() {
+	start := time.Now()
+	return func() {
+		histogram.Observe(time.Since(start).Seconds(), labelValues...)
+	}
+}
@@ -11,10 +11,13 @@ import (
 )
 
 var (
-	Global                 *State
-	CONFIG_FILE_NAME       = "config.hjson"
-	PRIVATE_KEY_FILE_NAME  = "payout_wallet_private.key"
-	REMOTE_SPECS_FILE_NAME = "remote_signer.hjson"
+	Global                  *State
+	CONFIG_FILE_NAME        = "config.hjson"
+	PRIVATE_KEY_FILE_NAME   = "payout_wallet_private.key"
+	REMOTE_SPECS_FILE_NAME  = "remote_signer.hjson"
+	TRANSIT_SPECS_FILE_NAME = "transit_signer.hjson"
+	TOP_UP_LOG_FILE_NAME    = "top_up_log.json"
+	PRICE_CACHE_FILE_NAME   = "price_cache.json"
 )
 
 type StateInitOptions struct {
@@ -116,6 +119,30 @@ func (state *State) GetRemoteSpecsFilePath() string {
 	return path.Join(state.GetWorkingDirectory(), REMOTE_SPECS_FILE_NAME)
 }
 
+func (state *State) GetTransitSpecsFilePath() string {
+	transitSpecsConfigurationFile := os.Getenv("TRANSIT_SIGNER_CONFIGURATION_FILE")
+	if transitSpecsConfigurationFile != "" {
+		return transitSpecsConfigurationFile
+	}
+	return path.Join(state.GetWorkingDirectory(), TRANSIT_SPECS_FILE_NAME)
+}
+
+func (state *State) GetTopUpLogFilePath() string {
+	topUpLogFile := os.Getenv("TOP_UP_LOG_FILE")
+	if topUpLogFile != "" {
+		return topUpLogFile
+	}
+	return path.Join(state.GetWorkingDirectory(), TOP_UP_LOG_FILE_NAME)
+}
+
+func (state *State) GetPriceCacheFilePath() string {
+	priceCacheFile := os.Getenv("PRICE_CACHE_FILE")
+	if priceCacheFile != "" {
+		return priceCacheFile
+	}
+	return path.Join(state.GetWorkingDirectory(), PRICE_CACHE_FILE_NAME)
+}
+
 func (state *State) GetPayOnlyAddressPrefix() string {
 	return state.payOnlyAddressPrefix
 }
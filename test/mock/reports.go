@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/tez-capital/tezpay/common"
+	"github.com/tez-capital/tezpay/constants/enums"
+	"github.com/trilitech/tzgo/tezos"
+)
+
+var PayoutReportTimestamp = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+// NewPayoutReport builds report of successful tez reward paid to random delegator, tests adjust fields they need
+func NewPayoutReport(cycle int64, amount int64) common.PayoutReport {
+	delegator := GetRandomAddress()
+	return common.PayoutReport{
+		Timestamp: PayoutReportTimestamp,
+		Cycle:     cycle,
+		Kind:      enums.PAYOUT_KIND_DELEGATOR_REWARD,
+		TxKind:    enums.PAYOUT_TX_KIND_TEZ,
+		Delegator: delegator,
+		Recipient: delegator,
+		Amount:    tezos.NewZ(amount),
+		IsSuccess: true,
+	}
+}
@@ -26,6 +26,7 @@ type SimpleCollectorOpts struct {
 	FailWithReceiptError  error
 	ReturnOnlyNCosts      int
 	SerializationGasLimit int64
+	MempoolFeeLevels      *common.MempoolFeeLevels
 }
 
 func InitSimpleColletor() *SimpleColletor {
@@ -199,6 +200,29 @@ func (engine *SimpleColletor) GetBalance(addr tezos.Address) (tezos.Z, error) {
 
 func (engine *SimpleColletor) SendAnalytics(bakerId string, version string) {}
 
+func (engine *SimpleColletor) GetMempoolFeeLevels() (*common.MempoolFeeLevels, error) {
+	if engine.opts.MempoolFeeLevels != nil {
+		return engine.opts.MempoolFeeLevels, nil
+	}
+	return &common.MempoolFeeLevels{
+		MinimalFees:              constants.PROTOCOL_MINIMAL_FEE_MUTEZ,
+		MinimalNanotezPerGasUnit: float64(constants.PROTOCOL_MINIMAL_NANOTEZ_PER_GAS),
+		MinimalNanotezPerByte:    1000,
+	}, nil
+}
+
+func (engine *SimpleColletor) GetWalletStatus(addr tezos.Address) (*common.WalletStatus, error) {
+	balance, err := engine.GetBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &common.WalletStatus{
+		Address:    addr,
+		Balance:    balance,
+		IsRevealed: true,
+	}, nil
+}
+
 func (engine *SimpleColletor) GetCurrentProtocol() (tezos.ProtocolHash, error) {
 	return tezos.ZeroProtocolHash, nil
 }
@@ -197,18 +197,53 @@ func PrintCycleSummary(summary common.CyclePayoutSummary, header string) {
 	summaryTable.Style().Title.Align = text.AlignCenter
 	summaryTable.AppendRow(table.Row{"Earned Fees", common.MutezToTezS(summary.EarnedFees.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Earned Rewards", common.MutezToTezS(summary.EarnedRewards.Int64())}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Earned Staking Edge", common.MutezToTezS(summary.EarnedStakingEdge.Int64())}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Missed Rewards", common.MutezToTezS(summary.MissedRewards.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Distributed Rewards", common.MutezToTezS(summary.DistributedRewards.Int64())}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Staker Bonuses", common.MutezToTezS(summary.StakerBonuses.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendSeparator()
 	summaryTable.AppendRow(table.Row{"Donated Bonds", common.MutezToTezS(summary.DonatedBonds.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Donated Fees", common.MutezToTezS(summary.DonatedFees.Int64())}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Donated Staking Edge", common.MutezToTezS(summary.DonatedStakingEdge.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Donated Total", common.MutezToTezS(summary.DonatedTotal.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendSeparator()
 	summaryTable.AppendRow(table.Row{"Bond Income", common.MutezToTezS(summary.BondIncome.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Fee Income", common.MutezToTezS(summary.FeeIncome.Int64())}, table.RowConfig{AutoMerge: false})
+	summaryTable.AppendRow(table.Row{"Staking Edge Income", common.MutezToTezS(summary.StakingEdgeIncome.Int64())}, table.RowConfig{AutoMerge: false})
 	summaryTable.AppendRow(table.Row{"Income Total", common.MutezToTezS(summary.IncomeTotal.Int64())}, table.RowConfig{AutoMerge: false})
+	if summary.AutoStake != nil {
+		summaryTable.AppendSeparator()
+		summaryTable.AppendRow(table.Row{"Staked Income", common.MutezToTezS(summary.AutoStake.Amount.Int64())}, table.RowConfig{AutoMerge: false})
+	}
 	summaryTable.Render()
 }
 
+func PrintInvalidPayoutsBreakdowns(breakdowns []common.InvalidPayoutsBreakdown, header string) {
+	if len(breakdowns) == 0 {
+		return
+	}
+	PrintTable(breakdowns[0].GetTableHeaders(), lo.Map(breakdowns, func(breakdown common.InvalidPayoutsBreakdown, _ int) []string {
+		return breakdown.ToTableRowData()
+	}), header)
+}
+
+func PrintTable(headers []string, rows [][]string, header string) {
+	if len(rows) == 0 {
+		return
+	}
+	dataTable := table.NewWriter()
+	dataTable.SetStyle(table.StyleLight)
+	dataTable.SetColumnConfigs([]table.ColumnConfig{{Number: 1, Align: text.AlignLeft}})
+	dataTable.SetOutputMirror(os.Stdout)
+	dataTable.SetTitle(header)
+	dataTable.Style().Title.Align = text.AlignCenter
+	dataTable.AppendHeader(columnsAsInterfaces(headers), table.RowConfig{AutoMerge: true})
+	for _, row := range rows {
+		dataTable.AppendRow(columnsAsInterfaces(row), table.RowConfig{AutoMerge: false})
+	}
+	dataTable.Render()
+}
+
 func PrintBatchResults(results []common.BatchResult, header string, explorerUrl string) {
 	if len(results) == 0 {
 		return
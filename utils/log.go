@@ -16,6 +16,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 )
 
 type PrettyHandlerOptions struct {
@@ -179,7 +180,7 @@ func (s *LogServer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-func NewLogServer(address string) *LogServer {
+func NewLogServer(address string, serveMetrics bool) *LogServer {
 	logServer := &LogServer{
 		clients:   make(map[uuid.UUID]logServerClient),
 		clientMtx: sync.RWMutex{},
@@ -237,6 +238,10 @@ func NewLogServer(address string) *LogServer {
 		return nil
 	})
 
+	if serveMetrics {
+		app.Get(metrics.METRICS_PATH, metrics.Handler)
+	}
+
 	go func() {
 		slog.Info("starting log server", "address", address)
 		err := app.Listen(address)
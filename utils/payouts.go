@@ -165,3 +165,9 @@ func FilterRecipesByReports(payouts []common.PayoutRecipe, reports []common.Payo
 		return !ok
 	}), lo.Values(paidOut)
 }
+
+// FilterRecipesByBakerReports filters out payouts already paid according to reports of the baker,
+// shared by payout preparation and broadcasting of pre-built payouts to keep them in sync
+func FilterRecipesByBakerReports(payouts []common.PayoutRecipe, reports []common.PayoutReport, baker tezos.Address, collector common.CollectorEngine) ([]common.PayoutRecipe, []common.PayoutReport) {
+	return FilterRecipesByReports(payouts, FilterReportsByBaker(reports, baker), collector)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/tez-capital/tezpay/constants"
+	"github.com/tez-capital/tezpay/metrics"
 	"github.com/trilitech/tzgo/rpc"
 )
 
@@ -72,12 +73,14 @@ func AttemptWithRpcClients[T any](ctx context.Context, clients []*rpc.Client, f
 	var result T
 	for _, client := range clients {
 		if !isClientSynced(ctx, client) {
+			metrics.RpcFailovers.Inc(client.BaseURL.Host)
 			continue
 		}
 		slog.Debug("attempting with client", "client", client.BaseURL.Host)
 
 		result, err = f(client)
 		if err != nil {
+			metrics.RpcFailovers.Inc(client.BaseURL.Host)
 			continue
 		}
 		return result, nil
@@ -89,6 +92,7 @@ func AttemptWithRpcClients[T any](ctx context.Context, clients []*rpc.Client, f
 func GetFirstSyncedRpc(ctx context.Context, clients []*rpc.Client) (*rpc.Client, error) {
 	for _, client := range clients {
 		if !isClientSynced(ctx, client) {
+			metrics.RpcFailovers.Inc(client.BaseURL.Host)
 			continue
 		}
 		return client, nil